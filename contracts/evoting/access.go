@@ -0,0 +1,136 @@
+package evoting
+
+import (
+	accessContract "go.dedis.ch/dela/contracts/access"
+	"go.dedis.ch/dela/core/access"
+	darctypes "go.dedis.ch/dela/core/access/darc/types"
+	"go.dedis.ch/dela/core/store"
+	"go.dedis.ch/dela/core/store/prefixed"
+	"go.dedis.ch/dela/serde"
+	"golang.org/x/xerrors"
+
+	// Register the JSON format for the permissions of the access contract
+	_ "go.dedis.ch/dela/core/access/darc/json"
+)
+
+// AccessService is the access control service of the evoting contract. On top
+// of matching and granting credentials, it revokes them.
+type AccessService interface {
+	access.Service
+
+	// Granted returns true if the credential has been granted to some
+	// identities.
+	Granted(store store.Readable, creds access.Credential) (bool, error)
+
+	// Revoke updates the store so that the group of identities doesn't match
+	// the credentials anymore. The last group of a credential can't be
+	// revoked.
+	Revoke(store store.Snapshot, creds access.Credential, idents ...access.Identity) error
+}
+
+// darcService implements the revocation on top of the DARC service, which
+// only grants.
+//
+// - implements AccessService
+type darcService struct {
+	access.Service
+
+	context serde.Context
+	fac     darctypes.PermissionFactory
+}
+
+// NewAccessService returns the access service of the contract on top of the
+// service of the access contract. It reads and writes the permissions where
+// the access contract stores them, so that grants and revocations apply to
+// the same permissions.
+func NewAccessService(srvc access.Service, ctx serde.Context) AccessService {
+	return darcService{
+		Service: srvc,
+		context: ctx,
+		fac:     darctypes.NewFactory(),
+	}
+}
+
+// Granted implements AccessService.
+func (srvc darcService) Granted(store store.Readable, creds access.Credential) (bool, error) {
+	store = prefixed.NewReadable(accessContract.ContractUID, store)
+
+	value, err := store.Get(creds.GetID())
+	if err != nil {
+		return false, xerrors.Errorf("failed to get permission: %v", err)
+	}
+
+	if len(value) == 0 {
+		return false, nil
+	}
+
+	rules, err := srvc.rulesOf(value)
+	if err != nil {
+		return false, err
+	}
+
+	_, found := rules.GetRules()[creds.GetRule()]
+
+	return found, nil
+}
+
+// Revoke implements AccessService.
+func (srvc darcService) Revoke(store store.Snapshot, creds access.Credential,
+	idents ...access.Identity) error {
+
+	store = prefixed.NewSnapshot(accessContract.ContractUID, store)
+
+	value, err := store.Get(creds.GetID())
+	if err != nil {
+		return xerrors.Errorf("failed to get permission: %v", err)
+	}
+
+	if len(value) == 0 {
+		return xerrors.Errorf("permission %#x not found", creds.GetID())
+	}
+
+	perm, err := srvc.rulesOf(value)
+	if err != nil {
+		return err
+	}
+
+	err = perm.Match(creds.GetRule(), idents...)
+	if err != nil {
+		return xerrors.Errorf("the credential is not granted: %v", err)
+	}
+
+	perm.Deny(creds.GetRule(), idents...)
+
+	// the rule is removed with its last group
+	_, found := perm.GetRules()[creds.GetRule()]
+	if !found {
+		return xerrors.Errorf("the last identities of the credential can't be revoked")
+	}
+
+	value, err = perm.Serialize(srvc.context)
+	if err != nil {
+		return xerrors.Errorf("failed to serialize permission: %v", err)
+	}
+
+	err = store.Set(creds.GetID(), value)
+	if err != nil {
+		return xerrors.Errorf("failed to set permission: %v", err)
+	}
+
+	return nil
+}
+
+// rulesOf deserializes the permission, whose rules are the commands granted.
+func (srvc darcService) rulesOf(value []byte) (*darctypes.DisjunctivePermission, error) {
+	perm, err := srvc.fac.PermissionOf(srvc.context, value)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to deserialize permission: %v", err)
+	}
+
+	rules, ok := perm.(*darctypes.DisjunctivePermission)
+	if !ok {
+		return nil, xerrors.Errorf("unsupported permission: %T", perm)
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/c4dt/d-voting/contracts/evoting"
+	"github.com/c4dt/d-voting/contracts/evoting/types"
+	"go.dedis.ch/dela/cli/node"
+	accessContract "go.dedis.ch/dela/contracts/access"
+	"go.dedis.ch/dela/core/execution/native"
+	"go.dedis.ch/dela/core/ordering"
+	"go.dedis.ch/dela/core/txn"
+	"go.dedis.ch/dela/core/txn/pool"
+	"go.dedis.ch/dela/core/validation"
+	"go.dedis.ch/dela/serde"
+	sjson "go.dedis.ch/dela/serde/json"
+	"golang.org/x/xerrors"
+)
+
+// accessAction is an action to grant or revoke the credential of an evoting
+// command to an identity. Granting goes through the access contract, and
+// revoking through the REVOKE_ACCESS command of the evoting contract.
+//
+// - implements node.ActionTemplate
+type accessAction struct {
+	revoke bool
+}
+
+// Execute implements node.ActionTemplate. It adds the transaction to the pool.
+func (a *accessAction) Execute(ctx node.Context) error {
+	signer, err := getSigner(ctx.Flags.String("signer"))
+	if err != nil {
+		return xerrors.Errorf("failed to get the signer: %v", err)
+	}
+
+	command := ctx.Flags.String("command")
+	identity := ctx.Flags.String("identity")
+
+	var args []txn.Arg
+
+	if a.revoke {
+		args, err = revokeArgs(sjson.NewContext(), command, identity)
+	} else {
+		args, err = grantArgs(command, identity)
+	}
+
+	if err != nil {
+		return xerrors.Errorf("failed to make the arguments: %v", err)
+	}
+
+	var p pool.Pool
+	err = ctx.Injector.Resolve(&p)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve pool.Pool: %v", err)
+	}
+
+	var orderingSvc ordering.Service
+	err = ctx.Injector.Resolve(&orderingSvc)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve ordering.Service: %v", err)
+	}
+
+	var validationSvc validation.Service
+	err = ctx.Injector.Resolve(&validationSvc)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve validation: %v", err)
+	}
+
+	mngr := getManager(signer, client{srvc: orderingSvc, mgr: validationSvc})
+
+	err = mngr.Sync()
+	if err != nil {
+		return xerrors.Errorf("failed to sync the manager: %v", err)
+	}
+
+	tx, err := mngr.Make(args...)
+	if err != nil {
+		return xerrors.Errorf("failed to make the transaction: %v", err)
+	}
+
+	err = p.Add(tx)
+	if err != nil {
+		return xerrors.Errorf("failed to add the transaction: %v", err)
+	}
+
+	fmt.Fprintf(ctx.Out, "transaction %x added to the pool\n", tx.GetID())
+
+	return nil
+}
+
+// grantArgs returns the arguments of the transaction granting the credential
+// of the command to the identity, a base64-encoded BLS public key.
+func grantArgs(command, identity string) ([]txn.Arg, error) {
+	if command == "" || identity == "" {
+		return nil, xerrors.Errorf("the command and the identity are required")
+	}
+
+	return []txn.Arg{
+		{Key: native.ContractArg, Value: []byte(accessContract.ContractName)},
+		{Key: accessContract.GrantIDArg, Value: []byte(hex.EncodeToString([]byte(evoting.ContractUID)))},
+		{Key: accessContract.GrantContractArg, Value: []byte(evoting.ContractName)},
+		{Key: accessContract.GrantCommandArg, Value: []byte(command)},
+		{Key: accessContract.IdentityArg, Value: []byte(identity)},
+		{Key: accessContract.CmdArg, Value: []byte(accessContract.CmdSet)},
+	}, nil
+}
+
+// revokeArgs returns the arguments of the transaction revoking the credential
+// of the command from the identity, a base64-encoded BLS public key.
+func revokeArgs(ctx serde.Context, command, identity string) ([]txn.Arg, error) {
+	if command == "" || identity == "" {
+		return nil, xerrors.Errorf("the command and the identity are required")
+	}
+
+	revokeAccess := types.RevokeAccess{
+		Command:    command,
+		Identities: []string{identity},
+	}
+
+	data, err := revokeAccess.Serialize(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to marshal RevokeAccess: %v", err)
+	}
+
+	return []txn.Arg{
+		{Key: native.ContractArg, Value: []byte(evoting.ContractName)},
+		{Key: evoting.CmdArg, Value: []byte(evoting.CmdRevokeAccess)},
+		{Key: evoting.FormArg, Value: data},
+	}, nil
+}
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/c4dt/d-voting/contracts/evoting"
+	"github.com/c4dt/d-voting/contracts/evoting/types"
+	"github.com/stretchr/testify/require"
+	accessContract "go.dedis.ch/dela/contracts/access"
+	"go.dedis.ch/dela/core/execution/native"
+	sjson "go.dedis.ch/dela/serde/json"
+)
+
+func TestGrantArgs(t *testing.T) {
+	_, err := grantArgs("", "AAAA")
+	require.EqualError(t, err, "the command and the identity are required")
+
+	args, err := grantArgs(string(evoting.CmdCastVote), "AAAA")
+	require.NoError(t, err)
+
+	values := make(map[string]string)
+	for _, arg := range args {
+		values[arg.Key] = string(arg.Value)
+	}
+
+	require.Equal(t, accessContract.ContractName, values[native.ContractArg])
+	require.Equal(t, "45564f54", values[accessContract.GrantIDArg])
+	require.Equal(t, evoting.ContractName, values[accessContract.GrantContractArg])
+	require.Equal(t, "CAST_VOTE", values[accessContract.GrantCommandArg])
+	require.Equal(t, "AAAA", values[accessContract.IdentityArg])
+	require.Equal(t, "GRANT", values[accessContract.CmdArg])
+}
+
+func TestRevokeArgs(t *testing.T) {
+	ctx := sjson.NewContext()
+
+	_, err := revokeArgs(ctx, "CAST_VOTE", "")
+	require.EqualError(t, err, "the command and the identity are required")
+
+	args, err := revokeArgs(ctx, "CAST_VOTE", "AAAA")
+	require.NoError(t, err)
+	require.Len(t, args, 3)
+
+	require.Equal(t, evoting.ContractName, string(args[0].Value))
+	require.Equal(t, string(evoting.CmdRevokeAccess), string(args[1].Value))
+
+	msg, err := types.NewTransactionFactory(types.CiphervoteFactory{}).Deserialize(ctx, args[2].Value)
+	require.NoError(t, err)
+	require.Equal(t, types.RevokeAccess{
+		Command:    "CAST_VOTE",
+		Identities: []string{"AAAA"},
+	}, msg)
+}
@@ -54,7 +54,14 @@ const (
 
 	evotingPathSlash = "/evoting/"
 
+	tenantPath   = evotingPathSlash + "tenants"
+	tenantIDPath = tenantPath + "/{tenantID}"
+
+	proposalPath   = evotingPathSlash + "adminproposals"
+	proposalIDPath = proposalPath + "/{proposalID}"
+
 	transactionPath        = transactionSlash + "{token}"
+	openAPIPath            = "/openapi.json"
 	unexpectedStatus       = "unexpected status: %s, body: %s"
 	failRetrieveDecryption = "failed to retrieve decryption key: %v"
 	selectString           = "select:"
@@ -172,28 +179,23 @@ func (a *RegisterAction) Execute(ctx node.Context) error {
 		return xerrors.Errorf("failed to unmarshal proxy key: %v", err)
 	}
 
+	keys, err := eproxy.ResolveKeyring(ctx.Injector, proxykey)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve keyring: %v", err)
+	}
+
 	transactionManager := txnmanager.NewTransactionManager(mngr, p, sjson.NewContext(), proxykey, blocks, signer, validation)
 
-	ep := eproxy.NewForm(ordering, p, sjson.NewContext(), formFac, proxykey, transactionManager)
+	ep := eproxy.NewForm(ordering, blocks, p, sjson.NewContext(), formFac, keys, transactionManager)
 
 	router := mux.NewRouter()
+	// the body is parsed and its signature verified once, then the retries
+	// are answered before the replay protection rejects them
+	router.Use(eproxy.ParseRequests(keys))
+	router.Use(transactionManager.Idempotent)
+	router.Use(eproxy.RejectReplays(keys, eproxy.ResolveReplayGuard(ctx.Injector)))
 
-	router.HandleFunc(evotingPathSlash+"addadmin", ep.AddAdmin).Methods("POST")
-	router.HandleFunc(evotingPathSlash+"removeadmin", ep.RemoveAdmin).Methods("POST")
-	router.HandleFunc(evotingPathSlash+"adminlist", ep.AdminList).Methods("GET")
-	router.HandleFunc(formIDPath+"/addowner", ep.AddOwnerToForm).Methods("POST")
-	router.HandleFunc(formIDPath+"/removeowner", ep.RemoveOwnerToForm).Methods("POST")
-	router.HandleFunc(formIDPath+"/addvoter", ep.AddVoterToForm).Methods("POST")
-	router.HandleFunc(formIDPath+"/removevoter", ep.RemoveVoterToForm).Methods("POST")
-	router.HandleFunc(formPath, ep.NewForm).Methods("POST")
-	router.HandleFunc(formPath, ep.Forms).Methods("GET")
-	router.HandleFunc(formPath, eproxy.AllowCORS).Methods("OPTIONS")
-	router.HandleFunc(formIDPath, ep.Form).Methods("GET")
-	router.HandleFunc(formIDPath, ep.EditForm).Methods("PUT")
-	router.HandleFunc(formIDPath, eproxy.AllowCORS).Methods("OPTIONS")
-	router.HandleFunc(formIDPath, ep.DeleteForm).Methods("DELETE")
-	router.HandleFunc(formIDPath+"/vote", ep.NewFormVote).Methods("POST")
-	router.HandleFunc(transactionPath, transactionManager.StatusHandlerGet).Methods("GET")
+	registerRoutes(router, ep, transactionManager)
 
 	router.NotFoundHandler = http.HandlerFunc(eproxy.NotFoundHandler)
 	router.MethodNotAllowedHandler = http.HandlerFunc(eproxy.NotAllowedHandler)
@@ -202,12 +204,58 @@ func (a *RegisterAction) Execute(ctx node.Context) error {
 	proxy.RegisterHandler(formPath, router.ServeHTTP)
 	proxy.RegisterHandler(FormPathSlash, router.ServeHTTP)
 	proxy.RegisterHandler(transactionSlash, router.ServeHTTP)
+	proxy.RegisterHandler(openAPIPath, router.ServeHTTP)
 
 	dela.Logger.Info().Msg("d-voting proxy handlers registered")
 
 	return nil
 }
 
+// registerRoutes registers the handlers of the proxy on the router. The
+// payload of every signed request is validated before it reaches its handler.
+func registerRoutes(router *mux.Router, ep eproxy.Form, mngr txnmanager.Manager) {
+	router.HandleFunc(evotingPathSlash+"addadmin", eproxy.ValidatePayload(ptypes.PermissionOperationRequest{}, ep.AddAdmin)).Methods("POST")
+	router.HandleFunc(evotingPathSlash+"removeadmin", eproxy.ValidatePayload(ptypes.PermissionOperationRequest{}, ep.RemoveAdmin)).Methods("POST")
+	router.HandleFunc(evotingPathSlash+"adminlist", ep.AdminList).Methods("GET")
+	router.HandleFunc(evotingPathSlash+"limits", ep.Limits).Methods("GET")
+	router.HandleFunc(evotingPathSlash+"limits", eproxy.ValidatePayload(ptypes.SetLimitsRequest{}, ep.SetLimits)).Methods("PUT")
+	router.HandleFunc(proposalPath, ep.AdminProposals).Methods("GET")
+	router.HandleFunc(proposalPath, eproxy.ValidatePayload(ptypes.ProposeAdminChangeRequest{}, ep.NewAdminProposal)).Methods("POST")
+	router.HandleFunc(proposalIDPath+"/approve", eproxy.ValidatePayload(ptypes.AdminProposalVoteRequest{}, ep.ApproveAdminProposal)).Methods("POST")
+	router.HandleFunc(proposalIDPath+"/reject", eproxy.ValidatePayload(ptypes.AdminProposalVoteRequest{}, ep.RejectAdminProposal)).Methods("POST")
+	router.HandleFunc(tenantPath, eproxy.ValidatePayload(ptypes.CreateTenantRequest{}, ep.NewTenant)).Methods("POST")
+	router.HandleFunc(tenantPath, ep.Tenants).Methods("GET")
+	router.HandleFunc(tenantIDPath+"/addadmin", eproxy.ValidatePayload(ptypes.PermissionOperationRequest{}, ep.AddTenantAdmin)).Methods("POST")
+	router.HandleFunc(tenantIDPath+"/removeadmin", eproxy.ValidatePayload(ptypes.PermissionOperationRequest{}, ep.RemoveTenantAdmin)).Methods("POST")
+	router.HandleFunc(tenantIDPath+"/adminlist", ep.TenantAdminList).Methods("GET")
+	router.HandleFunc(formIDPath+"/addowner", eproxy.ValidatePayload(ptypes.PermissionOperationRequest{}, ep.AddOwnerToForm)).Methods("POST")
+	router.HandleFunc(formIDPath+"/removeowner", eproxy.ValidatePayload(ptypes.PermissionOperationRequest{}, ep.RemoveOwnerToForm)).Methods("POST")
+	router.HandleFunc(formIDPath+"/addvoter", eproxy.ValidatePayload(ptypes.PermissionOperationRequest{}, ep.AddVoterToForm)).Methods("POST")
+	router.HandleFunc(formIDPath+"/removevoter", eproxy.ValidatePayload(ptypes.PermissionOperationRequest{}, ep.RemoveVoterToForm)).Methods("POST")
+	router.HandleFunc(formIDPath+"/addrole", eproxy.ValidatePayload(ptypes.RoleOperationRequest{}, ep.AddRoleToForm)).Methods("POST")
+	router.HandleFunc(formIDPath+"/removerole", eproxy.ValidatePayload(ptypes.RoleOperationRequest{}, ep.RemoveRoleToForm)).Methods("POST")
+	router.HandleFunc(formIDPath+"/verification", ep.FormVerification).Methods("GET")
+	router.HandleFunc(formIDPath+"/verify", eproxy.ValidatePayload(ptypes.VerifyFormRequest{}, ep.VerifyForm)).Methods("POST")
+	router.HandleFunc(formIDPath+"/status", ep.FormStatus).Methods("GET")
+	router.HandleFunc(formPath, eproxy.ValidatePayload(ptypes.CreateFormRequest{}, ep.NewForm)).Methods("POST")
+	router.HandleFunc(formPath, ep.Forms).Methods("GET")
+	router.HandleFunc(formPath, eproxy.AllowCORS).Methods("OPTIONS")
+	router.HandleFunc(formIDPath, ep.Form).Methods("GET")
+	router.HandleFunc(formIDPath+"/history", ep.FormHistory).Methods("GET")
+	router.HandleFunc(formIDPath+"/summary", ep.FormSummary).Methods("GET")
+	router.HandleFunc(formIDPath+"/stream", ep.FormStream).Methods("GET")
+	router.HandleFunc(formIDPath, eproxy.ValidatePayload(ptypes.UpdateFormRequest{}, ep.EditForm)).Methods("PUT")
+	router.HandleFunc(formIDPath, eproxy.AllowCORS).Methods("OPTIONS")
+	router.HandleFunc(formIDPath, eproxy.ValidatePayload(ptypes.DeleteFormRequest{}, ep.DeleteForm)).Methods("DELETE")
+	router.HandleFunc(formIDPath+"/runoff", eproxy.ValidatePayload(ptypes.CreateRunoffRequest{}, ep.NewFormRunoff)).Methods("POST")
+	router.HandleFunc(formIDPath+"/vote", eproxy.ValidatePayload(ptypes.CastVoteRequest{}, ep.NewFormVote)).Methods("POST")
+	router.HandleFunc(formIDPath+"/vote", eproxy.ValidatePayload(ptypes.WithdrawVoteRequest{}, ep.WithdrawFormVote)).Methods("DELETE")
+	router.HandleFunc(formIDPath+"/delegation", eproxy.ValidatePayload(ptypes.DelegateVoteRequest{}, ep.NewFormDelegation)).Methods("POST")
+	router.HandleFunc(formIDPath+"/delegation", eproxy.ValidatePayload(ptypes.RevokeDelegationRequest{}, ep.RevokeFormDelegation)).Methods("DELETE")
+	router.HandleFunc(transactionPath, mngr.StatusHandlerGet).Methods("GET")
+	router.HandleFunc(openAPIPath, eproxy.OpenAPI).Methods("GET")
+}
+
 // getSigner creates a signer from a file.
 func getSigner(filePath string) (crypto.Signer, error) {
 	l := loader.NewFileLoader(filePath)
@@ -367,7 +415,7 @@ func (a *scenarioTestAction) Execute(ctx node.Context) error {
 		Ballot:  ballot1,
 	}
 
-	signed, err := createSignedRequest(secret, castVoteRequest)
+	signed, err := createSignedRequest(secret, castVoteRequest, http.MethodPost, FormPathSlash+formID+"/vote")
 	if err != nil {
 		return createSignedErr(err)
 	}
@@ -392,7 +440,7 @@ func (a *scenarioTestAction) Execute(ctx node.Context) error {
 		Ballot:  ballot2,
 	}
 
-	signed, err = createSignedRequest(secret, castVoteRequest)
+	signed, err = createSignedRequest(secret, castVoteRequest, http.MethodPost, FormPathSlash+formID+"/vote")
 	if err != nil {
 		return createSignedErr(err)
 	}
@@ -417,7 +465,7 @@ func (a *scenarioTestAction) Execute(ctx node.Context) error {
 		Ballot:  ballot3,
 	}
 
-	signed, err = createSignedRequest(secret, castVoteRequest)
+	signed, err = createSignedRequest(secret, castVoteRequest, http.MethodPost, FormPathSlash+formID+"/vote")
 	if err != nil {
 		return createSignedErr(err)
 	}
@@ -471,7 +519,7 @@ func (a *scenarioTestAction) Execute(ctx node.Context) error {
 		Action: "shuffle",
 	}
 
-	signed, err = createSignedRequest(secret, shuffleRequest)
+	signed, err = createSignedRequest(secret, shuffleRequest, http.MethodPut, "/evoting/services/shuffle/"+formID)
 	if err != nil {
 		return createSignedErr(err)
 	}
@@ -607,7 +655,7 @@ func setupSimpleForm(ctx node.Context, secret kyber.Scalar, proxyAddr1 string,
 		UserID:        "UserID",
 	}
 
-	signed, err := createSignedRequest(secret, createSimpleFormRequest)
+	signed, err := createSignedRequest(secret, createSimpleFormRequest, http.MethodPost, formPath)
 	if err != nil {
 		return "", types.Form{}, nil, createSignedErr(err)
 	}
@@ -747,7 +795,7 @@ func updateForm(secret kyber.Scalar, proxyAddr, formIDHex, action string) (int,
 		Action: action,
 	}
 
-	signed, err := createSignedRequest(secret, msg)
+	signed, err := createSignedRequest(secret, msg, http.MethodPut, FormPathSlash+formIDHex)
 	if err != nil {
 		return 0, createSignedErr(err)
 	}
@@ -776,7 +824,7 @@ func initDKG(secret kyber.Scalar, proxyAddr, formIDHex string) error {
 		FormID: formIDHex,
 	}
 
-	signed, err := createSignedRequest(secret, setupDKG)
+	signed, err := createSignedRequest(secret, setupDKG, http.MethodPost, "/evoting/services/dkg/actors")
 	if err != nil {
 		return createSignedErr(err)
 	}
@@ -799,7 +847,7 @@ func updateDKG(secret kyber.Scalar, proxyAddr, formIDHex, action string) (int, e
 		Action: action,
 	}
 
-	signed, err := createSignedRequest(secret, msg)
+	signed, err := createSignedRequest(secret, msg, http.MethodPut, "/evoting/services/dkg/actors/"+formIDHex)
 	if err != nil {
 		return 0, createSignedErr(err)
 	}
@@ -826,10 +874,11 @@ func createSignedErr(err error) error {
 	return xerrors.Errorf("failed to create signed request: %v", err)
 }
 
-func createSignedRequest(secret kyber.Scalar, msg interface{}) ([]byte, error) {
-	jsonMsg, err := json.Marshal(msg)
+// createSignedRequest signs msg for a request to the method and path
+func createSignedRequest(secret kyber.Scalar, msg interface{}, method, path string) ([]byte, error) {
+	jsonMsg, err := ptypes.NewPayload(msg, method, path)
 	if err != nil {
-		return nil, xerrors.Errorf("failed to marshal json: %v", err)
+		return nil, xerrors.Errorf("failed to create payload: %v", err)
 	}
 
 	payload := base64.URLEncoding.EncodeToString(jsonMsg)
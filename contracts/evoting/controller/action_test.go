@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	eproxy "github.com/c4dt/d-voting/proxy"
+	"github.com/c4dt/d-voting/proxy/txnmanager"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	sjson "go.dedis.ch/dela/serde/json"
+)
+
+func TestRegisterRoutes_Documented(t *testing.T) {
+	router := mux.NewRouter()
+	ctx := sjson.NewContext()
+
+	registerRoutes(router, eproxy.NewForm(nil, nil, nil, ctx, nil, nil, nil),
+		txnmanager.NewTransactionManager(nil, nil, ctx, nil, nil, nil, nil))
+
+	var routes []string
+
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		require.NoError(t, err)
+
+		methods, err := route.GetMethods()
+		require.NoError(t, err)
+
+		for _, method := range methods {
+			// the preflight requests and the document itself aren't operations
+			if method != "OPTIONS" && path != openAPIPath {
+				routes = append(routes, method+" "+path)
+			}
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	var documented []string
+
+	for _, op := range eproxy.Operations() {
+		if !strings.Contains(op, " /evoting/services/") {
+			documented = append(documented, op)
+		}
+	}
+
+	require.ElementsMatch(t, documented, routes)
+}
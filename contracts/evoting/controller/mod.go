@@ -64,6 +64,84 @@ func (m controller) SetCommands(builder node.Builder) {
 		},
 	)
 	sub.SetAction(builder.MakeAction(&scenarioTestAction{}))
+
+	accessFlags := []cli.Flag{
+		cli.StringFlag{
+			Name:     "signer",
+			Usage:    "Path to signer's private key",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name:     "command",
+			Usage:    "the evoting command, such as CAST_VOTE, or \"all\"",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name:     "identity",
+			Usage:    "the BLS public key of the identity, base64 encoded",
+			Required: true,
+		},
+	}
+
+	// dvoting --config /tmp/node1 e-voting grant --signer private.key \
+	//   --command CAST_VOTE --identity <base64 public key>
+	sub = cmd.SetSubCommand("grant")
+	sub.SetDescription("grant the credential of an evoting command to an identity")
+	sub.SetFlags(accessFlags...)
+	sub.SetAction(builder.MakeAction(&accessAction{}))
+
+	// dvoting --config /tmp/node1 e-voting revoke --signer private.key \
+	//   --command CAST_VOTE --identity <base64 public key>
+	//
+	// The signer needs the REVOKE_ACCESS credential, granted on its own.
+	sub = cmd.SetSubCommand("revoke")
+	sub.SetDescription("revoke the credential of an evoting command from an identity, " +
+		"the signer needs the REVOKE_ACCESS credential")
+	sub.SetFlags(accessFlags...)
+	sub.SetAction(builder.MakeAction(&accessAction{revoke: true}))
+
+	// dvoting --config /tmp/node1 e-voting addProxyKey --id web2 \
+	//   --key <hex public key> --notAfter 1767225600
+	sub = cmd.SetSubCommand("addProxyKey")
+	sub.SetDescription("trust a key to sign the requests of a frontend")
+	sub.SetFlags(
+		cli.StringFlag{
+			Name:     "id",
+			Usage:    "the ID of the key, given in the KeyID of the signed requests",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name:     "key",
+			Usage:    "the public key of the frontend, hex encoded",
+			Required: true,
+		},
+		cli.IntFlag{
+			Name:  "notBefore",
+			Usage: "the unix time from which the key is valid, 0 for no bound",
+		},
+		cli.IntFlag{
+			Name:  "notAfter",
+			Usage: "the unix time until which the key is valid, 0 for no bound",
+		},
+	)
+	sub.SetAction(builder.MakeAction(&proxyKeyAction{}))
+
+	// dvoting --config /tmp/node1 e-voting revokeProxyKey --id web2
+	sub = cmd.SetSubCommand("revokeProxyKey")
+	sub.SetDescription("stop trusting a key to sign the requests of a frontend")
+	sub.SetFlags(
+		cli.StringFlag{
+			Name:     "id",
+			Usage:    "the ID of the key",
+			Required: true,
+		},
+	)
+	sub.SetAction(builder.MakeAction(&proxyKeyAction{revoke: true}))
+
+	// dvoting --config /tmp/node1 e-voting proxyKeys
+	sub = cmd.SetSubCommand("proxyKeys")
+	sub.SetDescription("list the keys trusted to sign the requests of the frontends")
+	sub.SetAction(builder.MakeAction(&listProxyKeysAction{}))
 }
 
 // OnStart implements node.Initializer. It creates and registers a pedersen DKG.
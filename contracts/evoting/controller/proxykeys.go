@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	eproxy "github.com/c4dt/d-voting/proxy"
+	"go.dedis.ch/dela/cli/node"
+	"golang.org/x/xerrors"
+)
+
+// proxyKeyAction is an action to add or revoke a key trusted to sign the
+// requests of a frontend, on the running proxy of the node. The keys are
+// stored in the node database and kept when the node restarts.
+//
+// - implements node.ActionTemplate
+type proxyKeyAction struct {
+	revoke bool
+}
+
+// Execute implements node.ActionTemplate. It updates the keyring of the
+// handlers.
+func (a *proxyKeyAction) Execute(ctx node.Context) error {
+	var keys *eproxy.Keyring
+	err := ctx.Injector.Resolve(&keys)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve the keyring, are the handlers registered? %v", err)
+	}
+
+	id := ctx.Flags.String("id")
+
+	if a.revoke {
+		err = keys.Revoke(id)
+		if err != nil {
+			return xerrors.Errorf("failed to revoke key: %v", err)
+		}
+
+		fmt.Fprintf(ctx.Out, "key %q revoked\n", id)
+
+		return nil
+	}
+
+	key, err := eproxy.DecodeKey(ctx.Flags.String("key"))
+	if err != nil {
+		return xerrors.Errorf("failed to decode key: %v", err)
+	}
+
+	err = keys.Add(eproxy.TrustedKey{
+		ID:        id,
+		Key:       key,
+		NotBefore: int64(ctx.Flags.Int("notBefore")),
+		NotAfter:  int64(ctx.Flags.Int("notAfter")),
+	})
+	if err != nil {
+		return xerrors.Errorf("failed to add key: %v", err)
+	}
+
+	fmt.Fprintf(ctx.Out, "key %q added\n", id)
+
+	return nil
+}
+
+// listProxyKeysAction is an action to print the keys trusted to sign the
+// requests of the frontends.
+//
+// - implements node.ActionTemplate
+type listProxyKeysAction struct{}
+
+// Execute implements node.ActionTemplate. It prints a key per line.
+func (a *listProxyKeysAction) Execute(ctx node.Context) error {
+	var keys *eproxy.Keyring
+	err := ctx.Injector.Resolve(&keys)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve the keyring, are the handlers registered? %v", err)
+	}
+
+	for _, key := range keys.Keys() {
+		keyBuf, err := key.Key.MarshalBinary()
+		if err != nil {
+			return xerrors.Errorf("failed to marshal key %q: %v", key.ID, err)
+		}
+
+		fmt.Fprintf(ctx.Out, "%s\t%s\tnotBefore=%d\tnotAfter=%d\n",
+			key.ID, hex.EncodeToString(keyBuf), key.NotBefore, key.NotAfter)
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	eproxy "github.com/c4dt/d-voting/proxy"
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/dela/cli/node"
+)
+
+func TestProxyKeyAction_Execute(t *testing.T) {
+	out := &bytes.Buffer{}
+	flags := node.FlagSet{"id": "web2"}
+	ctx := node.Context{
+		Injector: node.NewInjector(),
+		Flags:    flags,
+		Out:      out,
+	}
+
+	action := proxyKeyAction{}
+
+	err := action.Execute(ctx)
+	require.ErrorContains(t, err, "failed to resolve the keyring")
+
+	keys, err := eproxy.ResolveKeyring(ctx.Injector, suite.Point().Pick(suite.RandomStream()))
+	require.NoError(t, err)
+
+	flags["key"] = "xx"
+	err = action.Execute(ctx)
+	require.ErrorContains(t, err, "failed to decode key")
+
+	keyBuf, err := suite.Point().Pick(suite.RandomStream()).MarshalBinary()
+	require.NoError(t, err)
+
+	flags["key"] = hex.EncodeToString(keyBuf)
+	flags["notAfter"] = float64(1767225600)
+
+	err = action.Execute(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "key \"web2\" added\n", out.String())
+
+	require.Len(t, keys.Keys(), 2)
+	require.Equal(t, int64(1767225600), keys.Keys()[1].NotAfter)
+
+	out.Reset()
+	err = (&listProxyKeysAction{}).Execute(ctx)
+	require.NoError(t, err)
+	require.Contains(t, out.String(), "web2\t"+hex.EncodeToString(keyBuf)+"\tnotBefore=0\tnotAfter=1767225600\n")
+
+	out.Reset()
+	err = (&proxyKeyAction{revoke: true}).Execute(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "key \"web2\" revoked\n", out.String())
+	require.Len(t, keys.Keys(), 1)
+
+	err = (&proxyKeyAction{revoke: true}).Execute(ctx)
+	require.EqualError(t, err, `failed to revoke key: the key "web2" doesn't exist`)
+}
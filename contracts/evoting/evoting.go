@@ -5,10 +5,13 @@ package evoting
 import (
 	"bytes"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"math/rand"
+	"reflect"
+	"strconv"
 	"strings"
 
 	"go.dedis.ch/dela"
@@ -16,6 +19,7 @@ import (
 	"go.dedis.ch/kyber/v3/share"
 
 	"github.com/c4dt/d-voting/contracts/evoting/types"
+	"go.dedis.ch/dela/core/access"
 	"go.dedis.ch/dela/core/execution"
 	"go.dedis.ch/dela/core/execution/native"
 	"go.dedis.ch/dela/core/ordering/cosipbft/authority"
@@ -36,7 +40,12 @@ const (
 	errIsRole             = "failed check the permission: %v"
 	errNoOwnerPerms       = "The user %v doesn't have the Owner permission on the form."
 	errNoVoterPerms       = "The user %v doesn't have the Voter permission on the form."
+	errNoRegistrarPerms   = "The user %v doesn't have the Owner or Registrar permission on the form."
+	errNoAuditorPerms     = "The user %v doesn't have the Owner or Auditor permission on the form."
 	errWrongTx            = "wrong type of transaction: %T"
+	errRecordEvent        = "failed to record event: %v"
+	errSetForm            = "failed to set form: %v"
+	errUserForms          = "failed to update the forms of the users: %v"
 )
 
 // evotingCommand implements the commands of the Evoting contract.
@@ -53,6 +62,9 @@ type Role int
 const (
 	Voters Role = iota + 1
 	Owners
+	Auditors
+	Registrars
+	Observers
 )
 
 type prover func(suite proof.Suite, protocolName string, verifier proof.Verifier, proof []byte) error
@@ -75,13 +87,24 @@ func (e evotingCommand) createForm(snap store.Snapshot, step execution.Step) err
 		return xerrors.Errorf("failed to get roster")
 	}
 
-	// Check if has Admin Right to create a form
-	isAdmin, _, err := e.fetchAdmin(snap, tx.UserID)
-	if err != nil {
-		return err
-	}
-	if !isAdmin {
-		return xerrors.Errorf("The performing user is not an admin.")
+	// Check if has Admin Right to create a form, in the tenant if any
+	if tx.TenantID == "" {
+		isAdmin, _, err := e.fetchAdmin(snap, tx.UserID)
+		if err != nil {
+			return err
+		}
+		if !isAdmin {
+			return types.Reject(types.RejectNotAdmin, "The performing user is not an admin.")
+		}
+	} else {
+		isAdmin, _, err := e.fetchTenantAdmin(snap, tx.TenantID, tx.UserID)
+		if err != nil {
+			return err
+		}
+		if !isAdmin {
+			return types.Reject(types.RejectNotAdmin, "The performing user is not an admin of the tenant %s.",
+				tx.TenantID)
+		}
 	}
 
 	roster, err := e.rosterFac.AuthorityOf(e.context, rosterBuf)
@@ -89,19 +112,23 @@ func (e evotingCommand) createForm(snap store.Snapshot, step execution.Step) err
 		return xerrors.Errorf("failed to get roster: %v", err)
 	}
 
-	// Get the formID, which is the SHA256 of the transaction ID
-	h := sha256.New()
-	h.Write(step.Current.GetID())
-	formIDBuf := h.Sum(nil)
+	err = tx.Configuration.CheckMajorities()
+	if err != nil {
+		return xerrors.Errorf("invalid majority rule: %v", err)
+	}
 
 	if !tx.Configuration.IsValid() {
 		return xerrors.Errorf("configuration of form is incoherent or has duplicated IDs")
 	}
 
-	units := types.PubsharesUnits{
-		Pubshares: make([]types.PubsharesUnit, 0),
-		PubKeys:   make([][]byte, 0),
-		Indexes:   make([]int, 0),
+	limits, err := getLimits(snap)
+	if err != nil {
+		return xerrors.Errorf("failed to get limits: %v", err)
+	}
+
+	err = limits.CheckConfiguration(tx.Configuration)
+	if err != nil {
+		return xerrors.Errorf("the configuration exceeds the limits: %v", err)
 	}
 
 	// Initial owner is the creator
@@ -114,33 +141,30 @@ func (e evotingCommand) createForm(snap store.Snapshot, step execution.Step) err
 
 	owners[0] = sciperInt
 
-	form := types.Form{
-		FormID:        hex.EncodeToString(formIDBuf),
-		Configuration: tx.Configuration,
-		Status:        types.Initial,
-		// Pubkey is set by the opening command
-		BallotSize:       tx.Configuration.MaxBallotSize(),
-		PubsharesUnits:   units,
-		ShuffleInstances: []types.ShuffleInstance{},
-		DecryptedBallots: []types.Ballot{},
-		// We set the participant in the e-voting once for all. If it happens
-		// that 1/3 of the participants go away, the form will never end.
-		Roster:           roster,
-		ShuffleThreshold: threshold.ByzantineThreshold(roster.Len()),
-		Owners:           owners,
-		Voters:           make([]int, 0),
+	formIDBuf, form := newForm(step, tx.Configuration, roster, owners, make([]int, 0))
+	form.TenantID = tx.TenantID
+	form.CloseBlock = tx.CloseBlock
+	form.Private = tx.Private
+
+	if form.IsPastDeadline(e.blocks.Len()) {
+		return types.Reject(types.RejectInvalidRequest, "the close block %d is already passed", tx.CloseBlock)
 	}
 
 	PromFormStatus.WithLabelValues(form.FormID).Set(float64(form.Status))
 
-	formBuf, err := form.Serialize(e.context)
+	err = e.setForm(snap, formIDBuf, form)
 	if err != nil {
-		return xerrors.Errorf("failed to marshal Form : %v", err)
+		return xerrors.Errorf(errSetForm, err)
 	}
 
-	err = snap.Set(formIDBuf, formBuf)
+	err = types.OwnerForms.Add(snap, form.FormID, form.Owners...)
 	if err != nil {
-		return xerrors.Errorf("failed to set value: %v", err)
+		return xerrors.Errorf(errUserForms, err)
+	}
+
+	err = e.recordEvent(snap, formIDBuf, CmdCreateForm, tx.UserID, nil)
+	if err != nil {
+		return xerrors.Errorf(errRecordEvent, err)
 	}
 
 	err = updateFormMetadataStore(snap, form.FormID)
@@ -151,8 +175,65 @@ func (e evotingCommand) createForm(snap store.Snapshot, step execution.Step) err
 	return nil
 }
 
+// newForm returns a new form with the given configuration, roster, owners and
+// voters, and its ID, which is the SHA256 of the transaction ID.
+func newForm(step execution.Step, configuration types.Configuration,
+	roster authority.Authority, owners, voters []int) ([]byte, types.Form) {
+
+	// Get the formID, which is the SHA256 of the transaction ID
+	h := sha256.New()
+	h.Write(step.Current.GetID())
+	formIDBuf := h.Sum(nil)
+
+	units := types.PubsharesUnits{
+		Pubshares: make([]types.PubsharesUnit, 0),
+		PubKeys:   make([][]byte, 0),
+		Indexes:   make([]int, 0),
+	}
+
+	form := types.Form{
+		FormID:        hex.EncodeToString(formIDBuf),
+		Configuration: configuration,
+		Status:        types.Initial,
+		// Pubkey is set by the opening command
+		BallotSize:       configuration.MaxBallotSize(),
+		PubsharesUnits:   units,
+		ShuffleInstances: []types.ShuffleInstance{},
+		DecryptedBallots: []types.Ballot{},
+		// We set the participant in the e-voting once for all. If it happens
+		// that 1/3 of the participants go away, the form will never end.
+		Roster:           roster,
+		ShuffleThreshold: threshold.ByzantineThreshold(roster.Len()),
+		Owners:           owners,
+		Voters:           voters,
+	}
+
+	return formIDBuf, form
+}
+
 // updateFormMetadataStore Update the form metadata store
 func updateFormMetadataStore(snap store.Snapshot, formID string) error {
+	return editFormMetadataStore(snap, func(formIDs *types.FormIDs) error {
+		err := formIDs.Add(formID)
+		if err != nil {
+			return xerrors.Errorf("couldn't add new form: %v", err)
+		}
+
+		return nil
+	})
+}
+
+// removeFormMetadataStore removes the form from the form metadata store
+func removeFormMetadataStore(snap store.Snapshot, formID string) error {
+	return editFormMetadataStore(snap, func(formIDs *types.FormIDs) error {
+		formIDs.Remove(formID)
+		return nil
+	})
+}
+
+// editFormMetadataStore applies the edit on the form IDs of the form metadata
+// store
+func editFormMetadataStore(snap store.Snapshot, edit func(*types.FormIDs) error) error {
 	formsMetadataBuf, err := snap.Get([]byte(FormsMetadataKey))
 	if err != nil {
 		return xerrors.Errorf("failed to get key '%s': %v", formsMetadataBuf, err)
@@ -169,9 +250,9 @@ func updateFormMetadataStore(snap store.Snapshot, formID string) error {
 		}
 	}
 
-	err = formsMetadata.FormsIDs.Add(formID)
+	err = edit(&formsMetadata.FormsIDs)
 	if err != nil {
-		return xerrors.Errorf("couldn't add new form: %v", err)
+		return err
 	}
 
 	formMetadataJSON, err := json.Marshal(formsMetadata)
@@ -186,6 +267,74 @@ func updateFormMetadataStore(snap store.Snapshot, formID string) error {
 	return nil
 }
 
+// setForm stores the form and its index entry
+func (e evotingCommand) setForm(snap store.Snapshot, formIDBuf []byte, form types.Form) error {
+	formBuf, err := form.Serialize(e.context)
+	if err != nil {
+		return xerrors.Errorf("failed to marshal Form : %v", err)
+	}
+
+	err = snap.Set(formIDBuf, formBuf)
+	if err != nil {
+		return xerrors.Errorf("failed to set value: %v", err)
+	}
+
+	err = e.updateFormIndex(snap, formIDBuf, form)
+	if err != nil {
+		return xerrors.Errorf("failed to update index: %v", err)
+	}
+
+	return nil
+}
+
+// updateFormIndex stores the index entry of the form if the form changed one
+// of its fields in the current block.
+func (e evotingCommand) updateFormIndex(snap store.Snapshot, formIDBuf []byte, form types.Form) error {
+	current, _, err := types.FormIndexEntryFromStore(hex.EncodeToString(formIDBuf), snap)
+	if err != nil {
+		return xerrors.Errorf("failed to get index entry: %v", err)
+	}
+
+	entry, changed, err := current.Update(form, e.blocks.Len())
+	if err != nil {
+		return xerrors.Errorf("failed to update index entry: %v", err)
+	}
+
+	if !changed {
+		return nil
+	}
+
+	entryBuf, err := json.Marshal(entry)
+	if err != nil {
+		return xerrors.Errorf("failed to marshal index entry: %v", err)
+	}
+
+	err = snap.Set(types.FormIndexKey(formIDBuf), entryBuf)
+	if err != nil {
+		return xerrors.Errorf("failed to set index entry: %v", err)
+	}
+
+	return nil
+}
+
+// recordEvent adds the event of a command to the history of the form
+func (e evotingCommand) recordEvent(snap store.Snapshot, formIDBuf []byte, command Command,
+	userID string, params map[string]string) error {
+
+	return types.AppendEvent(e.context, snap, formIDBuf, types.FormEvent{
+		Command:    string(command),
+		UserID:     userID,
+		BlockIndex: e.blocks.Len(),
+		Params:     params,
+	})
+}
+
+// countEvent counts a command in the history of the form. The events of the
+// same command in the same block are merged.
+func (e evotingCommand) countEvent(snap store.Snapshot, formIDBuf []byte, command Command) error {
+	return types.CountEvent(e.context, snap, formIDBuf, string(command), e.blocks.Len())
+}
+
 // openForm set the public key on the form. The public key is fetched
 // from the DKG actor. It works only if DKG is set up.
 func (e evotingCommand) openForm(snap store.Snapshot, step execution.Step) error {
@@ -211,7 +360,7 @@ func (e evotingCommand) openForm(snap store.Snapshot, step execution.Step) error
 	}
 
 	if !isOwner {
-		return xerrors.Errorf(errNoOwnerPerms, tx.UserID)
+		return types.Reject(types.RejectNotOwner, errNoOwnerPerms, tx.UserID)
 	}
 
 	if form.Status != types.Initial {
@@ -237,14 +386,14 @@ func (e evotingCommand) openForm(snap store.Snapshot, step execution.Step) error
 
 	form.Pubkey = pubkey
 
-	formBuf, err := form.Serialize(e.context)
+	err = e.setForm(snap, formID, form)
 	if err != nil {
-		return xerrors.Errorf("failed to marshal Form : %v", err)
+		return xerrors.Errorf(errSetForm, err)
 	}
 
-	err = snap.Set(formID, formBuf)
+	err = e.recordEvent(snap, formID, CmdOpenForm, tx.UserID, nil)
 	if err != nil {
-		return xerrors.Errorf("failed to set value: %v", err)
+		return xerrors.Errorf(errRecordEvent, err)
 	}
 
 	return nil
@@ -268,8 +417,12 @@ func (e evotingCommand) castVote(snap store.Snapshot, step execution.Step) error
 		return xerrors.Errorf(errGetForm, err)
 	}
 
+	if form.Status == types.Suspended {
+		return types.Reject(types.RejectFormSuspended, "the form is suspended")
+	}
+
 	if form.Status != types.Open {
-		return xerrors.Errorf("the form is not open, current status: %d", form.Status)
+		return types.Reject(types.RejectFormNotOpen, "the form is not open, current status: %d", form.Status)
 	}
 
 	isOwner, err := e.isRole(form, tx.VoterID, Voters)
@@ -278,27 +431,64 @@ func (e evotingCommand) castVote(snap store.Snapshot, step execution.Step) error
 	}
 
 	if !isOwner {
-		return xerrors.Errorf(errNoVoterPerms, tx.VoterID)
+		return types.Reject(types.RejectNotVoter, errNoVoterPerms, tx.VoterID)
+	}
+
+	if form.IsPastDeadline(e.blocks.Len()) {
+		return types.Reject(types.RejectFormNotOpen, "the form stopped accepting ballots at block %d",
+			form.CloseBlock)
+	}
+
+	// ballotVoterID is the voter the ballot belongs to, which is the
+	// delegator when a delegate votes on their behalf
+	ballotVoterID, err := ballotVoter(form, tx.VoterID, tx.DelegatorID)
+	if err != nil {
+		return types.Reject(types.RejectInvalidDelegation, "invalid delegation: %v", err)
 	}
 
 	if len(tx.Ballot) != form.ChunksPerBallot() {
-		return xerrors.Errorf("the ballot has unexpected length: %d != %d",
+		return types.Reject(types.RejectBallotLength, "the ballot has unexpected length: %d != %d",
 			len(tx.Ballot), form.ChunksPerBallot())
 	}
 
-	err = form.CastVote(e.context, snap, tx.VoterID, tx.Ballot)
+	policy := form.Configuration.GetRevotePolicy()
+
+	record, err := form.VoterRecord(e.context, snap, ballotVoterID)
+	if err != nil {
+		return xerrors.Errorf("failed to get voter record: %v", err)
+	}
+
+	if policy == types.NoRevote && record.Ballots > 0 {
+		return types.Reject(types.RejectRevoteNotAllowed, "voter %s already voted and re-voting is not allowed",
+			ballotVoterID)
+	}
+
+	// with the first vote only policy, a ballot is accepted again once the
+	// previous one has been withdrawn
+	if policy == types.FirstVoteOnly && record.Live {
+		return types.Reject(types.RejectRevoteNotAllowed, "voter %s already voted and only the first ballot is kept",
+			ballotVoterID)
+	}
+
+	// the first ballot is not a re-vote
+	if form.Configuration.MaxRevotes > 0 && uint(record.Ballots) > form.Configuration.MaxRevotes {
+		return types.Reject(types.RejectRevoteNotAllowed, "voter %s reached the maximum number of re-votes: %d",
+			ballotVoterID, form.Configuration.MaxRevotes)
+	}
+
+	err = form.CastVote(e.context, snap, ballotVoterID, tx.Ballot)
 	if err != nil {
 		return xerrors.Errorf("couldn't cast vote: %v", err)
 	}
 
-	formBuf, err := form.Serialize(e.context)
+	err = e.setForm(snap, formID, form)
 	if err != nil {
-		return xerrors.Errorf("failed to marshal Form : %v", err)
+		return xerrors.Errorf(errSetForm, err)
 	}
 
-	err = snap.Set(formID, formBuf)
+	err = e.countEvent(snap, formID, CmdCastVote)
 	if err != nil {
-		return xerrors.Errorf("failed to set value: %v", err)
+		return xerrors.Errorf(errRecordEvent, err)
 	}
 
 	PromFormBallots.WithLabelValues(form.FormID).Set(float64(form.BallotCount))
@@ -306,127 +496,332 @@ func (e evotingCommand) castVote(snap store.Snapshot, step execution.Step) error
 	return nil
 }
 
-// shuffleBallots implements commands. It performs the SHUFFLE_BALLOTS command
-func (e evotingCommand) shuffleBallots(snap store.Snapshot, step execution.Step) error {
+// ballotVoter returns the voter the ballot of a transaction of the voter
+// belongs to. A voter who delegated their vote can neither vote nor withdraw
+// the ballot, and a delegate acts only for the voters who delegated to them.
+func ballotVoter(form types.Form, voterID, delegatorID string) (string, error) {
+	if delegatorID == "" {
+		delegate, err := form.GetDelegate(voterID)
+		if err != nil {
+			return "", xerrors.Errorf("failed GetDelegate: %v", err)
+		}
 
-	msg, err := e.getTransaction(step.Current)
+		if delegate >= 0 {
+			return "", xerrors.Errorf("the voter %s delegated their vote to %d",
+				voterID, delegate)
+		}
+
+		return voterID, nil
+	}
+
+	delegate, err := form.GetDelegate(delegatorID)
 	if err != nil {
-		return xerrors.Errorf(errGetTransaction, err)
+		return "", xerrors.Errorf("failed GetDelegate: %v", err)
 	}
 
-	tx, ok := msg.(types.ShuffleBallots)
-	if !ok {
-		return xerrors.Errorf(errWrongTx, msg)
+	voterInt, err := types.SciperToInt(voterID)
+	if err != nil {
+		return "", xerrors.Errorf("failed to convert SCIPER to integer: %v", err)
 	}
 
-	err = e.checkPreviousTransactions(step, tx.Round)
+	if delegate != voterInt {
+		return "", xerrors.Errorf("the voter %s didn't delegate their vote to %s",
+			delegatorID, voterID)
+	}
+
+	return delegatorID, nil
+}
+
+// manageDelegations implements commands. It performs the DELEGATE_VOTE and
+// REVOKE_DELEGATION commands
+func (e evotingCommand) manageDelegations(snap store.Snapshot, step execution.Step) error {
+
+	msg, err := e.getTransaction(step.Current)
 	if err != nil {
-		return xerrors.Errorf("check previous transactions failed: %v", err)
+		return xerrors.Errorf(errGetTransaction, err)
 	}
 
-	form, formID, err := e.getForm(tx.FormID, snap)
+	var formIDHex, voterID string
+	var command Command
+	var params map[string]string
+
+	switch tx := msg.(type) {
+	case types.DelegateVote:
+		formIDHex, voterID = tx.FormID, tx.VoterID
+		command = CmdDelegateVote
+		params = map[string]string{"DelegateID": tx.DelegateID}
+	case types.RevokeDelegation:
+		formIDHex, voterID = tx.FormID, tx.VoterID
+		command = CmdRevokeDelegation
+	default:
+		return xerrors.Errorf(errWrongTx, msg)
+	}
+
+	form, formID, err := e.getForm(formIDHex, snap)
 	if err != nil {
 		return xerrors.Errorf(errGetForm, err)
 	}
 
-	if form.Status != types.Closed {
-		return xerrors.Errorf("the form is not in state closed (current: %d != closed: %d)",
-			form.Status, types.Closed)
+	// votes can be delegated until the form closes
+	if form.Status != types.Initial && form.Status != types.Open {
+		return types.Reject(types.RejectFormNotOpen, "the form is not open, current status: %d", form.Status)
 	}
 
-	isOwner, err := e.isRole(form, tx.UserID, Owners)
+	isVoter, err := e.isRole(form, voterID, Voters)
 	if err != nil {
 		return xerrors.Errorf(errIsRole, err)
 	}
 
-	if !isOwner {
-		return xerrors.Errorf(errNoOwnerPerms, tx.UserID)
+	if !isVoter {
+		return types.Reject(types.RejectNotVoter, errNoVoterPerms, voterID)
 	}
 
-	// Round starts at 0
-	expectedRound := len(form.ShuffleInstances)
+	if command == CmdDelegateVote {
+		// the ballot of the voter would be counted along with the one of the
+		// delegate
+		var record types.VoterRecord
 
-	if tx.Round != expectedRound {
-		return xerrors.Errorf("wrong shuffle round: expected round '%d', "+
-			"transaction is for round '%d'", expectedRound, tx.Round)
-	}
+		record, err = form.VoterRecord(e.context, snap, voterID)
+		if err != nil {
+			return xerrors.Errorf("failed to get voter record: %v", err)
+		}
 
-	shufflerPublicKey := tx.PublicKey
+		if record.Live {
+			return types.Reject(types.RejectInvalidDelegation,
+				"voter %s already voted, the ballot must be withdrawn first", voterID)
+		}
 
-	err = isMemberOf(form.Roster, shufflerPublicKey)
-	if err != nil {
-		return xerrors.Errorf("could not verify identity of shuffler : %v", err)
-	}
+		err = form.AddDelegation(voterID, params["DelegateID"])
+	} else {
+		// once the delegate voted, only the delegate can withdraw the ballot
+		var record types.VoterRecord
 
-	// Check the node who submitted the shuffle did not already submit an
-	// accepted shuffle
-	for i, shuffleInstance := range form.ShuffleInstances {
-		if bytes.Equal(shufflerPublicKey, shuffleInstance.ShufflerPublicKey) {
-			return xerrors.Errorf("a node already submitted a shuffle that "+
-				"has been accepted in round %d", i)
+		record, err = form.VoterRecord(e.context, snap, voterID)
+		if err != nil {
+			return xerrors.Errorf("failed to get voter record: %v", err)
+		}
+
+		if record.Live {
+			return types.Reject(types.RejectInvalidDelegation,
+				"the delegate of voter %s already voted, the ballot must be withdrawn by the delegate first",
+				voterID)
 		}
+
+		err = form.RevokeDelegation(voterID)
 	}
 
-	// Check the shuffler indeed signed the transaction:
-	signerPubKey, err := bls.NewPublicKey(tx.PublicKey)
 	if err != nil {
-		return xerrors.Errorf("could not decode public key of signer : %v ", err)
+		return types.Reject(types.RejectInvalidDelegation, "couldn't update delegations: %v", err)
 	}
 
-	txSignature := tx.Signature
+	err = e.setForm(snap, formID, form)
+	if err != nil {
+		return xerrors.Errorf(errSetForm, err)
+	}
 
-	signature, err := bls.NewSignatureFactory().SignatureOf(e.context, txSignature)
+	err = e.recordEvent(snap, formID, command, voterID, params)
 	if err != nil {
-		return xerrors.Errorf("could node deserialize shuffle signature : %v", err)
+		return xerrors.Errorf(errRecordEvent, err)
 	}
 
-	h := sha256.New()
+	return nil
+}
 
-	err = tx.Fingerprint(h)
+// withdrawVote implements commands. It performs the WITHDRAW_VOTE command
+func (e evotingCommand) withdrawVote(snap store.Snapshot, step execution.Step) error {
+
+	msg, err := e.getTransaction(step.Current)
 	if err != nil {
-		return xerrors.Errorf("failed to get fingerprint: %v", err)
+		return xerrors.Errorf(errGetTransaction, err)
 	}
 
-	hash := h.Sum(nil)
+	tx, ok := msg.(types.WithdrawVote)
+	if !ok {
+		return xerrors.Errorf(errWrongTx, msg)
+	}
 
-	// Check the signature matches the shuffle using the shuffler's public key
-	err = signerPubKey.Verify(hash, signature)
+	form, formID, err := e.getForm(tx.FormID, snap)
 	if err != nil {
-		return xerrors.Errorf("signature does not match the Shuffle : %v", err)
+		return xerrors.Errorf(errGetForm, err)
 	}
 
-	// Retrieve the random vector (ie the Scalar vector)
-	randomVector, err := tx.RandomVector.Unmarshal()
-	if err != nil {
-		return xerrors.Errorf("failed to unmarshal random vector: %v", err)
+	if form.Status == types.Suspended {
+		return types.Reject(types.RejectFormSuspended, "the form is suspended")
 	}
 
-	// Check that the random vector is correct
-	semiRandomStream, err := NewSemiRandomStream(hash)
+	if form.Status != types.Open {
+		return types.Reject(types.RejectFormNotOpen, "the form is not open, current status: %d", form.Status)
+	}
+
+	isVoter, err := e.isRole(form, tx.VoterID, Voters)
 	if err != nil {
-		return xerrors.Errorf("could not create semi-random stream: %v", err)
+		return xerrors.Errorf(errIsRole, err)
 	}
 
-	if form.ChunksPerBallot() != len(randomVector) {
-		return xerrors.Errorf("randomVector has unexpected length : %v != %v",
-			len(randomVector), form.ChunksPerBallot())
+	if !isVoter {
+		return types.Reject(types.RejectNotVoter, errNoVoterPerms, tx.VoterID)
 	}
 
-	for i := 0; i < form.ChunksPerBallot(); i++ {
-		v := suite.Scalar().Pick(semiRandomStream)
-		if !randomVector[i].Equal(v) {
-			return xerrors.Errorf("random vector from shuffle transaction is " +
-				"different than expected random vector")
-		}
+	if form.IsPastDeadline(e.blocks.Len()) {
+		return types.Reject(types.RejectFormNotOpen, "the form stopped accepting ballots at block %d",
+			form.CloseBlock)
 	}
 
-	if len(tx.ShuffledBallots) == 0 {
-		return xerrors.Errorf("there are no shuffled ballots")
+	// ballotVoterID is the voter the ballot belongs to, which is the
+	// delegator when a delegate withdraws the ballot they cast
+	ballotVoterID, err := ballotVoter(form, tx.VoterID, tx.DelegatorID)
+	if err != nil {
+		return types.Reject(types.RejectInvalidDelegation, "invalid delegation: %v", err)
 	}
 
-	XX, YY := types.CiphervotesToPairs(tx.ShuffledBallots)
+	record, err := form.VoterRecord(e.context, snap, ballotVoterID)
+	if err != nil {
+		return xerrors.Errorf("failed to get voter record: %v", err)
+	}
 
-	var ciphervotes []types.Ciphervote
+	if !record.Live {
+		return xerrors.Errorf("voter %s has no ballot to withdraw", ballotVoterID)
+	}
+
+	err = form.WithdrawVote(e.context, snap, ballotVoterID)
+	if err != nil {
+		return xerrors.Errorf("couldn't withdraw vote: %v", err)
+	}
+
+	err = e.setForm(snap, formID, form)
+	if err != nil {
+		return xerrors.Errorf(errSetForm, err)
+	}
+
+	err = e.countEvent(snap, formID, CmdWithdrawVote)
+	if err != nil {
+		return xerrors.Errorf(errRecordEvent, err)
+	}
+
+	return nil
+}
+
+// shuffleBallots implements commands. It performs the SHUFFLE_BALLOTS command
+func (e evotingCommand) shuffleBallots(snap store.Snapshot, step execution.Step) error {
+
+	msg, err := e.getTransaction(step.Current)
+	if err != nil {
+		return xerrors.Errorf(errGetTransaction, err)
+	}
+
+	tx, ok := msg.(types.ShuffleBallots)
+	if !ok {
+		return xerrors.Errorf(errWrongTx, msg)
+	}
+
+	err = e.checkPreviousTransactions(step, tx.Round)
+	if err != nil {
+		return xerrors.Errorf("check previous transactions failed: %v", err)
+	}
+
+	form, formID, err := e.getForm(tx.FormID, snap)
+	if err != nil {
+		return xerrors.Errorf(errGetForm, err)
+	}
+
+	if form.Status != types.Closed {
+		return xerrors.Errorf("the form is not in state closed (current: %d != closed: %d)",
+			form.Status, types.Closed)
+	}
+
+	isOwner, err := e.isRole(form, tx.UserID, Owners)
+	if err != nil {
+		return xerrors.Errorf(errIsRole, err)
+	}
+
+	if !isOwner {
+		return types.Reject(types.RejectNotOwner, errNoOwnerPerms, tx.UserID)
+	}
+
+	// Round starts at 0
+	expectedRound := len(form.ShuffleInstances)
+
+	if tx.Round != expectedRound {
+		return xerrors.Errorf("wrong shuffle round: expected round '%d', "+
+			"transaction is for round '%d'", expectedRound, tx.Round)
+	}
+
+	shufflerPublicKey := tx.PublicKey
+
+	err = isMemberOf(form.Roster, shufflerPublicKey)
+	if err != nil {
+		return xerrors.Errorf("could not verify identity of shuffler : %v", err)
+	}
+
+	// Check the node who submitted the shuffle did not already submit an
+	// accepted shuffle
+	for i, shuffleInstance := range form.ShuffleInstances {
+		if bytes.Equal(shufflerPublicKey, shuffleInstance.ShufflerPublicKey) {
+			return xerrors.Errorf("a node already submitted a shuffle that "+
+				"has been accepted in round %d", i)
+		}
+	}
+
+	// Check the shuffler indeed signed the transaction:
+	signerPubKey, err := bls.NewPublicKey(tx.PublicKey)
+	if err != nil {
+		return xerrors.Errorf("could not decode public key of signer : %v ", err)
+	}
+
+	txSignature := tx.Signature
+
+	signature, err := bls.NewSignatureFactory().SignatureOf(e.context, txSignature)
+	if err != nil {
+		return xerrors.Errorf("could node deserialize shuffle signature : %v", err)
+	}
+
+	h := sha256.New()
+
+	err = tx.Fingerprint(h)
+	if err != nil {
+		return xerrors.Errorf("failed to get fingerprint: %v", err)
+	}
+
+	hash := h.Sum(nil)
+
+	// Check the signature matches the shuffle using the shuffler's public key
+	err = signerPubKey.Verify(hash, signature)
+	if err != nil {
+		return xerrors.Errorf("signature does not match the Shuffle : %v", err)
+	}
+
+	// Retrieve the random vector (ie the Scalar vector)
+	randomVector, err := tx.RandomVector.Unmarshal()
+	if err != nil {
+		return xerrors.Errorf("failed to unmarshal random vector: %v", err)
+	}
+
+	// Check that the random vector is correct
+	semiRandomStream, err := NewSemiRandomStream(hash)
+	if err != nil {
+		return xerrors.Errorf("could not create semi-random stream: %v", err)
+	}
+
+	if form.ChunksPerBallot() != len(randomVector) {
+		return xerrors.Errorf("randomVector has unexpected length : %v != %v",
+			len(randomVector), form.ChunksPerBallot())
+	}
+
+	for i := 0; i < form.ChunksPerBallot(); i++ {
+		v := suite.Scalar().Pick(semiRandomStream)
+		if !randomVector[i].Equal(v) {
+			return xerrors.Errorf("random vector from shuffle transaction is " +
+				"different than expected random vector")
+		}
+	}
+
+	if len(tx.ShuffledBallots) == 0 {
+		return xerrors.Errorf("there are no shuffled ballots")
+	}
+
+	XX, YY := types.CiphervotesToPairs(tx.ShuffledBallots)
+
+	var ciphervotes []types.Ciphervote
 
 	if tx.Round == 0 {
 		suff, err := form.Suffragia(e.context, snap)
@@ -473,14 +868,16 @@ func (e evotingCommand) shuffleBallots(snap store.Snapshot, step execution.Step)
 		PromFormStatus.WithLabelValues(form.FormID).Set(float64(form.Status))
 	}
 
-	formBuf, err := form.Serialize(e.context)
+	err = e.setForm(snap, formID, form)
 	if err != nil {
-		return xerrors.Errorf("failed to marshal Form : %v", err)
+		return xerrors.Errorf(errSetForm, err)
 	}
 
-	err = snap.Set(formID, formBuf)
+	err = e.recordEvent(snap, formID, CmdShuffleBallots, tx.UserID, map[string]string{
+		"Round": strconv.Itoa(tx.Round),
+	})
 	if err != nil {
-		return xerrors.Errorf("failed to set value: %v", err)
+		return xerrors.Errorf(errRecordEvent, err)
 	}
 
 	return nil
@@ -538,7 +935,7 @@ func (e evotingCommand) closeForm(snap store.Snapshot, step execution.Step) erro
 	}
 
 	if form.Status != types.Open {
-		return xerrors.Errorf("the form is not open, current status: %d", form.Status)
+		return types.Reject(types.RejectFormNotOpen, "the form is not open, current status: %d", form.Status)
 	}
 
 	isOwner, err := e.isRole(form, tx.UserID, Owners)
@@ -547,38 +944,38 @@ func (e evotingCommand) closeForm(snap store.Snapshot, step execution.Step) erro
 	}
 
 	if !isOwner {
-		return xerrors.Errorf(errNoOwnerPerms, tx.UserID)
+		return types.Reject(types.RejectNotOwner, errNoOwnerPerms, tx.UserID)
 	}
 
-	if form.BallotCount <= 1 {
+	if form.LiveBallots <= 1 {
 		return xerrors.Errorf("at least two ballots are required")
 	}
 
 	form.Status = types.Closed
 	PromFormStatus.WithLabelValues(form.FormID).Set(float64(form.Status))
 
-	formBuf, err := form.Serialize(e.context)
+	err = e.setForm(snap, formID, form)
 	if err != nil {
-		return xerrors.Errorf("failed to marshal Form : %v", err)
+		return xerrors.Errorf(errSetForm, err)
 	}
 
-	err = snap.Set(formID, formBuf)
+	err = e.recordEvent(snap, formID, CmdCloseForm, tx.UserID, nil)
 	if err != nil {
-		return xerrors.Errorf("failed to set value: %v", err)
+		return xerrors.Errorf(errRecordEvent, err)
 	}
 
 	return nil
 }
 
-// registerPubshares implements commands. It performs the
-// REGISTER_PUB_SHARES command
-func (e evotingCommand) registerPubshares(snap store.Snapshot, step execution.Step) error {
+// reopenForm implements commands. It performs the REOPEN_FORM command
+func (e evotingCommand) reopenForm(snap store.Snapshot, step execution.Step) error {
+
 	msg, err := e.getTransaction(step.Current)
 	if err != nil {
 		return xerrors.Errorf(errGetTransaction, err)
 	}
 
-	tx, ok := msg.(types.RegisterPubShares)
+	tx, ok := msg.(types.ReopenForm)
 	if !ok {
 		return xerrors.Errorf(errWrongTx, msg)
 	}
@@ -588,175 +985,982 @@ func (e evotingCommand) registerPubshares(snap store.Snapshot, step execution.St
 		return xerrors.Errorf(errGetForm, err)
 	}
 
-	if form.Status != types.ShuffledBallots {
-		return xerrors.Errorf("the ballots have not been shuffled")
-	}
-
-	err = isMemberOf(form.Roster, tx.PublicKey)
-	if err != nil {
-		return xerrors.Errorf("could not verify identity of node : %v", err)
-	}
-
-	signerPubKey, err := bls.NewPublicKey(tx.PublicKey)
-	if err != nil {
-		return xerrors.Errorf("could not recover public key from tx: %v", err)
+	if form.Status != types.Closed {
+		return types.Reject(types.RejectInvalidFormStatus, "the form is not closed, current status: %d",
+			form.Status)
 	}
 
-	// Check the node indeed signed the transaction:
-	txSignature := tx.Signature
-
-	signature, err := bls.NewSignatureFactory().SignatureOf(e.context, txSignature)
-	if err != nil {
-		return xerrors.Errorf("could node deserialize pubShare signature: %v", err)
+	if len(form.ShuffleInstances) > 0 {
+		return types.Reject(types.RejectInvalidFormStatus, "the form cannot be reopened once shuffled")
 	}
 
-	h := sha256.New()
-
-	err = tx.Fingerprint(h)
+	isOwner, err := e.isRole(form, tx.UserID, Owners)
 	if err != nil {
-		return xerrors.Errorf("failed to get fingerprint: %v", err)
+		return xerrors.Errorf(errIsRole, err)
 	}
 
-	hash := h.Sum(nil)
-
-	// Check the signature matches the pubshares using the node's public key
-	err = signerPubKey.Verify(hash, signature)
-	if err != nil {
-		return xerrors.Errorf("signature does not match the PubsharesUnit: %v ", err)
+	if !isOwner {
+		return types.Reject(types.RejectNotOwner, errNoOwnerPerms, tx.UserID)
 	}
 
-	// coherence check on the length of the shares submitted
-	shuffledBallots := form.ShuffleInstances[len(form.ShuffleInstances)-1].
-		ShuffledBallots
-	if len(tx.Pubshares) != len(shuffledBallots) {
-		return xerrors.Errorf("unexpected size of pubshares submission: %d != %d",
-			len(tx.Pubshares), len(shuffledBallots))
+	if tx.Reason == "" {
+		return types.Reject(types.RejectInvalidRequest, "a reason is required to reopen the form")
 	}
 
-	for i, ballot := range shuffledBallots {
-		if len(ballot) != len(tx.Pubshares[i]) {
-			return xerrors.Errorf("unexpected size of pubshares submission: %d != %d",
-				len(tx.Pubshares[i]), len(ballot))
-		}
+	// the deadline of a form that has one must be extended, so that the
+	// reopened form accepts ballots
+	if form.CloseBlock != 0 && tx.CloseBlock <= form.CloseBlock {
+		return types.Reject(types.RejectInvalidRequest, "the close block must be extended: %d <= %d",
+			tx.CloseBlock, form.CloseBlock)
 	}
 
-	units := &form.PubsharesUnits
-
-	// Check the node hasn't made any other submissions
-	for _, key := range units.PubKeys {
-		if bytes.Equal(key, tx.PublicKey) {
-			return xerrors.Errorf("'%x' already made a submission", key)
-		}
+	if tx.CloseBlock != 0 {
+		form.CloseBlock = tx.CloseBlock
 	}
 
-	for _, index := range units.Indexes {
-		if index == tx.Index {
-			return xerrors.Errorf("a submission has already been made for index %d", index)
-		}
+	if form.IsPastDeadline(e.blocks.Len()) {
+		return types.Reject(types.RejectInvalidRequest, "the close block %d is already passed", form.CloseBlock)
 	}
 
-	// Add the pubshares to the form
-	units.Pubshares = append(units.Pubshares, tx.Pubshares)
-	units.PubKeys = append(units.PubKeys, tx.PublicKey)
-	units.Indexes = append(units.Indexes, tx.Index)
-
-	nbrSubmissions := len(units.Pubshares)
-
-	PromFormPubShares.WithLabelValues(form.FormID).Set(float64(nbrSubmissions))
+	form.Reopenings = append(form.Reopenings, types.Reopening{
+		UserID:     tx.UserID,
+		Reason:     tx.Reason,
+		CloseBlock: form.CloseBlock,
+	})
 
-	if nbrSubmissions >= form.ShuffleThreshold {
-		form.Status = types.PubSharesSubmitted
-		PromFormStatus.WithLabelValues(form.FormID).Set(float64(form.Status))
-	}
+	form.Status = types.Open
+	PromFormStatus.WithLabelValues(form.FormID).Set(float64(form.Status))
 
-	formBuf, err := form.Serialize(e.context)
+	err = e.setForm(snap, formID, form)
 	if err != nil {
-		return xerrors.Errorf("failed to marshal Form: %v", err)
+		return xerrors.Errorf(errSetForm, err)
 	}
 
-	err = snap.Set(formID, formBuf)
+	err = e.recordEvent(snap, formID, CmdReopenForm, tx.UserID, map[string]string{
+		"Reason":     tx.Reason,
+		"CloseBlock": strconv.FormatUint(form.CloseBlock, 10),
+	})
 	if err != nil {
-		return xerrors.Errorf("failed to set value: %v", err)
+		return xerrors.Errorf(errRecordEvent, err)
 	}
 
 	return nil
 }
 
-// combineShares implements commands. It performs the COMBINE_SHARES command
-func (e evotingCommand) combineShares(snap store.Snapshot, step execution.Step) error {
+// createRunoff implements commands. It performs the CREATE_RUNOFF command
+func (e evotingCommand) createRunoff(snap store.Snapshot, step execution.Step) error {
 
 	msg, err := e.getTransaction(step.Current)
 	if err != nil {
 		return xerrors.Errorf(errGetTransaction, err)
 	}
 
-	tx, ok := msg.(types.CombineShares)
+	tx, ok := msg.(types.CreateRunoff)
 	if !ok {
 		return xerrors.Errorf(errWrongTx, msg)
 	}
 
-	form, formID, err := e.getForm(tx.FormID, snap)
+	parent, parentID, err := e.getForm(tx.FormID, snap)
 	if err != nil {
 		return xerrors.Errorf(errGetForm, err)
 	}
 
-	if form.Status != types.PubSharesSubmitted {
-		return xerrors.Errorf("the public shares have not been submitted,"+
-			" current status: %d", form.Status)
+	if parent.Status != types.ResultAvailable {
+		return xerrors.Errorf("the result of the form is not available, current status: %d",
+			parent.Status)
 	}
 
-	isOwner, err := e.isRole(form, tx.UserID, Owners)
+	isOwner, err := e.isRole(parent, tx.UserID, Owners)
 	if err != nil {
 		return xerrors.Errorf(errIsRole, err)
 	}
 
 	if !isOwner {
-		return xerrors.Errorf(errNoOwnerPerms, tx.UserID)
+		return types.Reject(types.RejectNotOwner, errNoOwnerPerms, tx.UserID)
 	}
 
-	allPubShares := form.PubsharesUnits.Pubshares
-
-	shufflesSize := len(form.ShuffleInstances)
-
-	shuffledBallotsSize := len(form.ShuffleInstances[shufflesSize-1].ShuffledBallots)
-	ballotSize := len(form.ShuffleInstances[shufflesSize-1].ShuffledBallots[0])
-
-	decryptedBallots := make([]types.Ballot, shuffledBallotsSize)
+	if !tx.Rule.IsValid() {
+		return xerrors.Errorf("invalid runoff rule: %+v", tx.Rule)
+	}
 
-	for i := 0; i < shuffledBallotsSize; i++ {
-		// decryption of one ballot:
-		marshalledBallot := strings.Builder{}
+	tally := types.NewTally(parent.Configuration, parent.DecryptedBallots)
 
-		for j := 0; j < ballotSize; j++ {
-			chunk, err := decrypt(i, j, allPubShares, form.PubsharesUnits.Indexes)
-			if err != nil {
-				return xerrors.Errorf("failed to decrypt (K, C): %v", err)
-			}
+	candidates, err := types.RunoffCandidates(tx.Rule, tally, tx.QuestionID)
+	if err != nil {
+		return xerrors.Errorf("failed to get candidates: %v", err)
+	}
 
-			marshalledBallot.Write(chunk)
-		}
+	configuration, err := types.NewRunoffConfiguration(parent.Configuration,
+		tx.QuestionID, candidates)
+	if err != nil {
+		return xerrors.Errorf("failed to create configuration: %v", err)
+	}
 
-		var ballot types.Ballot
-		err = ballot.Unmarshal(marshalledBallot.String(), form)
+	limits, err := getLimits(snap)
+	if err != nil {
+		return xerrors.Errorf("failed to get limits: %v", err)
+	}
 
-		if err != nil {
-			dela.Logger.Warn().Msgf("Failed to unmarshal a ballot: %v", err)
-		}
+	err = limits.CheckConfiguration(configuration)
+	if err != nil {
+		return xerrors.Errorf("the configuration exceeds the limits: %v", err)
+	}
 
-		decryptedBallots[i] = ballot
+	err = limits.CheckVoters(len(parent.Voters))
+	if err != nil {
+		return xerrors.Errorf("the voters exceed the limits: %v", err)
 	}
 
-	form.DecryptedBallots = decryptedBallots
+	rosterBuf, err := snap.Get(viewchange.GetRosterKey())
+	if err != nil {
+		return xerrors.Errorf("failed to get roster")
+	}
+
+	roster, err := e.rosterFac.AuthorityOf(e.context, rosterBuf)
+	if err != nil {
+		return xerrors.Errorf("failed to get roster: %v", err)
+	}
+
+	// the runoff inherits the owners and the voters of its parent
+	owners := append([]int{}, parent.Owners...)
+	voters := append([]int{}, parent.Voters...)
+
+	formIDBuf, form := newForm(step, configuration, roster, owners, voters)
+
+	form.ParentFormID = parent.FormID
+	form.TenantID = parent.TenantID
+	form.Private = parent.Private
+	parent.RunoffFormIDs = append(parent.RunoffFormIDs, form.FormID)
+
+	PromFormStatus.WithLabelValues(form.FormID).Set(float64(form.Status))
+
+	err = e.setForm(snap, formIDBuf, form)
+	if err != nil {
+		return xerrors.Errorf(errSetForm, err)
+	}
+
+	err = e.setForm(snap, parentID, parent)
+	if err != nil {
+		return xerrors.Errorf(errSetForm, err)
+	}
+
+	err = types.OwnerForms.Add(snap, form.FormID, form.Owners...)
+	if err != nil {
+		return xerrors.Errorf(errUserForms, err)
+	}
+
+	err = types.VoterForms.Add(snap, form.FormID, form.Voters...)
+	if err != nil {
+		return xerrors.Errorf(errUserForms, err)
+	}
+
+	err = e.recordEvent(snap, formIDBuf, CmdCreateRunoff, tx.UserID, map[string]string{
+		"ParentFormID": parent.FormID,
+	})
+	if err != nil {
+		return xerrors.Errorf(errRecordEvent, err)
+	}
+
+	err = e.recordEvent(snap, parentID, CmdCreateRunoff, tx.UserID, map[string]string{
+		"RunoffFormID": form.FormID,
+	})
+	if err != nil {
+		return xerrors.Errorf(errRecordEvent, err)
+	}
+
+	err = updateFormMetadataStore(snap, form.FormID)
+	if err != nil {
+		return xerrors.Errorf("failed to update the metadata in the store: %v", err)
+	}
+
+	return nil
+}
+
+// suspendForm implements commands. It performs the SUSPEND_FORM command
+func (e evotingCommand) suspendForm(snap store.Snapshot, step execution.Step) error {
+
+	msg, err := e.getTransaction(step.Current)
+	if err != nil {
+		return xerrors.Errorf(errGetTransaction, err)
+	}
+
+	tx, ok := msg.(types.SuspendForm)
+	if !ok {
+		return xerrors.Errorf(errWrongTx, msg)
+	}
+
+	form, formID, err := e.getForm(tx.FormID, snap)
+	if err != nil {
+		return xerrors.Errorf(errGetForm, err)
+	}
+
+	if form.Status != types.Open {
+		return types.Reject(types.RejectFormNotOpen, "the form is not open, current status: %d", form.Status)
+	}
+
+	isOwner, err := e.isRole(form, tx.UserID, Owners)
+	if err != nil {
+		return xerrors.Errorf(errIsRole, err)
+	}
+
+	if !isOwner {
+		return types.Reject(types.RejectNotOwner, errNoOwnerPerms, tx.UserID)
+	}
+
+	form.Status = types.Suspended
+	PromFormStatus.WithLabelValues(form.FormID).Set(float64(form.Status))
+
+	err = e.setForm(snap, formID, form)
+	if err != nil {
+		return xerrors.Errorf(errSetForm, err)
+	}
+
+	err = e.recordEvent(snap, formID, CmdSuspendForm, tx.UserID, nil)
+	if err != nil {
+		return xerrors.Errorf(errRecordEvent, err)
+	}
+
+	return nil
+}
+
+// resumeForm implements commands. It performs the RESUME_FORM command
+func (e evotingCommand) resumeForm(snap store.Snapshot, step execution.Step) error {
+
+	msg, err := e.getTransaction(step.Current)
+	if err != nil {
+		return xerrors.Errorf(errGetTransaction, err)
+	}
+
+	tx, ok := msg.(types.ResumeForm)
+	if !ok {
+		return xerrors.Errorf(errWrongTx, msg)
+	}
+
+	form, formID, err := e.getForm(tx.FormID, snap)
+	if err != nil {
+		return xerrors.Errorf(errGetForm, err)
+	}
+
+	if form.Status != types.Suspended {
+		return xerrors.Errorf("the form is not suspended, current status: %d", form.Status)
+	}
+
+	isOwner, err := e.isRole(form, tx.UserID, Owners)
+	if err != nil {
+		return xerrors.Errorf(errIsRole, err)
+	}
+
+	if !isOwner {
+		return types.Reject(types.RejectNotOwner, errNoOwnerPerms, tx.UserID)
+	}
+
+	form.Status = types.Open
+	PromFormStatus.WithLabelValues(form.FormID).Set(float64(form.Status))
+
+	err = e.setForm(snap, formID, form)
+	if err != nil {
+		return xerrors.Errorf(errSetForm, err)
+	}
+
+	err = e.recordEvent(snap, formID, CmdResumeForm, tx.UserID, nil)
+	if err != nil {
+		return xerrors.Errorf(errRecordEvent, err)
+	}
+
+	return nil
+}
+
+// registerPubshares implements commands. It performs the
+// REGISTER_PUB_SHARES command
+func (e evotingCommand) registerPubshares(snap store.Snapshot, step execution.Step) error {
+	msg, err := e.getTransaction(step.Current)
+	if err != nil {
+		return xerrors.Errorf(errGetTransaction, err)
+	}
+
+	tx, ok := msg.(types.RegisterPubShares)
+	if !ok {
+		return xerrors.Errorf(errWrongTx, msg)
+	}
+
+	form, formID, err := e.getForm(tx.FormID, snap)
+	if err != nil {
+		return xerrors.Errorf(errGetForm, err)
+	}
+
+	if form.Status != types.ShuffledBallots {
+		return xerrors.Errorf("the ballots have not been shuffled")
+	}
+
+	err = isMemberOf(form.Roster, tx.PublicKey)
+	if err != nil {
+		return xerrors.Errorf("could not verify identity of node : %v", err)
+	}
+
+	signerPubKey, err := bls.NewPublicKey(tx.PublicKey)
+	if err != nil {
+		return xerrors.Errorf("could not recover public key from tx: %v", err)
+	}
+
+	// Check the node indeed signed the transaction:
+	txSignature := tx.Signature
+
+	signature, err := bls.NewSignatureFactory().SignatureOf(e.context, txSignature)
+	if err != nil {
+		return xerrors.Errorf("could node deserialize pubShare signature: %v", err)
+	}
+
+	h := sha256.New()
+
+	err = tx.Fingerprint(h)
+	if err != nil {
+		return xerrors.Errorf("failed to get fingerprint: %v", err)
+	}
+
+	hash := h.Sum(nil)
+
+	// Check the signature matches the pubshares using the node's public key
+	err = signerPubKey.Verify(hash, signature)
+	if err != nil {
+		return xerrors.Errorf("signature does not match the PubsharesUnit: %v ", err)
+	}
+
+	// coherence check on the length of the shares submitted
+	shuffledBallots := form.ShuffleInstances[len(form.ShuffleInstances)-1].
+		ShuffledBallots
+	if len(tx.Pubshares) != len(shuffledBallots) {
+		return xerrors.Errorf("unexpected size of pubshares submission: %d != %d",
+			len(tx.Pubshares), len(shuffledBallots))
+	}
+
+	for i, ballot := range shuffledBallots {
+		if len(ballot) != len(tx.Pubshares[i]) {
+			return xerrors.Errorf("unexpected size of pubshares submission: %d != %d",
+				len(tx.Pubshares[i]), len(ballot))
+		}
+	}
+
+	units := &form.PubsharesUnits
+
+	// Check the node hasn't made any other submissions
+	for _, key := range units.PubKeys {
+		if bytes.Equal(key, tx.PublicKey) {
+			return xerrors.Errorf("'%x' already made a submission", key)
+		}
+	}
+
+	for _, index := range units.Indexes {
+		if index == tx.Index {
+			return xerrors.Errorf("a submission has already been made for index %d", index)
+		}
+	}
+
+	// Add the pubshares to the form
+	units.Pubshares = append(units.Pubshares, tx.Pubshares)
+	units.PubKeys = append(units.PubKeys, tx.PublicKey)
+	units.Indexes = append(units.Indexes, tx.Index)
+
+	nbrSubmissions := len(units.Pubshares)
+
+	PromFormPubShares.WithLabelValues(form.FormID).Set(float64(nbrSubmissions))
+
+	if nbrSubmissions >= form.ShuffleThreshold {
+		form.Status = types.PubSharesSubmitted
+		PromFormStatus.WithLabelValues(form.FormID).Set(float64(form.Status))
+	}
+
+	err = e.setForm(snap, formID, form)
+	if err != nil {
+		return xerrors.Errorf(errSetForm, err)
+	}
+
+	err = e.recordEvent(snap, formID, CmdRegisterPubShares, "", map[string]string{
+		"Index": strconv.Itoa(tx.Index),
+	})
+	if err != nil {
+		return xerrors.Errorf(errRecordEvent, err)
+	}
+
+	return nil
+}
+
+// combineShares implements commands. It performs the COMBINE_SHARES command
+func (e evotingCommand) combineShares(snap store.Snapshot, step execution.Step) error {
+
+	msg, err := e.getTransaction(step.Current)
+	if err != nil {
+		return xerrors.Errorf(errGetTransaction, err)
+	}
+
+	tx, ok := msg.(types.CombineShares)
+	if !ok {
+		return xerrors.Errorf(errWrongTx, msg)
+	}
+
+	form, formID, err := e.getForm(tx.FormID, snap)
+	if err != nil {
+		return xerrors.Errorf(errGetForm, err)
+	}
+
+	if form.Status != types.PubSharesSubmitted {
+		return xerrors.Errorf("the public shares have not been submitted,"+
+			" current status: %d", form.Status)
+	}
+
+	isOwner, err := e.isRole(form, tx.UserID, Owners)
+	if err != nil {
+		return xerrors.Errorf(errIsRole, err)
+	}
+
+	if !isOwner {
+		return types.Reject(types.RejectNotOwner, errNoOwnerPerms, tx.UserID)
+	}
+
+	decryptedBallots, err := decryptBallots(form)
+	if err != nil {
+		return err
+	}
+
+	form.DecryptedBallots = decryptedBallots
+
+	form.Status = types.ResultAvailable
+	PromFormStatus.WithLabelValues(form.FormID).Set(float64(form.Status))
+
+	err = e.setForm(snap, formID, form)
+	if err != nil {
+		return xerrors.Errorf(errSetForm, err)
+	}
+
+	err = e.recordEvent(snap, formID, CmdCombineShares, tx.UserID, nil)
+	if err != nil {
+		return xerrors.Errorf(errRecordEvent, err)
+	}
+
+	return nil
+}
+
+// decryptBallots combines the public shares of the form to decrypt the
+// ballots of the last shuffle.
+func decryptBallots(form types.Form) ([]types.Ballot, error) {
+	allPubShares := form.PubsharesUnits.Pubshares
+
+	shufflesSize := len(form.ShuffleInstances)
+
+	shuffledBallotsSize := len(form.ShuffleInstances[shufflesSize-1].ShuffledBallots)
+	ballotSize := len(form.ShuffleInstances[shufflesSize-1].ShuffledBallots[0])
+
+	decryptedBallots := make([]types.Ballot, shuffledBallotsSize)
+
+	for i := 0; i < shuffledBallotsSize; i++ {
+		// decryption of one ballot:
+		marshalledBallot := strings.Builder{}
+
+		for j := 0; j < ballotSize; j++ {
+			chunk, err := decrypt(i, j, allPubShares, form.PubsharesUnits.Indexes)
+			if err != nil {
+				return nil, xerrors.Errorf("failed to decrypt (K, C): %v", err)
+			}
+
+			marshalledBallot.Write(chunk)
+		}
+
+		var ballot types.Ballot
+		err := ballot.Unmarshal(marshalledBallot.String(), form)
+
+		if err != nil {
+			dela.Logger.Warn().Msgf("Failed to unmarshal a ballot: %v", err)
+		}
+
+		decryptedBallots[i] = ballot
+	}
+
+	return decryptedBallots, nil
+}
+
+// verifyForm implements commands. It performs the VERIFY_FORM command. An
+// auditor or an owner of a form whose result is available checks that the
+// shuffles and the public shares come from distinct members of the roster, and
+// that combining the public shares again gives the decrypted ballots. The
+// outcome is recorded in the history of the form, so a failed verification is
+// kept as well.
+func (e evotingCommand) verifyForm(snap store.Snapshot, step execution.Step) error {
+	msg, err := e.getTransaction(step.Current)
+	if err != nil {
+		return xerrors.Errorf(errGetTransaction, err)
+	}
+
+	tx, ok := msg.(types.VerifyForm)
+	if !ok {
+		return xerrors.Errorf(errWrongTx, msg)
+	}
+
+	form, formID, err := e.getForm(tx.FormID, snap)
+	if err != nil {
+		return xerrors.Errorf(errGetForm, err)
+	}
+
+	if form.Status != types.ResultAvailable {
+		return xerrors.Errorf("the result of the form is not available, "+
+			"current status: %d", form.Status)
+	}
+
+	isOwner, err := e.isRole(form, tx.UserID, Owners)
+	if err != nil {
+		return xerrors.Errorf(errIsRole, err)
+	}
+
+	isAuditor, err := e.isRole(form, tx.UserID, Auditors)
+	if err != nil {
+		return xerrors.Errorf(errIsRole, err)
+	}
+
+	if !isOwner && !isAuditor {
+		return types.Reject(types.RejectForbidden, errNoAuditorPerms, tx.UserID)
+	}
+
+	params := map[string]string{"Result": "valid"}
+
+	err = checkTally(form)
+	if err != nil {
+		params = map[string]string{"Result": "invalid", "Reason": err.Error()}
+	}
+
+	err = e.recordEvent(snap, formID, CmdVerifyForm, tx.UserID, params)
+	if err != nil {
+		return xerrors.Errorf(errRecordEvent, err)
+	}
+
+	return nil
+}
+
+// checkTally checks the shuffles and the public shares of the form, and that
+// they decrypt to the ballots of the result.
+func checkTally(form types.Form) error {
+	if len(form.ShuffleInstances) < form.ShuffleThreshold {
+		return xerrors.Errorf("the form has %d shuffles, %d are required",
+			len(form.ShuffleInstances), form.ShuffleThreshold)
+	}
+
+	shufflers := make([][]byte, len(form.ShuffleInstances))
+	for i, instance := range form.ShuffleInstances {
+		shufflers[i] = instance.ShufflerPublicKey
+	}
+
+	err := checkDistinctMembers(form.Roster, shufflers)
+	if err != nil {
+		return xerrors.Errorf("invalid shuffler: %v", err)
+	}
+
+	err = checkDistinctMembers(form.Roster, form.PubsharesUnits.PubKeys)
+	if err != nil {
+		return xerrors.Errorf("invalid public shares: %v", err)
+	}
+
+	decryptedBallots, err := decryptBallots(form)
+	if err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(decryptedBallots, form.DecryptedBallots) {
+		return xerrors.Errorf("the public shares don't decrypt to the ballots of the result")
+	}
+
+	return nil
+}
+
+// checkDistinctMembers checks that the public keys are members of the roster
+// and that none is used twice.
+func checkDistinctMembers(roster authority.Authority, publicKeys [][]byte) error {
+	for i, publicKey := range publicKeys {
+		err := isMemberOf(roster, publicKey)
+		if err != nil {
+			return err
+		}
 
-	form.Status = types.ResultAvailable
+		for _, previous := range publicKeys[:i] {
+			if bytes.Equal(previous, publicKey) {
+				return xerrors.Errorf("public key used twice: %x", publicKey)
+			}
+		}
+	}
+
+	return nil
+}
+
+// cancelForm implements commands. It performs the CANCEL_FORM command
+func (e evotingCommand) cancelForm(snap store.Snapshot, step execution.Step) error {
+
+	msg, err := e.getTransaction(step.Current)
+	if err != nil {
+		return xerrors.Errorf(errGetTransaction, err)
+	}
+
+	tx, ok := msg.(types.CancelForm)
+	if !ok {
+		return xerrors.Errorf(errWrongTx, msg)
+	}
+
+	form, formID, err := e.getForm(tx.FormID, snap)
+	if err != nil {
+		return xerrors.Errorf(errGetForm, err)
+	}
+
+	isOwner, err := e.isRole(form, tx.UserID, Owners)
+	if err != nil {
+		return xerrors.Errorf(errIsRole, err)
+	}
+
+	if !isOwner {
+		return types.Reject(types.RejectNotOwner, errNoOwnerPerms, tx.UserID)
+	}
+
+	form.Status = types.Canceled
 	PromFormStatus.WithLabelValues(form.FormID).Set(float64(form.Status))
 
-	formBuf, err := form.Serialize(e.context)
+	err = e.setForm(snap, formID, form)
+	if err != nil {
+		return xerrors.Errorf(errSetForm, err)
+	}
+
+	err = e.recordEvent(snap, formID, CmdCancelForm, tx.UserID, nil)
+	if err != nil {
+		return xerrors.Errorf(errRecordEvent, err)
+	}
+
+	return nil
+}
+
+// deleteForm implements commands. It performs the DELETE_FORM command
+func (e evotingCommand) deleteForm(snap store.Snapshot, step execution.Step) error {
+
+	msg, err := e.getTransaction(step.Current)
+	if err != nil {
+		return xerrors.Errorf(errGetTransaction, err)
+	}
+
+	tx, ok := msg.(types.DeleteForm)
+	if !ok {
+		return xerrors.Errorf(errWrongTx, msg)
+	}
+
+	form, formID, err := e.getForm(tx.FormID, snap)
+	if err != nil {
+		return xerrors.Errorf(errGetForm, err)
+	}
+
+	isOwner, err := e.isRole(form, tx.UserID, Owners)
+	if err != nil {
+		return xerrors.Errorf(errIsRole, err)
+	}
+
+	if !isOwner {
+		return types.Reject(types.RejectNotOwner, errNoOwnerPerms, tx.UserID)
+	}
+
+	err = snap.Delete(formID)
+	if err != nil {
+		return xerrors.Errorf("failed to delete form: %v", err)
+	}
+
+	err = snap.Delete(types.FormIndexKey(formID))
+	if err != nil {
+		return xerrors.Errorf("failed to delete index entry: %v", err)
+	}
+
+	err = types.OwnerForms.Remove(snap, form.FormID, form.Owners...)
+	if err != nil {
+		return xerrors.Errorf(errUserForms, err)
+	}
+
+	err = types.VoterForms.Remove(snap, form.FormID, form.Voters...)
+	if err != nil {
+		return xerrors.Errorf(errUserForms, err)
+	}
+
+	err = types.DeleteHistory(snap, formID)
+	if err != nil {
+		return xerrors.Errorf("failed to delete history: %v", err)
+	}
+
+	err = form.DeleteVoterRecords(e.context, snap)
+	if err != nil {
+		return xerrors.Errorf("failed to delete voter records: %v", err)
+	}
+
+	err = removeFormMetadataStore(snap, form.FormID)
+	if err != nil {
+		return xerrors.Errorf("failed to update the metadata in the store: %v", err)
+	}
+
+	return nil
+}
+
+// manageAdminList implements commands. It performs the ADD or REMOVE ADMIN command
+func (e evotingCommand) manageAdminList(snap store.Snapshot, step execution.Step) error {
+	msg, err := e.getTransaction(step.Current)
+	if err != nil {
+		return xerrors.Errorf(errGetTransaction, err)
+	}
+
+	var list types.AdminList
+
+	txAddAdmin, okAddAdmin := msg.(types.AddAdmin)
+	txRemoveAdmin, okRemoveAdmin := msg.(types.RemoveAdmin)
+
+	if okAddAdmin {
+		isAdmin, listRetrieved, err := e.fetchAdmin(snap, txAddAdmin.PerformingUserID)
+		list = listRetrieved
+		if err != nil {
+			// Exact string matching of the error
+			if err.Error() != "failed to get the AdminList: No list found" {
+				return xerrors.Errorf("failed to get AdminList: %v", err)
+			}
+
+			// Trust On First Use System -> if no AdminList, will create one by default.
+
+			intSciper, err := types.SciperToInt(txAddAdmin.TargetUserID)
+			if err != nil {
+				return xerrors.Errorf("Invalid Sciper: %v", err)
+			}
+
+			err = initializeAdminList(snap, intSciper, e.context)
+			if err != nil {
+				return xerrors.Errorf("Failed to initialize admin list: %v", err)
+			}
+
+			// Adding the initial admin is performed by the initialize Admin List
+			// Therefore return
+			return nil
+		}
+		if !isAdmin {
+			return types.Reject(types.RejectNotAdmin, "The performing user is not an admin.")
+		}
+
+		// the change is a proposal, which applies right away unless several
+		// approvals are needed.
+		err = e.proposeAdminChange(snap, step, list, types.AdminProposal{
+			Action:       types.ProposalAddAdmin,
+			TargetUserID: txAddAdmin.TargetUserID,
+			ProposerID:   txAddAdmin.PerformingUserID,
+		}, types.DefaultProposalLifetime)
+		if err != nil {
+			return xerrors.Errorf("couldn't add admin: %v", err)
+		}
+	} else if okRemoveAdmin {
+		isAdmin, listRetrieved, err := e.fetchAdmin(snap, txRemoveAdmin.PerformingUserID)
+		list = listRetrieved
+		if err != nil {
+			return err
+		}
+		if !isAdmin {
+			return types.Reject(types.RejectNotAdmin, "The performing user is not an admin.")
+		}
+
+		err = e.proposeAdminChange(snap, step, list, types.AdminProposal{
+			Action:       types.ProposalRemoveAdmin,
+			TargetUserID: txRemoveAdmin.TargetUserID,
+			ProposerID:   txRemoveAdmin.PerformingUserID,
+		}, types.DefaultProposalLifetime)
+		if err != nil {
+			return xerrors.Errorf("couldn't remove admin: %v", err)
+		}
+	} else {
+		return xerrors.Errorf(errWrongTx, msg)
+	}
+
+	return nil
+}
+
+// manageAdminProposals implements commands. It performs the
+// PROPOSE_ADMIN_CHANGE, APPROVE_ADMIN_PROPOSAL and REJECT_ADMIN_PROPOSAL
+// commands.
+func (e evotingCommand) manageAdminProposals(snap store.Snapshot, step execution.Step) error {
+	msg, err := e.getTransaction(step.Current)
+	if err != nil {
+		return xerrors.Errorf(errGetTransaction, err)
+	}
+
+	switch tx := msg.(type) {
+	case types.ProposeAdminChange:
+		isAdmin, list, err := e.fetchAdmin(snap, tx.PerformingUserID)
+		if err != nil {
+			if err.Error() != "failed to get the AdminList: No list found" ||
+				tx.Action != types.ProposalAddAdmin {
+				return xerrors.Errorf("failed to get AdminList: %v", err)
+			}
+
+			// Trust On First Use System, as with the ADD_ADMIN command
+			intSciper, err := types.SciperToInt(tx.TargetUserID)
+			if err != nil {
+				return xerrors.Errorf("Invalid Sciper: %v", err)
+			}
+
+			return initializeAdminList(snap, intSciper, e.context)
+		}
+
+		if !isAdmin {
+			return types.Reject(types.RejectNotAdmin, "The performing user is not an admin.")
+		}
+
+		return e.proposeAdminChange(snap, step, list, types.AdminProposal{
+			Action:       tx.Action,
+			TargetUserID: tx.TargetUserID,
+			Threshold:    tx.Threshold,
+			ProposerID:   tx.PerformingUserID,
+		}, tx.Lifetime)
+	case types.ApproveAdminProposal:
+		return e.voteAdminProposal(snap, tx.ProposalID, tx.PerformingUserID, true)
+	case types.RejectAdminProposal:
+		return e.voteAdminProposal(snap, tx.ProposalID, tx.PerformingUserID, false)
+	default:
+		return xerrors.Errorf(errWrongTx, msg)
+	}
+}
+
+// proposeAdminChange stores the proposal, approved by its proposer, and
+// applies it if that is enough. The proposal can be approved during the given
+// number of blocks.
+func (e evotingCommand) proposeAdminChange(snap store.Snapshot, step execution.Step,
+	list types.AdminList, proposal types.AdminProposal, lifetime uint64) error {
+
+	err := proposal.Validate(list)
+	if err != nil {
+		return xerrors.Errorf("invalid proposal: %v", err)
+	}
+
+	proposals, err := types.AdminProposalsFromStore(snap, []byte(AdminProposalsKey))
+	if err != nil {
+		return xerrors.Errorf("failed to get proposals: %v", err)
+	}
+
+	if lifetime == 0 {
+		lifetime = types.DefaultProposalLifetime
+	}
+
+	if lifetime > types.MaxProposalLifetime {
+		return xerrors.Errorf("the lifetime must be at most %d blocks, got %d",
+			types.MaxProposalLifetime, lifetime)
+	}
+
+	proposal.ID = hex.EncodeToString(step.Current.GetID())
+	proposal.Status = types.ProposalPending
+	proposal.CreatedAt = e.blocks.Len()
+	proposal.ExpiresAt = proposal.CreatedAt + lifetime
+
+	_, err = proposals.Get(proposal.ID)
+	if err == nil {
+		return xerrors.Errorf("proposal %q already exists", proposal.ID)
+	}
+
+	proposerSciper, err := types.SciperToInt(proposal.ProposerID)
+	if err != nil {
+		return xerrors.Errorf("invalid proposer: %v", err)
+	}
+
+	proposal.Approvals = []int{proposerSciper}
+	proposals.Proposals = append(proposals.Proposals, proposal)
+
+	return e.settleAdminProposal(snap, &proposals, proposal.ID, list)
+}
+
+// voteAdminProposal adds the approval or the rejection of an admin to a
+// pending proposal.
+func (e evotingCommand) voteAdminProposal(snap store.Snapshot, proposalID string,
+	userID string, approve bool) error {
+
+	isAdmin, list, err := e.fetchAdmin(snap, userID)
+	if err != nil {
+		return xerrors.Errorf("failed to get AdminList: %v", err)
+	}
+
+	if !isAdmin {
+		return types.Reject(types.RejectNotAdmin, "The performing user is not an admin.")
+	}
+
+	proposals, err := types.AdminProposalsFromStore(snap, []byte(AdminProposalsKey))
+	if err != nil {
+		return xerrors.Errorf("failed to get proposals: %v", err)
+	}
+
+	proposal, err := proposals.Get(proposalID)
+	if err != nil {
+		return err
+	}
+
+	if proposal.IsExpired(e.blocks.Len()) {
+		return xerrors.Errorf("the proposal %s expired", proposalID)
+	}
+
+	// the SCIPER is valid since the user is an admin
+	sciper, _ := types.SciperToInt(userID)
+
+	if approve {
+		err = proposal.Approve(sciper)
+	} else {
+		err = proposal.Reject(sciper)
+	}
+
+	if err != nil {
+		return xerrors.Errorf("failed to vote: %v", err)
+	}
+
+	return e.settleAdminProposal(snap, &proposals, proposalID, list)
+}
+
+// settleAdminProposal applies the proposal if enough admins approved it, or
+// rejects it if it can't be approved anymore, and stores the admin list and
+// the proposals that are still pending.
+func (e evotingCommand) settleAdminProposal(snap store.Snapshot,
+	proposals *types.AdminProposals, proposalID string, list types.AdminList) error {
+
+	proposal, err := proposals.Get(proposalID)
+	if err != nil {
+		return err
+	}
+
+	threshold := proposals.GetThreshold(len(list.AdminList))
+
+	if proposal.CountApprovals(list) >= threshold {
+		err = proposals.Apply(proposal, &list)
+		if err != nil {
+			return err
+		}
+
+		err = e.setAdminList(snap, list)
+		if err != nil {
+			return xerrors.Errorf("failed to set AdminList: %v", err)
+		}
+	} else if proposal.CountRejections(list) > len(list.AdminList)-threshold {
+		proposal.Status = types.ProposalRejected
+	}
+
+	proposals.Prune(e.blocks.Len())
+
+	proposalsBuf, err := json.Marshal(proposals)
+	if err != nil {
+		return xerrors.Errorf("failed to marshal proposals: %v", err)
+	}
+
+	err = snap.Set([]byte(AdminProposalsKey), proposalsBuf)
+	if err != nil {
+		return xerrors.Errorf("failed to set proposals: %v", err)
+	}
+
+	return nil
+}
+
+// setAdminList stores the admin list
+func (e evotingCommand) setAdminList(snap store.Snapshot, list types.AdminList) error {
+	h := sha256.New()
+	h.Write([]byte(AdminListId))
+	formIDBuf := h.Sum(nil)
+
+	formBuf, err := list.Serialize(e.context)
 	if err != nil {
 		return xerrors.Errorf("failed to marshal Form : %v", err)
 	}
 
-	err = snap.Set(formID, formBuf)
+	err = snap.Set(formIDBuf, formBuf)
 	if err != nil {
 		return xerrors.Errorf("failed to set value: %v", err)
 	}
@@ -764,42 +1968,94 @@ func (e evotingCommand) combineShares(snap store.Snapshot, step execution.Step)
 	return nil
 }
 
-// cancelForm implements commands. It performs the CANCEL_FORM command
-func (e evotingCommand) cancelForm(snap store.Snapshot, step execution.Step) error {
-
+// manageTenants implements commands. It performs the CREATE_TENANT,
+// ADD_TENANT_ADMIN and REMOVE_TENANT_ADMIN commands. Tenants are created by
+// the admins of the deployment, and their admin lists are managed by the
+// admins of the tenant only. A tenant always keeps an admin since the last one
+// can't be removed.
+func (e evotingCommand) manageTenants(snap store.Snapshot, step execution.Step) error {
 	msg, err := e.getTransaction(step.Current)
 	if err != nil {
 		return xerrors.Errorf(errGetTransaction, err)
 	}
 
-	tx, ok := msg.(types.CancelForm)
-	if !ok {
-		return xerrors.Errorf(errWrongTx, msg)
-	}
+	var tenantID string
+	var list types.AdminList
 
-	form, formID, err := e.getForm(tx.FormID, snap)
-	if err != nil {
-		return xerrors.Errorf(errGetForm, err)
-	}
+	switch tx := msg.(type) {
+	case types.CreateTenant:
+		isAdmin, _, err := e.fetchAdmin(snap, tx.PerformingUserID)
+		if err != nil {
+			return err
+		}
+		if !isAdmin {
+			return types.Reject(types.RejectNotAdmin, "The performing user is not an admin.")
+		}
 
-	isOwner, err := e.isRole(form, tx.UserID, Owners)
-	if err != nil {
-		return xerrors.Errorf(errIsRole, err)
-	}
+		tenants, err := types.TenantsFromStore(e.context, snap, []byte(TenantsKey))
+		if err != nil {
+			return xerrors.Errorf("failed to get tenants: %v", err)
+		}
 
-	if !isOwner {
-		return xerrors.Errorf(errNoOwnerPerms, tx.UserID)
+		err = tenants.Add(types.Tenant{ID: tx.TenantID, Name: tx.Name})
+		if err != nil {
+			return xerrors.Errorf("couldn't add tenant: %v", err)
+		}
+
+		tenantsBuf, err := tenants.Serialize(e.context)
+		if err != nil {
+			return xerrors.Errorf("failed to serialize tenants: %v", err)
+		}
+
+		err = snap.Set([]byte(TenantsKey), tenantsBuf)
+		if err != nil {
+			return xerrors.Errorf("failed to set value: %v", err)
+		}
+
+		tenantID = tx.TenantID
+
+		err = list.AddAdmin(tx.AdminID)
+		if err != nil {
+			return xerrors.Errorf("couldn't add admin: %v", err)
+		}
+	case types.AddTenantAdmin:
+		tenantID = tx.TenantID
+
+		list, err = e.fetchTenantAdminList(snap, tx.TenantID, tx.PerformingUserID)
+		if err != nil {
+			return err
+		}
+
+		err = list.AddAdmin(tx.TargetUserID)
+		if err != nil {
+			return xerrors.Errorf("couldn't add admin: %v", err)
+		}
+	case types.RemoveTenantAdmin:
+		tenantID = tx.TenantID
+
+		list, err = e.fetchTenantAdminList(snap, tx.TenantID, tx.PerformingUserID)
+		if err != nil {
+			return err
+		}
+
+		err = list.RemoveAdmin(tx.TargetUserID)
+		if err != nil {
+			return xerrors.Errorf("couldn't remove admin: %v", err)
+		}
+	default:
+		return xerrors.Errorf(errWrongTx, msg)
 	}
 
-	form.Status = types.Canceled
-	PromFormStatus.WithLabelValues(form.FormID).Set(float64(form.Status))
+	h := sha256.New()
+	h.Write([]byte(TenantAdminListId(tenantID)))
+	listIDBuf := h.Sum(nil)
 
-	formBuf, err := form.Serialize(e.context)
+	listBuf, err := list.Serialize(e.context)
 	if err != nil {
-		return xerrors.Errorf("failed to marshal Form : %v", err)
+		return xerrors.Errorf("failed to marshal AdminList : %v", err)
 	}
 
-	err = snap.Set(formID, formBuf)
+	err = snap.Set(listIDBuf, listBuf)
 	if err != nil {
 		return xerrors.Errorf("failed to set value: %v", err)
 	}
@@ -807,121 +2063,97 @@ func (e evotingCommand) cancelForm(snap store.Snapshot, step execution.Step) err
 	return nil
 }
 
-// deleteForm implements commands. It performs the DELETE_FORM command
-func (e evotingCommand) deleteForm(snap store.Snapshot, step execution.Step) error {
+// fetchTenantAdminList returns the admin list of the tenant if the performing
+// user is one of its admins. The admins of the deployment can't manage the
+// admins of a tenant they don't belong to.
+func (e evotingCommand) fetchTenantAdminList(snap store.Snapshot, tenantID string,
+	txPerformingUser string) (types.AdminList, error) {
 
-	msg, err := e.getTransaction(step.Current)
+	isTenantAdmin, list, err := e.fetchTenantAdmin(snap, tenantID, txPerformingUser)
 	if err != nil {
-		return xerrors.Errorf(errGetTransaction, err)
+		return list, err
 	}
 
-	tx, ok := msg.(types.DeleteForm)
-	if !ok {
-		return xerrors.Errorf(errWrongTx, msg)
+	if !isTenantAdmin {
+		return list, types.Reject(types.RejectNotAdmin, "The performing user is not an admin of the tenant %s.",
+			tenantID)
 	}
 
-	form, formID, err := e.getForm(tx.FormID, snap)
-	if err != nil {
-		return xerrors.Errorf(errGetForm, err)
-	}
+	return list, nil
+}
 
-	isOwner, err := e.isRole(form, tx.UserID, Owners)
+// fetchTenantAdmin checks that the tenant exists and whether the performing
+// user is one of its admins.
+func (e evotingCommand) fetchTenantAdmin(snap store.Snapshot, tenantID string,
+	txPerformingUser string) (bool, types.AdminList, error) {
+
+	tenants, err := types.TenantsFromStore(e.context, snap, []byte(TenantsKey))
 	if err != nil {
-		return xerrors.Errorf(errIsRole, err)
+		return false, types.AdminList{}, xerrors.Errorf("failed to get tenants: %v", err)
 	}
 
-	if !isOwner {
-		return xerrors.Errorf(errNoOwnerPerms, tx.UserID)
+	if tenants.Contains(tenantID) < 0 {
+		return false, types.AdminList{}, xerrors.Errorf("tenant %q not found", tenantID)
 	}
 
-	err = snap.Delete(formID)
+	list, err := types.AdminListFromStore(e.context, e.adminListFac, snap,
+		TenantAdminListId(tenantID))
 	if err != nil {
-		return xerrors.Errorf("failed to delete form: %v", err)
+		return false, list, xerrors.Errorf("failed to get the AdminList of the tenant: %v", err)
 	}
 
-	err = updateFormMetadataStore(snap, form.FormID)
+	index, err := list.GetAdminIndex(txPerformingUser)
 	if err != nil {
-		return xerrors.Errorf("failed to update the metadata in the store: %v", err)
+		return false, list, xerrors.Errorf("couldn't retrieve admin permission of the performing user: %v", err)
 	}
 
-	return nil
+	return index >= 0, list, nil
 }
 
-// manageAdminList implements commands. It performs the ADD or REMOVE ADMIN command
-func (e evotingCommand) manageAdminList(snap store.Snapshot, step execution.Step) error {
+// revokeAccess implements commands. It performs the REVOKE_ACCESS command.
+// The credential of all the commands can't be revoked, nor the last
+// identities of a command, so that the nodes can't lock themselves out.
+func (e evotingCommand) revokeAccess(snap store.Snapshot, step execution.Step) error {
 	msg, err := e.getTransaction(step.Current)
 	if err != nil {
 		return xerrors.Errorf(errGetTransaction, err)
 	}
 
-	var list types.AdminList
-
-	h := sha256.New()
-	h.Write([]byte(AdminListId))
-	formIDBuf := h.Sum(nil)
-
-	txAddAdmin, okAddAdmin := msg.(types.AddAdmin)
-	txRemoveAdmin, okRemoveAdmin := msg.(types.RemoveAdmin)
-
-	if okAddAdmin {
-		isAdmin, listRetrieved, err := e.fetchAdmin(snap, txAddAdmin.PerformingUserID)
-		list = listRetrieved
-		if err != nil {
-			// Exact string matching of the error
-			if err.Error() != "failed to get the AdminList: No list found" {
-				return xerrors.Errorf("failed to get AdminList: %v", err)
-			}
-
-			// Trust On First Use System -> if no AdminList, will create one by default.
+	tx, ok := msg.(types.RevokeAccess)
+	if !ok {
+		return xerrors.Errorf(errWrongTx, msg)
+	}
 
-			intSciper, err := types.SciperToInt(txAddAdmin.TargetUserID)
-			if err != nil {
-				return xerrors.Errorf("Invalid Sciper: %v", err)
-			}
+	if tx.Command == "" {
+		return xerrors.Errorf("the command is empty")
+	}
 
-			err = initializeAdminList(snap, intSciper, e.context)
-			if err != nil {
-				return xerrors.Errorf("Failed to initialize admin list: %v", err)
-			}
+	if tx.Command == credentialAllCommand {
+		return xerrors.Errorf("the credential of all the commands can't be revoked")
+	}
 
-			// Adding the initial admin is performed by the initialize Admin List
-			// Therefore return
-			return nil
-		}
-		if !isAdmin {
-			return xerrors.Errorf("The performing user is not an admin.")
-		}
+	if len(tx.Identities) == 0 {
+		return xerrors.Errorf("no identity to revoke")
+	}
 
-		err = list.AddAdmin(txAddAdmin.TargetUserID)
-		if err != nil {
-			return xerrors.Errorf("couldn't add admin: %v", err)
-		}
-	} else if okRemoveAdmin {
-		isAdmin, listRetrieved, err := e.fetchAdmin(snap, txRemoveAdmin.PerformingUserID)
-		list = listRetrieved
+	identities := make([]access.Identity, len(tx.Identities))
+	for i, base64ID := range tx.Identities {
+		identity, err := base64.StdEncoding.DecodeString(base64ID)
 		if err != nil {
-			return err
-		}
-		if !isAdmin {
-			return xerrors.Errorf("The performing user is not an admin.")
+			return xerrors.Errorf("failed to decode base64ID: %v", err)
 		}
 
-		err = list.RemoveAdmin(txRemoveAdmin.TargetUserID)
+		pubKey, err := bls.NewPublicKey(identity)
 		if err != nil {
-			return xerrors.Errorf("couldn't remove admin: %v", err)
+			return xerrors.Errorf("failed to get public key: %v", err)
 		}
-	} else {
-		return xerrors.Errorf(errWrongTx, msg)
-	}
 
-	formBuf, err := list.Serialize(e.context)
-	if err != nil {
-		return xerrors.Errorf("failed to marshal Form : %v", err)
+		identities[i] = pubKey
 	}
 
-	err = snap.Set(formIDBuf, formBuf)
+	err = e.access.Revoke(snap, NewCommandCreds(Command(tx.Command)), identities...)
 	if err != nil {
-		return xerrors.Errorf("failed to set value: %v", err)
+		return xerrors.Errorf("failed to revoke %s: %v", tx.Command, err)
 	}
 
 	return nil
@@ -934,23 +2166,41 @@ func (e evotingCommand) isRole(form types.Form, txPerformingUser string, role Ro
 		return false, xerrors.Errorf("Failed to convert SCIPER to int: %v", err)
 	}
 
-	if role == Voters {
-		for i := 0; i < len(form.Voters); i++ {
-			if form.Voters[i] == sciperInt {
-				return true, nil
-			}
-		}
-	} else if role == Owners {
-		for i := 0; i < len(form.Owners); i++ {
-			if form.Owners[i] == sciperInt {
-				return true, nil
-			}
+	var members []int
+
+	switch role {
+	case Voters:
+		members = form.Voters
+	case Owners:
+		members = form.Owners
+	case Auditors:
+		members = form.Auditors
+	case Registrars:
+		members = form.Registrars
+	case Observers:
+		members = form.Observers
+	}
+
+	for _, member := range members {
+		if member == sciperInt {
+			return true, nil
 		}
 	}
 
 	return false, nil
 }
 
+// canManageVoters checks whether the user is an owner or a registrar of the
+// form, the roles that can add and remove voters.
+func (e evotingCommand) canManageVoters(form types.Form, txPerformingUser string) (bool, error) {
+	isOwner, err := e.isRole(form, txPerformingUser, Owners)
+	if err != nil || isOwner {
+		return isOwner, err
+	}
+
+	return e.isRole(form, txPerformingUser, Registrars)
+}
+
 // fetchAdmin Check whether a user is in an Admin List
 func (e evotingCommand) fetchAdmin(snap store.Snapshot, txPerformingUser string) (bool, types.AdminList, error) {
 	// If it found the AdminList
@@ -1001,7 +2251,7 @@ func initializeAdminList(snap store.Snapshot, initialAdmin int, ctx serde.Contex
 }
 
 // manageVotersForm implements commands.
-// It performs the ADD or REMOVE VOTERS/OWNERS command
+// It performs the ADD or REMOVE VOTERS/OWNERS/ROLES command
 func (e evotingCommand) manageOwnersVotersForm(snap store.Snapshot, step execution.Step) error {
 	msg, err := e.getTransaction(step.Current)
 	if err != nil {
@@ -1010,11 +2260,14 @@ func (e evotingCommand) manageOwnersVotersForm(snap store.Snapshot, step executi
 
 	var form types.Form
 	var formID []byte
+	var event types.FormEvent
 
 	txAddVoter, okAddVoter := msg.(types.AddVoter)
 	txRemoveVoter, okRemoveVoter := msg.(types.RemoveVoter)
 	txAddOwner, okAddOwner := msg.(types.AddOwner)
 	txRemoveOwner, okRemoveOwner := msg.(types.RemoveOwner)
+	txAddRole, okAddRole := msg.(types.AddRole)
+	txRemoveRole, okRemoveRole := msg.(types.RemoveRole)
 
 	if okAddVoter {
 		form, formID, err = e.getForm(txAddVoter.FormID, snap)
@@ -1022,38 +2275,60 @@ func (e evotingCommand) manageOwnersVotersForm(snap store.Snapshot, step executi
 			return xerrors.Errorf(errGetForm, err)
 		}
 
-		isOwner, err := e.isRole(form, txAddVoter.PerformingUserID, Owners)
+		canManage, err := e.canManageVoters(form, txAddVoter.PerformingUserID)
 		if err != nil {
 			return xerrors.Errorf(errIsRole, err)
 		}
 
-		if !isOwner {
-			return xerrors.Errorf(errNoOwnerPerms, txAddVoter.PerformingUserID)
+		if !canManage {
+			return types.Reject(types.RejectForbidden, errNoRegistrarPerms, txAddVoter.PerformingUserID)
+		}
+
+		limits, err := getLimits(snap)
+		if err != nil {
+			return xerrors.Errorf("failed to get limits: %v", err)
+		}
+
+		err = limits.CheckVoters(len(form.Voters) + 1)
+		if err != nil {
+			return xerrors.Errorf("couldn't add voter: %v", err)
 		}
 
 		err = form.AddVoter(txAddVoter.TargetUserID)
 		if err != nil {
 			return xerrors.Errorf("couldn't add voter: %v", err)
 		}
+
+		event = types.FormEvent{
+			Command: string(CmdAddVoterForm),
+			UserID:  txAddVoter.PerformingUserID,
+			Params:  map[string]string{"TargetUserID": txAddVoter.TargetUserID},
+		}
 	} else if okRemoveVoter {
 		form, formID, err = e.getForm(txRemoveVoter.FormID, snap)
 		if err != nil {
 			return xerrors.Errorf(errGetForm, err)
 		}
 
-		isOwner, err := e.isRole(form, txRemoveVoter.PerformingUserID, Owners)
+		canManage, err := e.canManageVoters(form, txRemoveVoter.PerformingUserID)
 		if err != nil {
 			return xerrors.Errorf(errIsRole, err)
 		}
 
-		if !isOwner {
-			return xerrors.Errorf(errNoOwnerPerms, txRemoveVoter.PerformingUserID)
+		if !canManage {
+			return types.Reject(types.RejectForbidden, errNoRegistrarPerms, txRemoveVoter.PerformingUserID)
 		}
 
 		err = form.RemoveVoter(txRemoveVoter.TargetUserID)
 		if err != nil {
 			return xerrors.Errorf("couldn't remove voter: %v", err)
 		}
+
+		event = types.FormEvent{
+			Command: string(CmdRemoveVoterForm),
+			UserID:  txRemoveVoter.PerformingUserID,
+			Params:  map[string]string{"TargetUserID": txRemoveVoter.TargetUserID},
+		}
 	} else if okAddOwner {
 		form, formID, err = e.getForm(txAddOwner.FormID, snap)
 		if err != nil {
@@ -1066,13 +2341,19 @@ func (e evotingCommand) manageOwnersVotersForm(snap store.Snapshot, step executi
 		}
 
 		if !isOwner {
-			return xerrors.Errorf(errNoOwnerPerms, txAddOwner.PerformingUserID)
+			return types.Reject(types.RejectNotOwner, errNoOwnerPerms, txAddOwner.PerformingUserID)
 		}
 
 		err = form.AddOwner(txAddOwner.TargetUserID)
 		if err != nil {
 			return xerrors.Errorf("couldn't add owner: %v", err)
 		}
+
+		event = types.FormEvent{
+			Command: string(CmdAddOwnerForm),
+			UserID:  txAddOwner.PerformingUserID,
+			Params:  map[string]string{"TargetUserID": txAddOwner.TargetUserID},
+		}
 	} else if okRemoveOwner {
 		form, formID, err = e.getForm(txRemoveOwner.FormID, snap)
 		if err != nil {
@@ -1085,30 +2366,133 @@ func (e evotingCommand) manageOwnersVotersForm(snap store.Snapshot, step executi
 		}
 
 		if !isOwner {
-			return xerrors.Errorf(errNoOwnerPerms, txRemoveOwner.PerformingUserID)
+			return types.Reject(types.RejectNotOwner, errNoOwnerPerms, txRemoveOwner.PerformingUserID)
 		}
 
 		err = form.RemoveOwner(txRemoveOwner.TargetUserID)
 		if err != nil {
 			return xerrors.Errorf("couldn't remove owner: %v", err)
 		}
+
+		event = types.FormEvent{
+			Command: string(CmdRemoveOwnerForm),
+			UserID:  txRemoveOwner.PerformingUserID,
+			Params:  map[string]string{"TargetUserID": txRemoveOwner.TargetUserID},
+		}
+	} else if okAddRole {
+		form, formID, err = e.getForm(txAddRole.FormID, snap)
+		if err != nil {
+			return xerrors.Errorf(errGetForm, err)
+		}
+
+		isOwner, err := e.isRole(form, txAddRole.PerformingUserID, Owners)
+		if err != nil {
+			return xerrors.Errorf(errIsRole, err)
+		}
+
+		if !isOwner {
+			return types.Reject(types.RejectNotOwner, errNoOwnerPerms, txAddRole.PerformingUserID)
+		}
+
+		err = form.AddRoleMember(txAddRole.Role, txAddRole.TargetUserID)
+		if err != nil {
+			return xerrors.Errorf("couldn't add role: %v", err)
+		}
+
+		event = types.FormEvent{
+			Command: string(CmdAddRoleForm),
+			UserID:  txAddRole.PerformingUserID,
+			Params: map[string]string{
+				"Role":         string(txAddRole.Role),
+				"TargetUserID": txAddRole.TargetUserID,
+			},
+		}
+	} else if okRemoveRole {
+		form, formID, err = e.getForm(txRemoveRole.FormID, snap)
+		if err != nil {
+			return xerrors.Errorf(errGetForm, err)
+		}
+
+		isOwner, err := e.isRole(form, txRemoveRole.PerformingUserID, Owners)
+		if err != nil {
+			return xerrors.Errorf(errIsRole, err)
+		}
+
+		if !isOwner {
+			return types.Reject(types.RejectNotOwner, errNoOwnerPerms, txRemoveRole.PerformingUserID)
+		}
+
+		err = form.RemoveRoleMember(txRemoveRole.Role, txRemoveRole.TargetUserID)
+		if err != nil {
+			return xerrors.Errorf("couldn't remove role: %v", err)
+		}
+
+		event = types.FormEvent{
+			Command: string(CmdRemoveRoleForm),
+			UserID:  txRemoveRole.PerformingUserID,
+			Params: map[string]string{
+				"Role":         string(txRemoveRole.Role),
+				"TargetUserID": txRemoveRole.TargetUserID,
+			},
+		}
 	} else {
 		return xerrors.Errorf(errWrongTx, msg)
 	}
 
-	formBuf, err := form.Serialize(e.context)
+	err = e.setForm(snap, formID, form)
 	if err != nil {
-		return xerrors.Errorf("failed to marshal Form : %v", err)
+		return xerrors.Errorf(errSetForm, err)
 	}
 
-	err = snap.Set(formID, formBuf)
+	err = updateUserForms(snap, form.FormID, msg)
 	if err != nil {
-		return xerrors.Errorf("failed to set value: %v", err)
+		return xerrors.Errorf(errUserForms, err)
+	}
+
+	event.BlockIndex = e.blocks.Len()
+
+	err = types.AppendEvent(e.context, snap, formID, event)
+	if err != nil {
+		return xerrors.Errorf(errRecordEvent, err)
 	}
 
 	return nil
 }
 
+// updateUserForms adds the form to the forms of the owner or voter added by
+// the transaction, or removes it from the forms of the one removed. The other
+// roles are not indexed.
+func updateUserForms(snap store.Snapshot, formID string, msg serde.Message) error {
+	var index types.UserForms
+	var userID string
+
+	add := false
+
+	switch tx := msg.(type) {
+	case types.AddVoter:
+		index, userID, add = types.VoterForms, tx.TargetUserID, true
+	case types.RemoveVoter:
+		index, userID = types.VoterForms, tx.TargetUserID
+	case types.AddOwner:
+		index, userID, add = types.OwnerForms, tx.TargetUserID, true
+	case types.RemoveOwner:
+		index, userID = types.OwnerForms, tx.TargetUserID
+	default:
+		return nil
+	}
+
+	sciperInt, err := types.SciperToInt(userID)
+	if err != nil {
+		return xerrors.Errorf("failed to convert SCIPER to integer: %v", err)
+	}
+
+	if add {
+		return index.Add(snap, formID, sciperInt)
+	}
+
+	return index.Remove(snap, formID, sciperInt)
+}
+
 // isMemberOf is a utility function to verify if a public key is associated to a
 // member of the roster or not. Returns nil if it's the case.
 func isMemberOf(roster authority.Authority, publicKey []byte) error {
@@ -1252,3 +2636,62 @@ func decrypt(ballot int, pair int, allPubShares []types.PubsharesUnit, indexes [
 
 	return decryptedMessage, nil
 }
+
+// setLimits implements commands. It performs the SET_LIMITS command. Only
+// the admins of the deployment can set the limits, which apply to the forms
+// created and the voters added afterwards.
+func (e evotingCommand) setLimits(snap store.Snapshot, step execution.Step) error {
+	msg, err := e.getTransaction(step.Current)
+	if err != nil {
+		return xerrors.Errorf(errGetTransaction, err)
+	}
+
+	tx, ok := msg.(types.SetLimits)
+	if !ok {
+		return xerrors.Errorf(errWrongTx, msg)
+	}
+
+	isAdmin, _, err := e.fetchAdmin(snap, tx.PerformingUserID)
+	if err != nil {
+		return xerrors.Errorf("failed to get AdminList: %v", err)
+	}
+
+	if !isAdmin {
+		return types.Reject(types.RejectNotAdmin, "The performing user is not an admin.")
+	}
+
+	parameters, err := types.ParametersFromStore(snap, []byte(ParametersKey))
+	if err != nil {
+		return xerrors.Errorf("failed to get parameters: %v", err)
+	}
+
+	// the limits left at 0 are kept
+	parameters.Limits = parameters.Limits.Merge(tx.Limits)
+
+	err = parameters.Limits.Validate()
+	if err != nil {
+		return xerrors.Errorf("invalid limits: %v", err)
+	}
+
+	parametersBuf, err := json.Marshal(parameters)
+	if err != nil {
+		return xerrors.Errorf("failed to marshal parameters: %v", err)
+	}
+
+	err = snap.Set([]byte(ParametersKey), parametersBuf)
+	if err != nil {
+		return xerrors.Errorf("failed to set parameters: %v", err)
+	}
+
+	return nil
+}
+
+// getLimits returns the resource limits of the forms
+func getLimits(snap store.Readable) (types.Limits, error) {
+	parameters, err := types.ParametersFromStore(snap, []byte(ParametersKey))
+	if err != nil {
+		return types.Limits{}, xerrors.Errorf("failed to get parameters: %v", err)
+	}
+
+	return parameters.Limits, nil
+}
@@ -0,0 +1,55 @@
+package evoting
+
+import (
+	"testing"
+
+	"github.com/c4dt/d-voting/contracts/evoting/types"
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/dela/testing/fake"
+)
+
+func TestHistory_CountEvent(t *testing.T) {
+	snap := fake.NewSnapshot()
+
+	require.NoError(t, types.CountEvent(ctx, snap, dummyFormIDBuff, "CAST_VOTE", 1))
+	require.NoError(t, types.CountEvent(ctx, snap, dummyFormIDBuff, "CAST_VOTE", 1))
+	require.NoError(t, types.CountEvent(ctx, snap, dummyFormIDBuff, "CAST_VOTE", 2))
+	require.NoError(t, types.AppendEvent(ctx, snap, dummyFormIDBuff, types.FormEvent{
+		Command:    "CLOSE_FORM",
+		UserID:     "123",
+		BlockIndex: 2,
+	}))
+	require.NoError(t, types.CountEvent(ctx, snap, dummyFormIDBuff, "CAST_VOTE", 2))
+
+	history, err := types.HistoryFromStore(ctx, fakeFormID, snap)
+	require.NoError(t, err)
+
+	// the votes of a block are merged, but an event of a committed block is
+	// not changed
+	require.Equal(t, []types.FormEvent{
+		{Command: "CAST_VOTE", BlockIndex: 1, Params: map[string]string{"Count": "2"}},
+		{Command: "CAST_VOTE", BlockIndex: 2, Params: map[string]string{"Count": "1"}},
+		{Command: "CLOSE_FORM", UserID: "123", BlockIndex: 2},
+		{Command: "CAST_VOTE", BlockIndex: 2, Params: map[string]string{"Count": "1"}},
+	}, history.Events)
+}
+
+func TestHistory_FromStore(t *testing.T) {
+	_, err := types.HistoryFromStore(ctx, "not hex", fake.NewSnapshot())
+	require.Error(t, err)
+
+	history, err := types.HistoryFromStore(ctx, fakeFormID, fake.NewSnapshot())
+	require.NoError(t, err)
+	require.Empty(t, history.Events)
+
+	_, err = types.HistoryFromStore(ctx, fakeFormID, fake.NewBadSnapshot())
+	require.ErrorContains(t, err, "while getting data for history")
+
+	snap := fake.NewSnapshot()
+
+	err = snap.Set(types.HistoryKey(dummyFormIDBuff), []byte{1})
+	require.NoError(t, err)
+
+	_, err = types.HistoryFromStore(ctx, fakeFormID, snap)
+	require.EqualError(t, err, "invalid history length: 01")
+}
@@ -71,6 +71,7 @@ func (formFormat) Encode(ctx serde.Context, message serde.Message) ([]byte, erro
 			Suffragias:       suffragias,
 			SuffragiaHashes:  suffragiaHashes,
 			BallotCount:      m.BallotCount,
+			LiveBallots:      m.LiveBallots,
 			ShuffleInstances: shuffleInstances,
 			ShuffleThreshold: m.ShuffleThreshold,
 			PubsharesUnits:   pubsharesUnits,
@@ -78,6 +79,16 @@ func (formFormat) Encode(ctx serde.Context, message serde.Message) ([]byte, erro
 			RosterBuf:        rosterBuf,
 			Owners:           m.Owners,
 			Voters:           m.Voters,
+			CloseBlock:       m.CloseBlock,
+			Reopenings:       m.Reopenings,
+			ParentFormID:     m.ParentFormID,
+			RunoffFormIDs:    m.RunoffFormIDs,
+			TenantID:         m.TenantID,
+			Auditors:         m.Auditors,
+			Registrars:       m.Registrars,
+			Observers:        m.Observers,
+			Private:          m.Private,
+			Delegations:      m.Delegations,
 		}
 
 		buff, err := ctx.Marshal(&formJSON)
@@ -156,6 +167,7 @@ func (formFormat) Decode(ctx serde.Context, data []byte) (serde.Message, error)
 		SuffragiaIDs:     suffragias,
 		SuffragiaHashes:  suffragiaHashes,
 		BallotCount:      formJSON.BallotCount,
+		LiveBallots:      formJSON.LiveBallots,
 		ShuffleInstances: shuffleInstances,
 		ShuffleThreshold: formJSON.ShuffleThreshold,
 		PubsharesUnits:   pubSharesSubmissions,
@@ -163,6 +175,16 @@ func (formFormat) Decode(ctx serde.Context, data []byte) (serde.Message, error)
 		Roster:           roster,
 		Owners:           formJSON.Owners,
 		Voters:           formJSON.Voters,
+		CloseBlock:       formJSON.CloseBlock,
+		Reopenings:       formJSON.Reopenings,
+		ParentFormID:     formJSON.ParentFormID,
+		RunoffFormIDs:    formJSON.RunoffFormIDs,
+		TenantID:         formJSON.TenantID,
+		Auditors:         formJSON.Auditors,
+		Registrars:       formJSON.Registrars,
+		Observers:        formJSON.Observers,
+		Private:          formJSON.Private,
+		Delegations:      formJSON.Delegations,
 	}, nil
 }
 
@@ -188,6 +210,9 @@ type FormJSON struct {
 	// BallotCount represents the total number of ballots cast.
 	BallotCount uint32
 
+	// LiveBallots is the number of ballots that have not been withdrawn.
+	LiveBallots uint32
+
 	// SuffragiaHashes are the hex-encoded sha256-hashes of the ballots
 	// in every Suffragia.
 	SuffragiaHashes []string
@@ -216,6 +241,19 @@ type FormJSON struct {
 
 	// Store the list of SCIPER of user that are Voters on the form.
 	Voters []int
+
+	CloseBlock uint64            `json:",omitempty"`
+	Reopenings []types.Reopening `json:",omitempty"`
+
+	ParentFormID  string   `json:",omitempty"`
+	RunoffFormIDs []string `json:",omitempty"`
+	TenantID      string   `json:",omitempty"`
+	Auditors      []int    `json:",omitempty"`
+	Registrars    []int    `json:",omitempty"`
+	Observers     []int    `json:",omitempty"`
+	Private       bool     `json:",omitempty"`
+
+	Delegations []types.Delegation `json:",omitempty"`
 }
 
 // ShuffleInstanceJSON defines the JSON representation of a shuffle instance
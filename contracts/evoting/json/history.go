@@ -0,0 +1,54 @@
+package json
+
+import (
+	"github.com/c4dt/d-voting/contracts/evoting/types"
+	"go.dedis.ch/dela/serde"
+	"golang.org/x/xerrors"
+)
+
+type formEventFormat struct{}
+
+func (formEventFormat) Encode(ctx serde.Context, message serde.Message) ([]byte, error) {
+	event, ok := message.(types.FormEvent)
+	if !ok {
+		return nil, xerrors.Errorf("Unknown format: %T", message)
+	}
+
+	eventJSON := FormEventJSON{
+		Command:    event.Command,
+		UserID:     event.UserID,
+		BlockIndex: event.BlockIndex,
+		Params:     event.Params,
+	}
+
+	buff, err := ctx.Marshal(&eventJSON)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to marshal form event: %v", err)
+	}
+
+	return buff, nil
+}
+
+func (formEventFormat) Decode(ctx serde.Context, data []byte) (serde.Message, error) {
+	var eventJSON FormEventJSON
+
+	err := ctx.Unmarshal(data, &eventJSON)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to unmarshal form event: %v", err)
+	}
+
+	return types.FormEvent{
+		Command:    eventJSON.Command,
+		UserID:     eventJSON.UserID,
+		BlockIndex: eventJSON.BlockIndex,
+		Params:     eventJSON.Params,
+	}, nil
+}
+
+// FormEventJSON defines the FormEvent in the JSON format
+type FormEventJSON struct {
+	Command    string
+	UserID     string `json:",omitempty"`
+	BlockIndex uint64
+	Params     map[string]string `json:",omitempty"`
+}
@@ -13,4 +13,7 @@ func init() {
 	types.RegisterCiphervoteFormat(serde.FormatJSON, ciphervoteFormat{})
 	types.RegisterTransactionFormat(serde.FormatJSON, transactionFormat{})
 	types.RegisterAdminListFormat(serde.FormatJSON, adminListFormat{})
+	types.RegisterVoterRecordFormat(serde.FormatJSON, voterRecordFormat{})
+	types.RegisterFormEventFormat(serde.FormatJSON, formEventFormat{})
+	types.RegisterTenantsFormat(serde.FormatJSON, tenantsFormat{})
 }
@@ -44,6 +44,7 @@ func (suffragiaFormat) Decode(ctx serde.Context, data []byte) (serde.Message, er
 type SuffragiaJSON struct {
 	VoterIDs    []string
 	Ciphervotes []json.RawMessage
+	Tombstones  []bool `json:",omitempty"`
 }
 
 func encodeSuffragia(ctx serde.Context, suffragia types.Suffragia) (SuffragiaJSON, error) {
@@ -60,6 +61,7 @@ func encodeSuffragia(ctx serde.Context, suffragia types.Suffragia) (SuffragiaJSO
 	return SuffragiaJSON{
 		VoterIDs:    suffragia.VoterIDs,
 		Ciphervotes: ciphervotes,
+		Tombstones:  suffragia.Tombstones,
 	}, nil
 }
 
@@ -91,6 +93,7 @@ func decodeSuffragia(ctx serde.Context, suffragiaJSON SuffragiaJSON) (types.Suff
 	res = types.Suffragia{
 		VoterIDs:    suffragiaJSON.VoterIDs,
 		Ciphervotes: ciphervotes,
+		Tombstones:  suffragiaJSON.Tombstones,
 	}
 
 	return res, nil
@@ -0,0 +1,67 @@
+package json
+
+import (
+	"github.com/c4dt/d-voting/contracts/evoting/types"
+	"go.dedis.ch/dela/serde"
+	"golang.org/x/xerrors"
+)
+
+type tenantsFormat struct{}
+
+func (tenantsFormat) Encode(ctx serde.Context, message serde.Message) ([]byte, error) {
+	tenants, ok := message.(types.Tenants)
+	if !ok {
+		return nil, xerrors.Errorf("Unknown format: %T", message)
+	}
+
+	tenantsJSON := TenantsJSON{
+		Tenants: make([]TenantJSON, len(tenants.Tenants)),
+	}
+
+	for i, tenant := range tenants.Tenants {
+		tenantsJSON.Tenants[i] = TenantJSON{
+			ID:   tenant.ID,
+			Name: tenant.Name,
+		}
+	}
+
+	buff, err := ctx.Marshal(&tenantsJSON)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to marshal tenants: %v", err)
+	}
+
+	return buff, nil
+}
+
+func (tenantsFormat) Decode(ctx serde.Context, data []byte) (serde.Message, error) {
+	var tenantsJSON TenantsJSON
+
+	err := ctx.Unmarshal(data, &tenantsJSON)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to unmarshal tenants: %v", err)
+	}
+
+	tenants := types.Tenants{
+		Tenants: make([]types.Tenant, len(tenantsJSON.Tenants)),
+	}
+
+	for i, tenant := range tenantsJSON.Tenants {
+		tenants.Tenants[i] = types.Tenant{
+			ID:   tenant.ID,
+			Name: tenant.Name,
+		}
+	}
+
+	return tenants, nil
+}
+
+// TenantsJSON defines the Tenants in the JSON format
+type TenantsJSON struct {
+	Tenants []TenantJSON
+}
+
+// TenantJSON defines the Tenant in the JSON format
+type TenantJSON struct {
+	ID   string
+	Name string
+}
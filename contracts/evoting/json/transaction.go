@@ -22,6 +22,9 @@ func (transactionFormat) Encode(ctx serde.Context, msg serde.Message) ([]byte, e
 		ce := CreateFormJSON{
 			Configuration: t.Configuration,
 			UserID:        t.UserID,
+			TenantID:      t.TenantID,
+			CloseBlock:    t.CloseBlock,
+			Private:       t.Private,
 		}
 
 		m = TransactionJSON{CreateForm: &ce}
@@ -39,12 +42,36 @@ func (transactionFormat) Encode(ctx serde.Context, msg serde.Message) ([]byte, e
 		}
 
 		cv := CastVoteJSON{
+			FormID:      t.FormID,
+			VoterID:     t.VoterID,
+			Ciphervote:  ballot,
+			DelegatorID: t.DelegatorID,
+		}
+
+		m = TransactionJSON{CastVote: &cv}
+	case types.DelegateVote:
+		dv := DelegateVoteJSON{
 			FormID:     t.FormID,
 			VoterID:    t.VoterID,
-			Ciphervote: ballot,
+			DelegateID: t.DelegateID,
 		}
 
-		m = TransactionJSON{CastVote: &cv}
+		m = TransactionJSON{DelegateVote: &dv}
+	case types.RevokeDelegation:
+		rd := RevokeDelegationJSON{
+			FormID:  t.FormID,
+			VoterID: t.VoterID,
+		}
+
+		m = TransactionJSON{RevokeDelegation: &rd}
+	case types.WithdrawVote:
+		wv := WithdrawVoteJSON{
+			FormID:      t.FormID,
+			VoterID:     t.VoterID,
+			DelegatorID: t.DelegatorID,
+		}
+
+		m = TransactionJSON{WithdrawVote: &wv}
 	case types.CloseForm:
 		ce := CloseFormJSON{
 			FormID: t.FormID,
@@ -52,6 +79,38 @@ func (transactionFormat) Encode(ctx serde.Context, msg serde.Message) ([]byte, e
 		}
 
 		m = TransactionJSON{CloseForm: &ce}
+	case types.ReopenForm:
+		re := ReopenFormJSON{
+			FormID:     t.FormID,
+			UserID:     t.UserID,
+			Reason:     t.Reason,
+			CloseBlock: t.CloseBlock,
+		}
+
+		m = TransactionJSON{ReopenForm: &re}
+	case types.CreateRunoff:
+		cr := CreateRunoffJSON{
+			FormID:     t.FormID,
+			UserID:     t.UserID,
+			QuestionID: string(t.QuestionID),
+			Rule:       t.Rule,
+		}
+
+		m = TransactionJSON{CreateRunoff: &cr}
+	case types.SuspendForm:
+		se := SuspendFormJSON{
+			FormID: t.FormID,
+			UserID: t.UserID,
+		}
+
+		m = TransactionJSON{SuspendForm: &se}
+	case types.ResumeForm:
+		re := ResumeFormJSON{
+			FormID: t.FormID,
+			UserID: t.UserID,
+		}
+
+		m = TransactionJSON{ResumeForm: &re}
 	case types.ShuffleBallots:
 		ciphervotes := make([]json.RawMessage, len(t.ShuffledBallots))
 
@@ -117,6 +176,7 @@ func (transactionFormat) Encode(ctx serde.Context, msg serde.Message) ([]byte, e
 	case types.DeleteForm:
 		de := DeleteFormJSON{
 			FormID: t.FormID,
+			UserID: t.UserID,
 		}
 
 		m = TransactionJSON{DeleteForm: &de}
@@ -134,6 +194,31 @@ func (transactionFormat) Encode(ctx serde.Context, msg serde.Message) ([]byte, e
 		}
 
 		m = TransactionJSON{RemoveAdmin: &ra}
+	case types.CreateTenant:
+		ct := CreateTenantJSON{
+			TenantID:         t.TenantID,
+			Name:             t.Name,
+			AdminID:          t.AdminID,
+			PerformingUserID: t.PerformingUserID,
+		}
+
+		m = TransactionJSON{CreateTenant: &ct}
+	case types.AddTenantAdmin:
+		ta := TenantAdminJSON{
+			TenantID:         t.TenantID,
+			TargetUserID:     t.TargetUserID,
+			PerformingUserID: t.PerformingUserID,
+		}
+
+		m = TransactionJSON{AddTenantAdmin: &ta}
+	case types.RemoveTenantAdmin:
+		ta := TenantAdminJSON{
+			TenantID:         t.TenantID,
+			TargetUserID:     t.TargetUserID,
+			PerformingUserID: t.PerformingUserID,
+		}
+
+		m = TransactionJSON{RemoveTenantAdmin: &ta}
 	case types.AddOwner:
 		addOwner := AddOwnerJSON{
 			FormID:           t.FormID,
@@ -166,6 +251,69 @@ func (transactionFormat) Encode(ctx serde.Context, msg serde.Message) ([]byte, e
 		}
 
 		m = TransactionJSON{RemoveVoter: &removeVoter}
+	case types.AddRole:
+		addRole := RoleJSON{
+			FormID:           t.FormID,
+			Role:             string(t.Role),
+			TargetUserID:     t.TargetUserID,
+			PerformingUserID: t.PerformingUserID,
+		}
+
+		m = TransactionJSON{AddRole: &addRole}
+	case types.RemoveRole:
+		removeRole := RoleJSON{
+			FormID:           t.FormID,
+			Role:             string(t.Role),
+			TargetUserID:     t.TargetUserID,
+			PerformingUserID: t.PerformingUserID,
+		}
+
+		m = TransactionJSON{RemoveRole: &removeRole}
+	case types.VerifyForm:
+		verifyForm := VerifyFormJSON{
+			FormID: t.FormID,
+			UserID: t.UserID,
+		}
+
+		m = TransactionJSON{VerifyForm: &verifyForm}
+	case types.RevokeAccess:
+		revokeAccess := RevokeAccessJSON{
+			Command:    t.Command,
+			Identities: t.Identities,
+		}
+
+		m = TransactionJSON{RevokeAccess: &revokeAccess}
+	case types.SetLimits:
+		setLimits := SetLimitsJSON{
+			Limits:           t.Limits,
+			PerformingUserID: t.PerformingUserID,
+		}
+
+		m = TransactionJSON{SetLimits: &setLimits}
+	case types.ProposeAdminChange:
+		propose := ProposeAdminChangeJSON{
+			Action:           string(t.Action),
+			TargetUserID:     t.TargetUserID,
+			Threshold:        t.Threshold,
+			PerformingUserID: t.PerformingUserID,
+			Lifetime:         t.Lifetime,
+		}
+
+		m = TransactionJSON{ProposeAdminChange: &propose}
+	case types.ApproveAdminProposal:
+		approve := AdminProposalVoteJSON{
+			ProposalID:       t.ProposalID,
+			PerformingUserID: t.PerformingUserID,
+		}
+
+		m = TransactionJSON{ApproveAdminProposal: &approve}
+	case types.RejectAdminProposal:
+		reject := AdminProposalVoteJSON{
+			ProposalID:       t.ProposalID,
+			PerformingUserID: t.PerformingUserID,
+		}
+
+		m = TransactionJSON{RejectAdminProposal: &reject}
 	default:
 		return nil, xerrors.Errorf("unknown type: '%T", msg)
 	}
@@ -192,6 +340,9 @@ func (transactionFormat) Decode(ctx serde.Context, data []byte) (serde.Message,
 		return types.CreateForm{
 			Configuration: m.CreateForm.Configuration,
 			UserID:        m.CreateForm.UserID,
+			TenantID:      m.CreateForm.TenantID,
+			CloseBlock:    m.CreateForm.CloseBlock,
+			Private:       m.CreateForm.Private,
 		}, nil
 	case m.OpenForm != nil:
 		return types.OpenForm{
@@ -205,11 +356,52 @@ func (transactionFormat) Decode(ctx serde.Context, data []byte) (serde.Message,
 		}
 
 		return msg, nil
+	case m.DelegateVote != nil:
+		return types.DelegateVote{
+			FormID:     m.DelegateVote.FormID,
+			VoterID:    m.DelegateVote.VoterID,
+			DelegateID: m.DelegateVote.DelegateID,
+		}, nil
+	case m.RevokeDelegation != nil:
+		return types.RevokeDelegation{
+			FormID:  m.RevokeDelegation.FormID,
+			VoterID: m.RevokeDelegation.VoterID,
+		}, nil
+	case m.WithdrawVote != nil:
+		return types.WithdrawVote{
+			FormID:      m.WithdrawVote.FormID,
+			VoterID:     m.WithdrawVote.VoterID,
+			DelegatorID: m.WithdrawVote.DelegatorID,
+		}, nil
 	case m.CloseForm != nil:
 		return types.CloseForm{
 			FormID: m.CloseForm.FormID,
 			UserID: m.CloseForm.UserID,
 		}, nil
+	case m.ReopenForm != nil:
+		return types.ReopenForm{
+			FormID:     m.ReopenForm.FormID,
+			UserID:     m.ReopenForm.UserID,
+			Reason:     m.ReopenForm.Reason,
+			CloseBlock: m.ReopenForm.CloseBlock,
+		}, nil
+	case m.CreateRunoff != nil:
+		return types.CreateRunoff{
+			FormID:     m.CreateRunoff.FormID,
+			UserID:     m.CreateRunoff.UserID,
+			QuestionID: types.ID(m.CreateRunoff.QuestionID),
+			Rule:       m.CreateRunoff.Rule,
+		}, nil
+	case m.SuspendForm != nil:
+		return types.SuspendForm{
+			FormID: m.SuspendForm.FormID,
+			UserID: m.SuspendForm.UserID,
+		}, nil
+	case m.ResumeForm != nil:
+		return types.ResumeForm{
+			FormID: m.ResumeForm.FormID,
+			UserID: m.ResumeForm.UserID,
+		}, nil
 	case m.ShuffleBallots != nil:
 		msg, err := decodeShuffleBallots(ctx, *m.ShuffleBallots)
 		if err != nil {
@@ -237,6 +429,7 @@ func (transactionFormat) Decode(ctx serde.Context, data []byte) (serde.Message,
 	case m.DeleteForm != nil:
 		return types.DeleteForm{
 			FormID: m.DeleteForm.FormID,
+			UserID: m.DeleteForm.UserID,
 		}, nil
 	case m.AddAdmin != nil:
 		return types.AddAdmin{
@@ -248,6 +441,25 @@ func (transactionFormat) Decode(ctx serde.Context, data []byte) (serde.Message,
 			TargetUserID:     m.RemoveAdmin.TargetUserID,
 			PerformingUserID: m.RemoveAdmin.PerformingUserID,
 		}, nil
+	case m.CreateTenant != nil:
+		return types.CreateTenant{
+			TenantID:         m.CreateTenant.TenantID,
+			Name:             m.CreateTenant.Name,
+			AdminID:          m.CreateTenant.AdminID,
+			PerformingUserID: m.CreateTenant.PerformingUserID,
+		}, nil
+	case m.AddTenantAdmin != nil:
+		return types.AddTenantAdmin{
+			TenantID:         m.AddTenantAdmin.TenantID,
+			TargetUserID:     m.AddTenantAdmin.TargetUserID,
+			PerformingUserID: m.AddTenantAdmin.PerformingUserID,
+		}, nil
+	case m.RemoveTenantAdmin != nil:
+		return types.RemoveTenantAdmin{
+			TenantID:         m.RemoveTenantAdmin.TenantID,
+			TargetUserID:     m.RemoveTenantAdmin.TargetUserID,
+			PerformingUserID: m.RemoveTenantAdmin.PerformingUserID,
+		}, nil
 	case m.AddOwner != nil:
 		return types.AddOwner{
 			FormID:           m.AddOwner.FormID,
@@ -272,6 +484,53 @@ func (transactionFormat) Decode(ctx serde.Context, data []byte) (serde.Message,
 			TargetUserID:     m.RemoveVoter.TargetUserID,
 			PerformingUserID: m.RemoveVoter.PerformingUserID,
 		}, nil
+	case m.AddRole != nil:
+		return types.AddRole{
+			FormID:           m.AddRole.FormID,
+			Role:             types.FormRole(m.AddRole.Role),
+			TargetUserID:     m.AddRole.TargetUserID,
+			PerformingUserID: m.AddRole.PerformingUserID,
+		}, nil
+	case m.RemoveRole != nil:
+		return types.RemoveRole{
+			FormID:           m.RemoveRole.FormID,
+			Role:             types.FormRole(m.RemoveRole.Role),
+			TargetUserID:     m.RemoveRole.TargetUserID,
+			PerformingUserID: m.RemoveRole.PerformingUserID,
+		}, nil
+	case m.VerifyForm != nil:
+		return types.VerifyForm{
+			FormID: m.VerifyForm.FormID,
+			UserID: m.VerifyForm.UserID,
+		}, nil
+	case m.RevokeAccess != nil:
+		return types.RevokeAccess{
+			Command:    m.RevokeAccess.Command,
+			Identities: m.RevokeAccess.Identities,
+		}, nil
+	case m.SetLimits != nil:
+		return types.SetLimits{
+			Limits:           m.SetLimits.Limits,
+			PerformingUserID: m.SetLimits.PerformingUserID,
+		}, nil
+	case m.ProposeAdminChange != nil:
+		return types.ProposeAdminChange{
+			Action:           types.ProposalAction(m.ProposeAdminChange.Action),
+			TargetUserID:     m.ProposeAdminChange.TargetUserID,
+			Threshold:        m.ProposeAdminChange.Threshold,
+			PerformingUserID: m.ProposeAdminChange.PerformingUserID,
+			Lifetime:         m.ProposeAdminChange.Lifetime,
+		}, nil
+	case m.ApproveAdminProposal != nil:
+		return types.ApproveAdminProposal{
+			ProposalID:       m.ApproveAdminProposal.ProposalID,
+			PerformingUserID: m.ApproveAdminProposal.PerformingUserID,
+		}, nil
+	case m.RejectAdminProposal != nil:
+		return types.RejectAdminProposal{
+			ProposalID:       m.RejectAdminProposal.ProposalID,
+			PerformingUserID: m.RejectAdminProposal.PerformingUserID,
+		}, nil
 	}
 
 	return nil, xerrors.Errorf("empty type: %s", data)
@@ -283,7 +542,14 @@ type TransactionJSON struct {
 	CreateForm        *CreateFormJSON        `json:",omitempty"`
 	OpenForm          *OpenFormJSON          `json:",omitempty"`
 	CastVote          *CastVoteJSON          `json:",omitempty"`
+	WithdrawVote      *WithdrawVoteJSON      `json:",omitempty"`
+	DelegateVote      *DelegateVoteJSON      `json:",omitempty"`
+	RevokeDelegation  *RevokeDelegationJSON  `json:",omitempty"`
 	CloseForm         *CloseFormJSON         `json:",omitempty"`
+	ReopenForm        *ReopenFormJSON        `json:",omitempty"`
+	CreateRunoff      *CreateRunoffJSON      `json:",omitempty"`
+	SuspendForm       *SuspendFormJSON       `json:",omitempty"`
+	ResumeForm        *ResumeFormJSON        `json:",omitempty"`
 	ShuffleBallots    *ShuffleBallotsJSON    `json:",omitempty"`
 	RegisterPubShares *RegisterPubSharesJSON `json:",omitempty"`
 	CombineShares     *CombineSharesJSON     `json:",omitempty"`
@@ -291,16 +557,31 @@ type TransactionJSON struct {
 	DeleteForm        *DeleteFormJSON        `json:",omitempty"`
 	AddAdmin          *AddAdminJSON          `json:",omitempty"`
 	RemoveAdmin       *RemoveAdminJSON       `json:",omitempty"`
+	CreateTenant      *CreateTenantJSON      `json:",omitempty"`
+	AddTenantAdmin    *TenantAdminJSON       `json:",omitempty"`
+	RemoveTenantAdmin *TenantAdminJSON       `json:",omitempty"`
 	AddOwner          *AddOwnerJSON          `json:",omitempty"`
 	RemoveOwner       *RemoveOwnerJSON       `json:",omitempty"`
 	AddVoter          *AddVoterJSON          `json:",omitempty"`
 	RemoveVoter       *RemoveVoterJSON       `json:",omitempty"`
+	AddRole           *RoleJSON              `json:",omitempty"`
+	RemoveRole        *RoleJSON              `json:",omitempty"`
+	VerifyForm        *VerifyFormJSON        `json:",omitempty"`
+	RevokeAccess      *RevokeAccessJSON      `json:",omitempty"`
+	SetLimits         *SetLimitsJSON         `json:",omitempty"`
+
+	ProposeAdminChange   *ProposeAdminChangeJSON `json:",omitempty"`
+	ApproveAdminProposal *AdminProposalVoteJSON  `json:",omitempty"`
+	RejectAdminProposal  *AdminProposalVoteJSON  `json:",omitempty"`
 }
 
 // CreateFormJSON is the JSON representation of a CreateForm transaction
 type CreateFormJSON struct {
 	Configuration types.Configuration
 	UserID        string
+	TenantID      string `json:",omitempty"`
+	CloseBlock    uint64 `json:",omitempty"`
+	Private       bool   `json:",omitempty"`
 }
 
 // OpenFormJSON is the JSON representation of a OpenForm transaction
@@ -311,9 +592,31 @@ type OpenFormJSON struct {
 
 // CastVoteJSON is the JSON representation of a CastVote transaction
 type CastVoteJSON struct {
+	FormID      string
+	VoterID     string
+	Ciphervote  json.RawMessage
+	DelegatorID string `json:",omitempty"`
+}
+
+// DelegateVoteJSON is the JSON representation of a DelegateVote transaction
+type DelegateVoteJSON struct {
 	FormID     string
 	VoterID    string
-	Ciphervote json.RawMessage
+	DelegateID string
+}
+
+// RevokeDelegationJSON is the JSON representation of a RevokeDelegation
+// transaction
+type RevokeDelegationJSON struct {
+	FormID  string
+	VoterID string
+}
+
+// WithdrawVoteJSON is the JSON representation of a WithdrawVote transaction
+type WithdrawVoteJSON struct {
+	FormID      string
+	VoterID     string
+	DelegatorID string `json:",omitempty"`
 }
 
 // CloseFormJSON is the JSON representation of a CloseForm transaction
@@ -322,6 +625,34 @@ type CloseFormJSON struct {
 	UserID string
 }
 
+// ReopenFormJSON is the JSON representation of a ReopenForm transaction
+type ReopenFormJSON struct {
+	FormID     string
+	UserID     string
+	Reason     string
+	CloseBlock uint64 `json:",omitempty"`
+}
+
+// CreateRunoffJSON is the JSON representation of a CreateRunoff transaction
+type CreateRunoffJSON struct {
+	FormID     string
+	UserID     string
+	QuestionID string
+	Rule       types.RunoffRule
+}
+
+// SuspendFormJSON is the JSON representation of a SuspendForm transaction
+type SuspendFormJSON struct {
+	FormID string
+	UserID string
+}
+
+// ResumeFormJSON is the JSON representation of a ResumeForm transaction
+type ResumeFormJSON struct {
+	FormID string
+	UserID string
+}
+
 // ShuffleBallotsJSON is the JSON representation of a ShuffleBallots transaction
 type ShuffleBallotsJSON struct {
 	FormID       string
@@ -357,6 +688,7 @@ type CancelFormJSON struct {
 // DeleteFormJSON is the JSON representation of a DeleteForm transaction
 type DeleteFormJSON struct {
 	FormID string
+	UserID string
 }
 
 // AdminList
@@ -373,6 +705,24 @@ type RemoveAdminJSON struct {
 	PerformingUserID string
 }
 
+// Tenants
+
+// CreateTenantJSON is the JSON representation of a CreateTenant transaction
+type CreateTenantJSON struct {
+	TenantID         string
+	Name             string
+	AdminID          string
+	PerformingUserID string
+}
+
+// TenantAdminJSON is the JSON representation of a AddTenantAdmin or
+// RemoveTenantAdmin transaction
+type TenantAdminJSON struct {
+	TenantID         string
+	TargetUserID     string
+	PerformingUserID string
+}
+
 // OwnerForm
 
 // AddOwnerJSON is the JSON representation of a AddOwner transaction
@@ -405,6 +755,51 @@ type RemoveVoterJSON struct {
 	PerformingUserID string
 }
 
+// RoleForm
+
+// RoleJSON is the JSON representation of a AddRole or RemoveRole transaction
+type RoleJSON struct {
+	FormID           string
+	Role             string
+	TargetUserID     string
+	PerformingUserID string
+}
+
+// VerifyFormJSON is the JSON representation of a VerifyForm transaction
+type VerifyFormJSON struct {
+	FormID string
+	UserID string
+}
+
+// RevokeAccessJSON is the JSON representation of a RevokeAccess transaction
+type RevokeAccessJSON struct {
+	Command    string
+	Identities []string
+}
+
+// SetLimitsJSON is the JSON representation of a SetLimits transaction
+type SetLimitsJSON struct {
+	Limits           types.Limits
+	PerformingUserID string
+}
+
+// ProposeAdminChangeJSON is the JSON representation of a ProposeAdminChange
+// transaction
+type ProposeAdminChangeJSON struct {
+	Action           string
+	TargetUserID     string
+	Threshold        int
+	PerformingUserID string
+	Lifetime         uint64
+}
+
+// AdminProposalVoteJSON is the JSON representation of a ApproveAdminProposal
+// or RejectAdminProposal transaction
+type AdminProposalVoteJSON struct {
+	ProposalID       string
+	PerformingUserID string
+}
+
 func decodeCastVote(ctx serde.Context, m CastVoteJSON) (serde.Message, error) {
 	factory := ctx.GetFactory(types.CiphervoteKey{})
 	if factory == nil {
@@ -422,9 +817,10 @@ func decodeCastVote(ctx serde.Context, m CastVoteJSON) (serde.Message, error) {
 	}
 
 	return types.CastVote{
-		FormID:  m.FormID,
-		VoterID: m.VoterID,
-		Ballot:  ciphervote,
+		FormID:      m.FormID,
+		VoterID:     m.VoterID,
+		Ballot:      ciphervote,
+		DelegatorID: m.DelegatorID,
 	}, nil
 }
 
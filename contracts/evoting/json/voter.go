@@ -0,0 +1,48 @@
+package json
+
+import (
+	"github.com/c4dt/d-voting/contracts/evoting/types"
+	"go.dedis.ch/dela/serde"
+	"golang.org/x/xerrors"
+)
+
+type voterRecordFormat struct{}
+
+func (voterRecordFormat) Encode(ctx serde.Context, message serde.Message) ([]byte, error) {
+	record, ok := message.(types.VoterRecord)
+	if !ok {
+		return nil, xerrors.Errorf("Unknown format: %T", message)
+	}
+
+	recordJSON := VoterRecordJSON{
+		Ballots: record.Ballots,
+		Live:    record.Live,
+	}
+
+	buff, err := ctx.Marshal(&recordJSON)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to marshal voter record: %v", err)
+	}
+
+	return buff, nil
+}
+
+func (voterRecordFormat) Decode(ctx serde.Context, data []byte) (serde.Message, error) {
+	var recordJSON VoterRecordJSON
+
+	err := ctx.Unmarshal(data, &recordJSON)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to unmarshal voter record: %v", err)
+	}
+
+	return types.VoterRecord{
+		Ballots: recordJSON.Ballots,
+		Live:    recordJSON.Live,
+	}, nil
+}
+
+// VoterRecordJSON defines the VoterRecord in the JSON format
+type VoterRecordJSON struct {
+	Ballots uint32
+	Live    bool
+}
@@ -9,6 +9,7 @@ import (
 	"go.dedis.ch/dela/core/execution"
 	"go.dedis.ch/dela/core/execution/native"
 	"go.dedis.ch/dela/core/ordering/cosipbft/authority"
+	"go.dedis.ch/dela/core/ordering/cosipbft/blockstore"
 	"go.dedis.ch/dela/core/store"
 	"go.dedis.ch/dela/serde"
 	"go.dedis.ch/dela/serde/json"
@@ -62,6 +63,17 @@ const (
 	// FormsMetadataKey is the key at which form metadata are saved in
 	// the storage.
 	FormsMetadataKey = "FormsMetadataKey"
+
+	// TenantsKey is the key at which the tenants are saved in the storage.
+	TenantsKey = "TenantsKey"
+
+	// AdminProposalsKey is the key at which the proposals to change the admin
+	// list are saved in the storage.
+	AdminProposalsKey = "AdminProposalsKey"
+
+	// ParametersKey is the key at which the parameters of the contract, such
+	// as the resource limits, are saved in the storage.
+	ParametersKey = "ParametersKey"
 )
 
 var suite = suites.MustFind("Ed25519")
@@ -88,20 +100,36 @@ const (
 	AdminListId = ContractUID + "AdminList"
 )
 
+// TenantAdminListId returns the ID of the admin list of a tenant
+func TenantAdminListId(tenantID string) string {
+	return AdminListId + "/" + tenantID
+}
+
 // commands defines the commands of the evoting contract. Using an interface
 // helps in testing.
 type commands interface {
 	createForm(snap store.Snapshot, step execution.Step) error
 	openForm(snap store.Snapshot, step execution.Step) error
 	castVote(snap store.Snapshot, step execution.Step) error
+	withdrawVote(snap store.Snapshot, step execution.Step) error
+	manageDelegations(snap store.Snapshot, step execution.Step) error
 	closeForm(snap store.Snapshot, step execution.Step) error
+	reopenForm(snap store.Snapshot, step execution.Step) error
+	createRunoff(snap store.Snapshot, step execution.Step) error
+	suspendForm(snap store.Snapshot, step execution.Step) error
+	resumeForm(snap store.Snapshot, step execution.Step) error
 	shuffleBallots(snap store.Snapshot, step execution.Step) error
 	registerPubshares(snap store.Snapshot, step execution.Step) error
 	combineShares(snap store.Snapshot, step execution.Step) error
 	cancelForm(snap store.Snapshot, step execution.Step) error
 	deleteForm(snap store.Snapshot, step execution.Step) error
 	manageAdminList(snap store.Snapshot, step execution.Step) error
+	manageAdminProposals(snap store.Snapshot, step execution.Step) error
+	manageTenants(snap store.Snapshot, step execution.Step) error
 	manageOwnersVotersForm(snap store.Snapshot, step execution.Step) error
+	verifyForm(snap store.Snapshot, step execution.Step) error
+	revokeAccess(snap store.Snapshot, step execution.Step) error
+	setLimits(snap store.Snapshot, step execution.Step) error
 }
 
 // Command defines a type of command for the value contract
@@ -114,8 +142,22 @@ const (
 	CmdOpenForm Command = "OPEN_FORM"
 	// CmdCastVote is the command to cast a vote
 	CmdCastVote Command = "CAST_VOTE"
+	// CmdWithdrawVote is the command to withdraw a vote
+	CmdWithdrawVote Command = "WITHDRAW_VOTE"
+	// CmdDelegateVote is the command to delegate a vote to another voter
+	CmdDelegateVote Command = "DELEGATE_VOTE"
+	// CmdRevokeDelegation is the command to revoke the delegation of a vote
+	CmdRevokeDelegation Command = "REVOKE_DELEGATION"
 	// CmdCloseForm is the command to close a form
 	CmdCloseForm Command = "CLOSE_FORM"
+	// CmdReopenForm is the command to reopen a closed form
+	CmdReopenForm Command = "REOPEN_FORM"
+	// CmdCreateRunoff is the command to create a runoff of a form
+	CmdCreateRunoff Command = "CREATE_RUNOFF"
+	// CmdSuspendForm is the command to temporarily suspend an open form
+	CmdSuspendForm Command = "SUSPEND_FORM"
+	// CmdResumeForm is the command to resume a suspended form
+	CmdResumeForm Command = "RESUME_FORM"
 	// CmdShuffleBallots is the command to shuffle ballots
 	CmdShuffleBallots Command = "SHUFFLE_BALLOTS"
 
@@ -135,6 +177,21 @@ const (
 	// CmdRemoveAdmin is the command to remove an admin to the system
 	CmdRemoveAdmin Command = "REMOVE_ADMIN"
 
+	// CmdProposeAdminChange is the command to propose a change of the admin
+	// list
+	CmdProposeAdminChange Command = "PROPOSE_ADMIN_CHANGE"
+	// CmdApproveAdminProposal is the command to approve a proposal
+	CmdApproveAdminProposal Command = "APPROVE_ADMIN_PROPOSAL"
+	// CmdRejectAdminProposal is the command to reject a proposal
+	CmdRejectAdminProposal Command = "REJECT_ADMIN_PROPOSAL"
+
+	// CmdCreateTenant is the command to create a tenant
+	CmdCreateTenant Command = "CREATE_TENANT"
+	// CmdAddTenantAdmin is the command to add an admin to a tenant
+	CmdAddTenantAdmin Command = "ADD_TENANT_ADMIN"
+	// CmdRemoveTenantAdmin is the command to remove an admin from a tenant
+	CmdRemoveTenantAdmin Command = "REMOVE_TENANT_ADMIN"
+
 	// CmdAddOwnerForm is the command to add an Owner to a form
 	CmdAddOwnerForm Command = "ADD_OWNER"
 	// CmdRemoveOwnerForm is the command to remove an Owner to a form
@@ -144,14 +201,39 @@ const (
 	CmdAddVoterForm Command = "ADD_VOTER"
 	// CmdRemoveVoterForm is the command to remove an Voter to a form
 	CmdRemoveVoterForm Command = "REMOVE_VOTER"
+
+	// CmdAddRoleForm is the command to give an Auditor, Registrar or Observer
+	// role to a user on a form
+	CmdAddRoleForm Command = "ADD_ROLE"
+	// CmdRemoveRoleForm is the command to remove an Auditor, Registrar or
+	// Observer role from a user on a form
+	CmdRemoveRoleForm Command = "REMOVE_ROLE"
+
+	// CmdVerifyForm is the command of an auditor or an owner to verify the
+	// tally of a form
+	CmdVerifyForm Command = "VERIFY_FORM"
+
+	// CmdRevokeAccess is the command to revoke the credential of a command
+	// from identities. Its own credential is required, the credential of all
+	// the commands doesn't allow it.
+	CmdRevokeAccess Command = "REVOKE_ACCESS"
+
+	// CmdSetLimits is the command to set the resource limits of the forms
+	CmdSetLimits Command = "SET_LIMITS"
 )
 
-// NewCreds creates new credentials for a evoting contract execution. We might
-// want to use in the future a separate credential for each command.
+// NewCreds creates new credentials for a evoting contract execution, that
+// allow all the commands.
 func NewCreds() access.Credential {
 	return access.NewContractCreds([]byte(ContractUID), ContractName, credentialAllCommand)
 }
 
+// NewCommandCreds creates new credentials for the execution of a single
+// command of the evoting contract.
+func NewCommandCreds(cmd Command) access.Credential {
+	return access.NewContractCreds([]byte(ContractUID), ContractName, string(cmd))
+}
+
 // RegisterContract registers the value contract to the given execution service.
 func RegisterContract(exec *native.Service, c Contract) {
 	exec.Set(ContractName, c)
@@ -163,12 +245,16 @@ func RegisterContract(exec *native.Service, c Contract) {
 type Contract struct {
 
 	// access is the access control service managing this smart contract
-	access access.Service
+	access AccessService
 
 	cmd commands
 
 	pedersen dkg.DKG
 
+	// blocks is the store of the committed blocks. While the transactions of
+	// a block are executed, its length is the index of that block.
+	blocks blockstore.BlockStore
+
 	context serde.Context
 
 	formFac        serde.Factory
@@ -178,8 +264,8 @@ type Contract struct {
 }
 
 // NewContract creates a new Value contract
-func NewContract(srvc access.Service,
-	pedersen dkg.DKG, rosterFac authority.Factory) Contract {
+func NewContract(srvc AccessService,
+	pedersen dkg.DKG, rosterFac authority.Factory, blocks blockstore.BlockStore) Contract {
 
 	ctx := json.NewContext()
 
@@ -191,6 +277,7 @@ func NewContract(srvc access.Service,
 	contract := Contract{
 		access:   srvc,
 		pedersen: pedersen,
+		blocks:   blocks,
 
 		context: ctx,
 
@@ -207,15 +294,14 @@ func NewContract(srvc access.Service,
 
 // Execute implements native.Contract
 func (c Contract) Execute(snap store.Snapshot, step execution.Step) error {
-	creds := NewCreds()
+	cmd := step.Current.GetArg(CmdArg)
 
-	err := c.access.Match(snap, creds, step.Current.GetIdentity())
+	err := c.matchAccess(snap, Command(cmd), step.Current.GetIdentity())
 	if err != nil {
-		return xerrors.Errorf("identity not authorized: %v (%v)",
+		return types.Reject(types.RejectForbidden, "identity not authorized: %v (%v)",
 			step.Current.GetIdentity(), err)
 	}
 
-	cmd := step.Current.GetArg(CmdArg)
 	if len(cmd) == 0 {
 		return xerrors.Errorf("%q not found in tx arg", CmdArg)
 	}
@@ -236,11 +322,46 @@ func (c Contract) Execute(snap store.Snapshot, step execution.Step) error {
 		if err != nil {
 			return xerrors.Errorf("failed to cast vote: %v", err)
 		}
+	case CmdWithdrawVote:
+		err := c.cmd.withdrawVote(snap, step)
+		if err != nil {
+			return xerrors.Errorf("failed to withdraw vote: %v", err)
+		}
+	case CmdDelegateVote:
+		err := c.cmd.manageDelegations(snap, step)
+		if err != nil {
+			return xerrors.Errorf("failed to delegate vote: %v", err)
+		}
+	case CmdRevokeDelegation:
+		err := c.cmd.manageDelegations(snap, step)
+		if err != nil {
+			return xerrors.Errorf("failed to revoke delegation: %v", err)
+		}
 	case CmdCloseForm:
 		err := c.cmd.closeForm(snap, step)
 		if err != nil {
 			return xerrors.Errorf("failed to close form: %v", err)
 		}
+	case CmdReopenForm:
+		err := c.cmd.reopenForm(snap, step)
+		if err != nil {
+			return xerrors.Errorf("failed to reopen form: %v", err)
+		}
+	case CmdCreateRunoff:
+		err := c.cmd.createRunoff(snap, step)
+		if err != nil {
+			return xerrors.Errorf("failed to create runoff: %v", err)
+		}
+	case CmdSuspendForm:
+		err := c.cmd.suspendForm(snap, step)
+		if err != nil {
+			return xerrors.Errorf("failed to suspend form: %v", err)
+		}
+	case CmdResumeForm:
+		err := c.cmd.resumeForm(snap, step)
+		if err != nil {
+			return xerrors.Errorf("failed to resume form: %v", err)
+		}
 	case CmdShuffleBallots:
 		err := c.cmd.shuffleBallots(snap, step)
 		if err != nil {
@@ -276,6 +397,36 @@ func (c Contract) Execute(snap store.Snapshot, step execution.Step) error {
 		if err != nil {
 			return xerrors.Errorf("failed to remove admin: %v", err)
 		}
+	case CmdProposeAdminChange:
+		err := c.cmd.manageAdminProposals(snap, step)
+		if err != nil {
+			return xerrors.Errorf("failed to propose admin change: %v", err)
+		}
+	case CmdApproveAdminProposal:
+		err := c.cmd.manageAdminProposals(snap, step)
+		if err != nil {
+			return xerrors.Errorf("failed to approve admin proposal: %v", err)
+		}
+	case CmdRejectAdminProposal:
+		err := c.cmd.manageAdminProposals(snap, step)
+		if err != nil {
+			return xerrors.Errorf("failed to reject admin proposal: %v", err)
+		}
+	case CmdCreateTenant:
+		err := c.cmd.manageTenants(snap, step)
+		if err != nil {
+			return xerrors.Errorf("failed to create tenant: %v", err)
+		}
+	case CmdAddTenantAdmin:
+		err := c.cmd.manageTenants(snap, step)
+		if err != nil {
+			return xerrors.Errorf("failed to add tenant admin: %v", err)
+		}
+	case CmdRemoveTenantAdmin:
+		err := c.cmd.manageTenants(snap, step)
+		if err != nil {
+			return xerrors.Errorf("failed to remove tenant admin: %v", err)
+		}
 	case CmdAddOwnerForm:
 		err := c.cmd.manageOwnersVotersForm(snap, step)
 		if err != nil {
@@ -296,6 +447,31 @@ func (c Contract) Execute(snap store.Snapshot, step execution.Step) error {
 		if err != nil {
 			return xerrors.Errorf("failed to remove voter: %v", err)
 		}
+	case CmdAddRoleForm:
+		err := c.cmd.manageOwnersVotersForm(snap, step)
+		if err != nil {
+			return xerrors.Errorf("failed to add role: %v", err)
+		}
+	case CmdRemoveRoleForm:
+		err := c.cmd.manageOwnersVotersForm(snap, step)
+		if err != nil {
+			return xerrors.Errorf("failed to remove role: %v", err)
+		}
+	case CmdVerifyForm:
+		err := c.cmd.verifyForm(snap, step)
+		if err != nil {
+			return xerrors.Errorf("failed to verify form: %v", err)
+		}
+	case CmdRevokeAccess:
+		err := c.cmd.revokeAccess(snap, step)
+		if err != nil {
+			return xerrors.Errorf("failed to revoke access: %v", err)
+		}
+	case CmdSetLimits:
+		err := c.cmd.setLimits(snap, step)
+		if err != nil {
+			return xerrors.Errorf("failed to set limits: %v", err)
+		}
 	default:
 		return xerrors.Errorf("unknown command: %s", cmd)
 	}
@@ -303,6 +479,34 @@ func (c Contract) Execute(snap store.Snapshot, step execution.Step) error {
 	return nil
 }
 
+// matchAccess checks that the identity has the credential of the command. The
+// credential of all the commands only applies to the commands that have not
+// been granted on their own: granting a command opts it out of the credential
+// of all the commands, so that revoking the command takes effect. Deployments
+// that only grant all the commands keep working unchanged. REVOKE_ACCESS is
+// the exception: the credential of all the commands is held by every proxy,
+// so it must be granted on its own, through the access contract.
+func (c Contract) matchAccess(snap store.Snapshot, cmd Command, ident access.Identity) error {
+	if cmd == CmdRevokeAccess {
+		return c.access.Match(snap, NewCommandCreds(cmd), ident)
+	}
+
+	if len(cmd) > 0 {
+		creds := NewCommandCreds(cmd)
+
+		granted, err := c.access.Granted(snap, creds)
+		if err != nil {
+			return xerrors.Errorf("failed to check the credential of %s: %v", cmd, err)
+		}
+
+		if granted {
+			return c.access.Match(snap, creds, ident)
+		}
+	}
+
+	return c.access.Match(snap, NewCreds(), ident)
+}
+
 // UID returns the unique 4-bytes contract identifier.
 //
 // - implements native.Contract
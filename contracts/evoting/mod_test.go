@@ -2,7 +2,9 @@ package evoting
 
 import (
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"testing"
@@ -13,10 +15,12 @@ import (
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 	"go.dedis.ch/dela/core/access"
+	"go.dedis.ch/dela/core/access/darc"
 	"go.dedis.ch/dela/core/execution"
 	"go.dedis.ch/dela/core/execution/native"
 	"go.dedis.ch/dela/core/ordering"
 	"go.dedis.ch/dela/core/ordering/cosipbft/authority"
+	"go.dedis.ch/dela/core/ordering/cosipbft/blockstore"
 	"go.dedis.ch/dela/core/store"
 	"go.dedis.ch/dela/core/txn"
 	"go.dedis.ch/dela/core/txn/signed"
@@ -69,14 +73,14 @@ func TestExecute(t *testing.T) {
 	service := fakeAccess{err: fake.GetError()}
 	rosterFac := fakeAuthorityFactory{}
 
-	contract := NewContract(service, fakeDkg, rosterFac)
+	contract := NewContract(service, fakeDkg, rosterFac, blockstore.NewInMemory())
 
 	err := contract.Execute(fakeStore{}, makeStep(t))
-	require.EqualError(t, err, "identity not authorized: fake.PublicKey ("+fake.GetError().Error()+")")
+	require.EqualError(t, err, "[FORBIDDEN] identity not authorized: fake.PublicKey ("+fake.GetError().Error()+")")
 
 	service = fakeAccess{}
 
-	contract = NewContract(service, fakeDkg, rosterFac)
+	contract = NewContract(service, fakeDkg, rosterFac, blockstore.NewInMemory())
 	err = contract.Execute(fakeStore{}, makeStep(t))
 	require.EqualError(t, err, "\"evoting:command\" not found in tx arg")
 
@@ -88,9 +92,30 @@ func TestExecute(t *testing.T) {
 	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdCastVote)))
 	require.EqualError(t, err, fake.Err("failed to cast vote"))
 
+	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdWithdrawVote)))
+	require.EqualError(t, err, fake.Err("failed to withdraw vote"))
+
+	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdDelegateVote)))
+	require.EqualError(t, err, fake.Err("failed to delegate vote"))
+
+	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdRevokeDelegation)))
+	require.EqualError(t, err, fake.Err("failed to revoke delegation"))
+
 	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdCloseForm)))
 	require.EqualError(t, err, fake.Err("failed to close form"))
 
+	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdReopenForm)))
+	require.EqualError(t, err, fake.Err("failed to reopen form"))
+
+	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdCreateRunoff)))
+	require.EqualError(t, err, fake.Err("failed to create runoff"))
+
+	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdSuspendForm)))
+	require.EqualError(t, err, fake.Err("failed to suspend form"))
+
+	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdResumeForm)))
+	require.EqualError(t, err, fake.Err("failed to resume form"))
+
 	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdShuffleBallots)))
 	require.EqualError(t, err, fake.Err("failed to shuffle ballots"))
 
@@ -106,6 +131,39 @@ func TestExecute(t *testing.T) {
 	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdRemoveAdmin)))
 	require.EqualError(t, err, fake.Err("failed to remove admin"))
 
+	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdProposeAdminChange)))
+	require.EqualError(t, err, fake.Err("failed to propose admin change"))
+
+	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdApproveAdminProposal)))
+	require.EqualError(t, err, fake.Err("failed to approve admin proposal"))
+
+	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdRejectAdminProposal)))
+	require.EqualError(t, err, fake.Err("failed to reject admin proposal"))
+
+	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdCreateTenant)))
+	require.EqualError(t, err, fake.Err("failed to create tenant"))
+
+	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdAddTenantAdmin)))
+	require.EqualError(t, err, fake.Err("failed to add tenant admin"))
+
+	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdRemoveTenantAdmin)))
+	require.EqualError(t, err, fake.Err("failed to remove tenant admin"))
+
+	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdAddRoleForm)))
+	require.EqualError(t, err, fake.Err("failed to add role"))
+
+	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdRemoveRoleForm)))
+	require.EqualError(t, err, fake.Err("failed to remove role"))
+
+	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdVerifyForm)))
+	require.EqualError(t, err, fake.Err("failed to verify form"))
+
+	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdRevokeAccess)))
+	require.EqualError(t, err, fake.Err("failed to revoke access"))
+
+	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdSetLimits)))
+	require.EqualError(t, err, fake.Err("failed to set limits"))
+
 	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, "fake"))
 	require.EqualError(t, err, "unknown command: fake")
 
@@ -142,7 +200,7 @@ func TestCommand_CreateForm(t *testing.T) {
 	service := fakeAccess{err: fake.GetError()}
 	rosterFac := fakeAuthorityFactory{}
 
-	contract := NewContract(service, fakeDkg, rosterFac)
+	contract := NewContract(service, fakeDkg, rosterFac, blockstore.NewInMemory())
 
 	cmd := evotingCommand{
 		Contract: &contract,
@@ -182,6 +240,11 @@ func TestCommand_CreateForm(t *testing.T) {
 
 	require.Equal(t, types.Initial, form.Status)
 	require.Equal(t, float64(types.Initial), testutil.ToFloat64(PromFormStatus))
+
+	// the form is listed among the forms of its owner
+	owned, err := types.OwnerForms.FromStore(form.Owners[0], snap)
+	require.NoError(t, err)
+	require.Equal(t, []string{form.FormID}, owned)
 }
 
 func TestCommand_OpenForm(t *testing.T) {
@@ -219,87 +282,969 @@ func TestCommand_CastVote(t *testing.T) {
 	err = cmd.castVote(fake.NewSnapshot(), makeStep(t))
 	require.EqualError(t, err, getTransactionErr)
 
-	err = cmd.castVote(fake.NewSnapshot(), makeStep(t, FormArg, "dummy"))
-	require.EqualError(t, err, unmarshalTransactionErr)
+	err = cmd.castVote(fake.NewSnapshot(), makeStep(t, FormArg, "dummy"))
+	require.EqualError(t, err, unmarshalTransactionErr)
+
+	err = cmd.castVote(fake.NewBadSnapshot(), makeStep(t, FormArg, string(data)))
+	require.ErrorContains(t, err, "failed to get key")
+
+	snap := fake.NewSnapshot()
+	err = snap.Set(dummyFormIDBuff, invalidForm)
+	require.NoError(t, err)
+
+	err = cmd.castVote(snap, makeStep(t, FormArg, string(data)))
+	require.ErrorContains(t, err, deserializeErr)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = cmd.castVote(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, fmt.Sprintf("[FORM_NOT_OPEN] the form is not open, current status: %d", types.Initial))
+
+	dummyForm.Status = types.Open
+	dummyForm.BallotSize = 0
+
+	formBuf, err = dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = cmd.castVote(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "[NOT_VOTER] The user 123456 doesn't have the Voter permission on the form.")
+
+	addVoter := types.AddVoter{FormID: fakeFormID, TargetUserID: dummyUserAdminID, PerformingUserID: dummyUserAdminID}
+	dataAddVoter, err := addVoter.Serialize(ctx)
+	require.NoError(t, err)
+	err = cmd.manageOwnersVotersForm(snap, makeStep(t, FormArg, string(dataAddVoter)))
+	require.NoError(t, err)
+
+	err = cmd.castVote(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "[BALLOT_LENGTH] the ballot has unexpected length: 1 != 0")
+
+	dummyForm.BallotSize = 29
+
+	formBuf, err = dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	// encrypt a real message :
+	RandomStream := suite.RandomStream()
+	h := suite.Scalar().Pick(RandomStream)
+	pubKey := suite.Point().Mul(h, nil)
+
+	M := suite.Point().Embed([]byte("fakeVote"), random.New())
+
+	// ElGamal-encrypt the point to produce ciphertext (K,C).
+	k := suite.Scalar().Pick(random.New()) // ephemeral private key
+	K := suite.Point().Mul(k, nil)         // ephemeral DH public key
+	S := suite.Point().Mul(k, pubKey)      // ephemeral DH shared secret
+	C := S.Add(S, M)                       // message blinded with secret
+
+	castVote.Ballot = types.Ciphervote{
+		types.EGPair{
+			K: K,
+			C: C,
+		},
+	}
+
+	castVote.FormID = "X"
+
+	data, err = castVote.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = cmd.castVote(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "failed to get form: failed to decode "+
+		"formIDHex: encoding/hex: invalid byte: U+0058 'X'")
+
+	dummyForm.FormID = fakeFormID
+
+	formBuf, err = dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	castVote.FormID = fakeFormID
+
+	data, err = castVote.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.manageOwnersVotersForm(snap, makeStep(t, FormArg, string(dataAddVoter)))
+	require.NoError(t, err)
+
+	err = cmd.castVote(snap, makeStep(t, FormArg, string(data)))
+	require.NoError(t, err)
+
+	res, err := snap.Get(dummyFormIDBuff)
+	require.NoError(t, err)
+
+	message, err := formFac.Deserialize(ctx, res)
+	require.NoError(t, err)
+
+	form, ok := message.(types.Form)
+	require.True(t, ok)
+
+	require.Equal(t, uint32(1), form.BallotCount)
+	suff, err := form.Suffragia(ctx, snap)
+	require.NoError(t, err)
+	require.True(t, castVote.Ballot.Equal(suff.Ciphervotes[0]))
+
+	require.Equal(t, castVote.VoterID, suff.VoterIDs[0])
+	require.Equal(t, float64(form.BallotCount), testutil.ToFloat64(PromFormBallots))
+
+	// with the no re-vote policy
+	form.Configuration.RevotePolicy = types.NoRevote
+
+	formBuf, err = form.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = cmd.castVote(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "[REVOTE_NOT_ALLOWED] voter 123456 already voted and re-voting is not allowed")
+
+	// with the first vote only policy, the new ballot is rejected
+	form.Configuration.RevotePolicy = types.FirstVoteOnly
+
+	formBuf, err = form.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	castVote.Ballot = types.Ciphervote{types.EGPair{K: C, C: K}}
+
+	dataRevote, err := castVote.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.castVote(snap, makeStep(t, FormArg, string(dataRevote)))
+	require.EqualError(t, err, "[REVOTE_NOT_ALLOWED] voter 123456 already voted and only the first ballot is kept")
+
+	form = getForm(t, snap)
+	require.Equal(t, uint32(1), form.BallotCount)
+
+	record, err := form.VoterRecord(ctx, snap, "123456")
+	require.NoError(t, err)
+	require.Equal(t, types.VoterRecord{Ballots: 1, Live: true}, record)
+
+	// with the last vote wins policy and a cap on re-votes
+	form.Configuration.RevotePolicy = types.LastVoteWins
+	form.Configuration.MaxRevotes = 2
+
+	formBuf, err = form.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = cmd.castVote(snap, makeStep(t, FormArg, string(dataRevote)))
+	require.NoError(t, err)
+
+	form = getForm(t, snap)
+	suff, err = form.Suffragia(ctx, snap)
+	require.NoError(t, err)
+	require.Len(t, suff.Ciphervotes, 1)
+	require.True(t, castVote.Ballot.Equal(suff.Ciphervotes[0]))
+
+	// consecutive votes are counted in a single event
+	history, err := types.HistoryFromStore(ctx, fakeFormID, snap)
+	require.NoError(t, err)
+	last := history.Events[len(history.Events)-1]
+	require.Equal(t, string(CmdCastVote), last.Command)
+	require.Equal(t, "2", last.Params["Count"])
+
+	err = cmd.castVote(snap, makeStep(t, FormArg, string(data)))
+	require.NoError(t, err)
+
+	err = cmd.castVote(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "[REVOTE_NOT_ALLOWED] voter 123456 reached the maximum number of re-votes: 2")
+}
+
+func TestCommand_Delegations(t *testing.T) {
+	initMetrics()
+
+	delegate := func(voterID, delegateID string) string {
+		tx := types.DelegateVote{FormID: fakeFormID, VoterID: voterID, DelegateID: delegateID}
+		data, err := tx.Serialize(ctx)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	revoke := func(voterID string) string {
+		tx := types.RevokeDelegation{FormID: fakeFormID, VoterID: voterID}
+		data, err := tx.Serialize(ctx)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	withdraw := func(voterID, delegatorID string) string {
+		tx := types.WithdrawVote{FormID: fakeFormID, VoterID: voterID, DelegatorID: delegatorID}
+		data, err := tx.Serialize(ctx)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	vote := func(voterID, delegatorID string) string {
+		tx := types.CastVote{
+			FormID:      fakeFormID,
+			VoterID:     voterID,
+			DelegatorID: delegatorID,
+			Ballot: types.Ciphervote{types.EGPair{
+				K: suite.Point(),
+				C: suite.Point(),
+			}},
+		}
+		data, err := tx.Serialize(ctx)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	dummyForm, contract := initFormAndContract(123456)
+	dummyForm.BallotSize = 29
+	dummyForm.Voters = []int{123456, 234567, 345678}
+	dummyForm.Configuration.MaxDelegations = 1
+
+	cmd := evotingCommand{
+		Contract: &contract,
+	}
+
+	err := cmd.manageDelegations(fake.NewSnapshot(), makeStep(t))
+	require.EqualError(t, err, getTransactionErr)
+
+	err = cmd.manageDelegations(fake.NewSnapshot(), makeStep(t, FormArg, "dummy"))
+	require.EqualError(t, err, unmarshalTransactionErr)
+
+	snap := fake.NewSnapshot()
+
+	formBuf, err := dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = cmd.manageDelegations(snap, makeStep(t, FormArg, delegate("567890", "123456")))
+	require.EqualError(t, err, "[NOT_VOTER] The user 567890 doesn't have the Voter permission on the form.")
+
+	err = cmd.manageDelegations(snap, makeStep(t, FormArg, delegate("234567", "123456")))
+	require.NoError(t, err)
+
+	err = cmd.manageDelegations(snap, makeStep(t, FormArg, delegate("345678", "123456")))
+	require.EqualError(t, err, "[INVALID_DELEGATION] couldn't update delegations: the voter 123456 "+
+		"already holds the maximum of 1 delegations")
+
+	form := getForm(t, snap)
+	require.Equal(t, []types.Delegation{{VoterID: 234567, DelegateID: 123456}}, form.Delegations)
+
+	history, err := types.HistoryFromStore(ctx, fakeFormID, snap)
+	require.NoError(t, err)
+	last := history.Events[len(history.Events)-1]
+	require.Equal(t, string(CmdDelegateVote), last.Command)
+	require.Equal(t, "234567", last.UserID)
+	require.Equal(t, "123456", last.Params["DelegateID"])
+
+	form.Status = types.Open
+
+	formBuf, err = form.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	// the delegator can't vote while the delegation holds
+	err = cmd.castVote(snap, makeStep(t, FormArg, vote("234567", "")))
+	require.EqualError(t, err, "[INVALID_DELEGATION] invalid delegation: the voter 234567 delegated their vote to 123456")
+
+	err = cmd.castVote(snap, makeStep(t, FormArg, vote("345678", "234567")))
+	require.EqualError(t, err, "[INVALID_DELEGATION] invalid delegation: the voter 234567 didn't delegate their vote to 345678")
+
+	err = cmd.castVote(snap, makeStep(t, FormArg, vote("123456", "")))
+	require.NoError(t, err)
+
+	err = cmd.castVote(snap, makeStep(t, FormArg, vote("123456", "234567")))
+	require.NoError(t, err)
+
+	form = getForm(t, snap)
+	suff, err := form.Suffragia(ctx, snap)
+	require.NoError(t, err)
+	require.Equal(t, []string{"123456", "234567"}, suff.VoterIDs)
+
+	err = cmd.manageDelegations(snap, makeStep(t, FormArg, revoke("345678")))
+	require.EqualError(t, err, "[INVALID_DELEGATION] couldn't update delegations: the voter 345678 "+
+		"didn't delegate their vote")
+
+	// once the delegate voted, only the delegate can withdraw the ballot
+	err = cmd.withdrawVote(snap, makeStep(t, FormArg, withdraw("234567", "")))
+	require.EqualError(t, err, "[INVALID_DELEGATION] invalid delegation: the voter 234567 delegated their vote to 123456")
+
+	err = cmd.manageDelegations(snap, makeStep(t, FormArg, revoke("234567")))
+	require.EqualError(t, err, "[INVALID_DELEGATION] the delegate of voter 234567 already voted, "+
+		"the ballot must be withdrawn by the delegate first")
+
+	err = cmd.withdrawVote(snap, makeStep(t, FormArg, withdraw("345678", "234567")))
+	require.EqualError(t, err, "[INVALID_DELEGATION] invalid delegation: the voter 234567 didn't delegate their vote to 345678")
+
+	err = cmd.withdrawVote(snap, makeStep(t, FormArg, withdraw("123456", "234567")))
+	require.NoError(t, err)
+
+	err = cmd.manageDelegations(snap, makeStep(t, FormArg, revoke("234567")))
+	require.NoError(t, err)
+
+	// the delegator can vote once the delegation is revoked
+	err = cmd.castVote(snap, makeStep(t, FormArg, vote("234567", "")))
+	require.NoError(t, err)
+
+	form = getForm(t, snap)
+	require.Empty(t, form.Delegations)
+
+	suff, err = form.Suffragia(ctx, snap)
+	require.NoError(t, err)
+	require.Equal(t, []string{"123456", "234567"}, suff.VoterIDs)
+
+	// a voter who already voted can't delegate, else both ballots count
+	err = cmd.manageDelegations(snap, makeStep(t, FormArg, delegate("234567", "123456")))
+	require.EqualError(t, err, "[INVALID_DELEGATION] voter 234567 already voted, the ballot must be withdrawn first")
+
+	form.Status = types.Closed
+
+	formBuf, err = form.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = cmd.manageDelegations(snap, makeStep(t, FormArg, delegate("234567", "123456")))
+	require.EqualError(t, err, fmt.Sprintf("[FORM_NOT_OPEN] the form is not open, current status: %d", types.Closed))
+}
+
+func TestCommand_DeleteForm(t *testing.T) {
+	initMetrics()
+
+	deleteForm := types.DeleteForm{
+		FormID: fakeFormID,
+		UserID: dummyUserAdminID,
+	}
+
+	data, err := deleteForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	blocks := &fakeBlocks{BlockStore: blockstore.NewInMemory(), height: 3}
+
+	dummyForm, contract := initFormAndContract(123456)
+	contract.blocks = blocks
+
+	cmd := evotingCommand{
+		Contract: &contract,
+	}
+
+	snap := fake.NewSnapshot()
+
+	err = cmd.setForm(snap, dummyFormIDBuff, dummyForm)
+	require.NoError(t, err)
+
+	err = updateFormMetadataStore(snap, fakeFormID)
+	require.NoError(t, err)
+
+	err = types.OwnerForms.Add(snap, fakeFormID, dummyForm.Owners...)
+	require.NoError(t, err)
+
+	entry, found, err := types.FormIndexEntryFromStore(fakeFormID, snap)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, fakeFormID, entry.FormID)
+	require.Equal(t, types.Initial, entry.Status)
+	require.Equal(t, 1, entry.OwnerCount)
+	require.Equal(t, uint64(1), entry.Version)
+	require.Equal(t, uint64(3), entry.CreatedBlock)
+
+	// the index entry follows the form
+	blocks.height = 5
+	dummyForm.Status = types.Open
+	dummyForm.Voters = []int{234567}
+
+	err = types.VoterForms.Add(snap, fakeFormID, dummyForm.Voters...)
+	require.NoError(t, err)
+
+	err = dummyForm.CastVote(ctx, snap, "234567", types.Ciphervote{types.EGPair{
+		K: suite.Point(),
+		C: suite.Point(),
+	}})
+	require.NoError(t, err)
+
+	err = cmd.setForm(snap, dummyFormIDBuff, dummyForm)
+	require.NoError(t, err)
+
+	err = cmd.recordEvent(snap, dummyFormIDBuff, CmdCastVote, "234567", nil)
+	require.NoError(t, err)
+
+	entry, _, err = types.FormIndexEntryFromStore(fakeFormID, snap)
+	require.NoError(t, err)
+	require.Equal(t, types.Open, entry.Status)
+	require.Equal(t, 1, entry.VoterCount)
+	require.Equal(t, uint32(1), entry.BallotCount)
+	require.Equal(t, uint64(2), entry.Version)
+	require.Equal(t, uint64(3), entry.CreatedBlock)
+	require.Equal(t, uint64(5), entry.UpdatedBlock)
+
+	// storing the form without changing the entry leaves it as is
+	blocks.height = 6
+
+	err = cmd.setForm(snap, dummyFormIDBuff, dummyForm)
+	require.NoError(t, err)
+
+	entry, _, err = types.FormIndexEntryFromStore(fakeFormID, snap)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), entry.Version)
+	require.Equal(t, uint64(5), entry.UpdatedBlock)
+
+	err = cmd.deleteForm(snap, makeStep(t, FormArg, string(data)))
+	require.NoError(t, err)
+
+	_, found, err = types.FormIndexEntryFromStore(fakeFormID, snap)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	owned, err := types.OwnerForms.FromStore(dummyForm.Owners[0], snap)
+	require.NoError(t, err)
+	require.Empty(t, owned)
+
+	voted, err := types.VoterForms.FromStore(234567, snap)
+	require.NoError(t, err)
+	require.Empty(t, voted)
+
+	// the history and the voter records are deleted with the form
+	history, err := types.HistoryFromStore(ctx, fakeFormID, snap)
+	require.NoError(t, err)
+	require.Empty(t, history.Events)
+
+	eventBuf, err := snap.Get(types.HistoryEventKey(dummyFormIDBuff, 0))
+	require.NoError(t, err)
+	require.Empty(t, eventBuf)
+
+	recordBuf, err := snap.Get(types.VoterRecordKey(dummyFormIDBuff, "234567"))
+	require.NoError(t, err)
+	require.Empty(t, recordBuf)
+
+	metadataBuf, err := snap.Get([]byte(FormsMetadataKey))
+	require.NoError(t, err)
+
+	var metadata types.FormsMetadata
+	require.NoError(t, json.Unmarshal(metadataBuf, &metadata))
+	require.Empty(t, metadata.FormsIDs)
+}
+
+func TestCommand_SetLimits(t *testing.T) {
+	initMetrics()
+
+	setLimits := types.SetLimits{
+		Limits:           types.Limits{MaxQuestions: 1, MaxVoters: 1},
+		PerformingUserID: "234567",
+	}
+
+	data, err := setLimits.Serialize(ctx)
+	require.NoError(t, err)
+
+	addAdmin := types.AddAdmin{TargetUserID: dummyUserAdminID, PerformingUserID: dummyUserAdminID}
+	dataAddAdmin, err := addAdmin.Serialize(ctx)
+	require.NoError(t, err)
+
+	dummyForm, contract := initFormAndContract(123456)
+
+	cmd := evotingCommand{
+		Contract: &contract,
+	}
+
+	err = cmd.setLimits(fake.NewSnapshot(), makeStep(t))
+	require.EqualError(t, err, getTransactionErr)
+
+	snap := fake.NewSnapshot()
+
+	err = cmd.manageAdminList(snap, makeStep(t, FormArg, string(dataAddAdmin)))
+	require.NoError(t, err)
+
+	err = cmd.setLimits(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "[NOT_ADMIN] The performing user is not an admin.")
+
+	limits, err := getLimits(snap)
+	require.NoError(t, err)
+	require.Equal(t, types.DefaultLimits, limits)
+
+	setLimits.PerformingUserID = dummyUserAdminID
+
+	data, err = setLimits.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.setLimits(snap, makeStep(t, FormArg, string(data)))
+	require.NoError(t, err)
+
+	// the limits that are not set are kept
+	expected := types.DefaultLimits
+	expected.MaxQuestions = 1
+	expected.MaxVoters = 1
+
+	limits, err = getLimits(snap)
+	require.NoError(t, err)
+	require.Equal(t, expected, limits)
+
+	// the limits can't exceed the hard limits
+	setLimits.Limits = types.Limits{MaxChoices: types.HardLimits.MaxChoices + 1}
+
+	data, err = setLimits.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.setLimits(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, fmt.Sprintf("invalid limits: MaxChoices must be between 1 and %d, got %d",
+		types.HardLimits.MaxChoices, types.HardLimits.MaxChoices+1))
+
+	limits, err = getLimits(snap)
+	require.NoError(t, err)
+	require.Equal(t, expected, limits)
+
+	// forms can't exceed the limits
+	question := func(id types.ID) types.Select {
+		return types.Select{ID: id, MaxN: 1, MinN: 1, Choices: []types.Choice{{Choice: "yes"}}}
+	}
+
+	createForm := types.CreateForm{
+		UserID: dummyUserAdminID,
+		Configuration: types.Configuration{
+			Scaffold: []types.Subject{{
+				ID:      "s",
+				Selects: []types.Select{question("q1"), question("q2")},
+			}},
+		},
+	}
+
+	dataCreate, err := createForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.createForm(snap, makeStep(t, FormArg, string(dataCreate)))
+	require.EqualError(t, err, "the configuration exceeds the limits: [LIMIT_EXCEEDED] the form "+
+		"has 2 questions, the limit is 1")
+
+	// nor have more voters
+	formBuf, err := dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	addVoter := func(voterID string) string {
+		tx := types.AddVoter{FormID: fakeFormID, TargetUserID: voterID, PerformingUserID: "123456"}
+		data, err := tx.Serialize(ctx)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	err = cmd.manageOwnersVotersForm(snap, makeStep(t, FormArg, addVoter("234567")))
+	require.NoError(t, err)
+
+	err = cmd.manageOwnersVotersForm(snap, makeStep(t, FormArg, addVoter("345678")))
+	require.EqualError(t, err, "couldn't add voter: [LIMIT_EXCEEDED] the form would have 2 voters, the limit is 1")
+}
+
+func TestCommand_WithdrawVote(t *testing.T) {
+	initMetrics()
+
+	withdrawVote := types.WithdrawVote{
+		FormID:  fakeFormID,
+		VoterID: dummyUserAdminID,
+	}
+
+	data, err := withdrawVote.Serialize(ctx)
+	require.NoError(t, err)
+
+	dummyForm, contract := initFormAndContract(123456)
+
+	formBuf, err := dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	cmd := evotingCommand{
+		Contract: &contract,
+	}
+
+	err = cmd.withdrawVote(fake.NewSnapshot(), makeStep(t))
+	require.EqualError(t, err, getTransactionErr)
+
+	err = cmd.withdrawVote(fake.NewSnapshot(), makeStep(t, FormArg, "dummy"))
+	require.EqualError(t, err, unmarshalTransactionErr)
+
+	err = cmd.withdrawVote(fake.NewBadSnapshot(), makeStep(t, FormArg, string(data)))
+	require.ErrorContains(t, err, "failed to get key")
+
+	snap := fake.NewSnapshot()
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = cmd.withdrawVote(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, fmt.Sprintf("[FORM_NOT_OPEN] the form is not open, current status: %d", types.Initial))
+
+	dummyForm.Status = types.Open
+
+	formBuf, err = dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = cmd.withdrawVote(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "[NOT_VOTER] The user 123456 doesn't have the Voter permission on the form.")
+
+	dummyForm.Voters = []int{123456}
+
+	formBuf, err = dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = cmd.withdrawVote(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "voter 123456 has no ballot to withdraw")
+
+	require.NoError(t, dummyForm.CastVote(ctx, snap, "123456", types.Ciphervote{}))
+	require.NoError(t, dummyForm.CastVote(ctx, snap, "654321", types.Ciphervote{}))
+
+	formBuf, err = dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = cmd.withdrawVote(snap, makeStep(t, FormArg, string(data)))
+	require.NoError(t, err)
+
+	form := getForm(t, snap)
+	require.Equal(t, uint32(3), form.BallotCount)
+	require.Equal(t, uint32(1), form.LiveBallots)
+
+	suff, err := form.Suffragia(ctx, snap)
+	require.NoError(t, err)
+	require.Equal(t, []string{"654321"}, suff.VoterIDs)
+
+	record, err := form.VoterRecord(ctx, snap, "123456")
+	require.NoError(t, err)
+	require.Equal(t, types.VoterRecord{Ballots: 1}, record)
+
+	err = cmd.withdrawVote(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "voter 123456 has no ballot to withdraw")
+
+	// a new ballot after the withdrawal is counted again
+	require.NoError(t, form.CastVote(ctx, snap, "123456", types.Ciphervote{}))
+
+	suff, err = form.Suffragia(ctx, snap)
+	require.NoError(t, err)
+	require.Equal(t, []string{"654321", "123456"}, suff.VoterIDs)
+}
+
+func TestCommand_CloseForm(t *testing.T) {
+	initMetrics()
+
+	closeForm := types.CloseForm{
+		FormID: fakeFormID,
+		UserID: dummyUserAdminID,
+	}
+
+	data, err := closeForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	dummyForm, contract := initFormAndContract(123456)
+	dummyForm.FormID = fakeFormID
+
+	formBuf, err := dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	cmd := evotingCommand{
+		Contract: &contract,
+	}
+
+	err = cmd.closeForm(fake.NewSnapshot(), makeStep(t))
+	require.EqualError(t, err, getTransactionErr)
+
+	err = cmd.closeForm(fake.NewSnapshot(), makeStep(t, FormArg, "dummy"))
+	require.EqualError(t, err, unmarshalTransactionErr)
+
+	err = cmd.closeForm(fake.NewBadSnapshot(), makeStep(t, FormArg, string(data)))
+	require.ErrorContains(t, err, "failed to get key")
+
+	snap := fake.NewSnapshot()
+	err = snap.Set(dummyFormIDBuff, invalidForm)
+	require.NoError(t, err)
+
+	err = cmd.closeForm(snap, makeStep(t, FormArg, string(data)))
+	require.ErrorContains(t, err, deserializeErr)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	data, err = closeForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.closeForm(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, fmt.Sprintf("[FORM_NOT_OPEN] the form is not open, "+
+		"current status: %d", types.Initial))
+	require.Equal(t, 0, testutil.CollectAndCount(PromFormStatus))
+
+	dummyForm.Status = types.Open
+
+	formBuf, err = dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = cmd.closeForm(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "at least two ballots are required")
+
+	// a withdrawal is not a ballot
+	require.NoError(t, dummyForm.CastVote(ctx, snap, "123456", types.Ciphervote{}))
+	require.NoError(t, dummyForm.WithdrawVote(ctx, snap, "123456"))
+
+	formBuf, err = dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = cmd.closeForm(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "at least two ballots are required")
+
+	require.NoError(t, dummyForm.CastVote(ctx, snap, "123456", types.Ciphervote{}))
+	require.NoError(t, dummyForm.CastVote(ctx, snap, "654321", types.Ciphervote{}))
+
+	formBuf, err = dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = cmd.closeForm(snap, makeStep(t, FormArg, string(data)))
+	require.NoError(t, err)
+	require.Equal(t, float64(types.Closed), testutil.ToFloat64(PromFormStatus))
+
+	res, err := snap.Get(dummyFormIDBuff)
+	require.NoError(t, err)
+
+	message, err := formFac.Deserialize(ctx, res)
+	require.NoError(t, err)
+
+	form, ok := message.(types.Form)
+	require.True(t, ok)
+
+	require.Equal(t, types.Closed, form.Status)
+	require.Equal(t, float64(types.Closed), testutil.ToFloat64(PromFormStatus))
+}
+
+func TestCommand_ReopenForm(t *testing.T) {
+	initMetrics()
+
+	reopenForm := types.ReopenForm{
+		FormID: fakeFormID,
+		UserID: dummyUserAdminID,
+		Reason: "",
+	}
+
+	data, err := reopenForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	dummyForm, contract := initFormAndContract(123456)
+	dummyForm.Status = types.Open
+	dummyForm.CloseBlock = 8
+
+	blocks := &fakeBlocks{BlockStore: blockstore.NewInMemory(), height: 10}
+	contract.blocks = blocks
+
+	formBuf, err := dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	cmd := evotingCommand{
+		Contract: &contract,
+	}
+
+	err = cmd.reopenForm(fake.NewSnapshot(), makeStep(t))
+	require.EqualError(t, err, getTransactionErr)
+
+	err = cmd.reopenForm(fake.NewSnapshot(), makeStep(t, FormArg, "dummy"))
+	require.EqualError(t, err, unmarshalTransactionErr)
+
+	err = cmd.reopenForm(fake.NewBadSnapshot(), makeStep(t, FormArg, string(data)))
+	require.ErrorContains(t, err, "failed to get key")
+
+	snap := fake.NewSnapshot()
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = cmd.reopenForm(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, fmt.Sprintf("[INVALID_FORM_STATUS] the form is not closed, "+
+		"current status: %d", types.Open))
+
+	dummyForm.Status = types.Closed
+	dummyForm.ShuffleInstances = []types.ShuffleInstance{{}}
+
+	formBuf, err = dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = cmd.reopenForm(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "[INVALID_FORM_STATUS] the form cannot be reopened once shuffled")
+
+	dummyForm.ShuffleInstances = make([]types.ShuffleInstance, 0)
+	dummyForm.Owners = []int{654321}
+
+	formBuf, err = dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = cmd.reopenForm(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "[NOT_OWNER] "+fmt.Sprintf(errNoOwnerPerms, dummyUserAdminID))
+
+	dummyForm.Owners = []int{123456}
+
+	formBuf, err = dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = cmd.reopenForm(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "[INVALID_REQUEST] a reason is required to reopen the form")
+
+	reopenForm.Reason = "network outage"
+
+	data, err = reopenForm.Serialize(ctx)
+	require.NoError(t, err)
 
-	err = cmd.castVote(fake.NewBadSnapshot(), makeStep(t, FormArg, string(data)))
-	require.ErrorContains(t, err, "failed to get key")
+	// the deadline of the form must be extended
+	err = cmd.reopenForm(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "[INVALID_REQUEST] the close block must be extended: 0 <= 8")
 
-	snap := fake.NewSnapshot()
-	err = snap.Set(dummyFormIDBuff, invalidForm)
+	reopenForm.CloseBlock = 9
+
+	data, err = reopenForm.Serialize(ctx)
 	require.NoError(t, err)
 
-	err = cmd.castVote(snap, makeStep(t, FormArg, string(data)))
-	require.ErrorContains(t, err, deserializeErr)
+	err = cmd.reopenForm(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "[INVALID_REQUEST] the close block 9 is already passed")
 
-	err = snap.Set(dummyFormIDBuff, formBuf)
+	reopenForm.CloseBlock = 20
+
+	data, err = reopenForm.Serialize(ctx)
 	require.NoError(t, err)
 
-	err = cmd.castVote(snap, makeStep(t, FormArg, string(data)))
-	require.EqualError(t, err, fmt.Sprintf("the form is not open, current status: %d", types.Initial))
+	err = cmd.reopenForm(snap, makeStep(t, FormArg, string(data)))
+	require.NoError(t, err)
 
-	dummyForm.Status = types.Open
-	dummyForm.BallotSize = 0
+	form := getForm(t, snap)
 
-	formBuf, err = dummyForm.Serialize(ctx)
+	require.Equal(t, types.Open, form.Status)
+	require.Equal(t, uint64(20), form.CloseBlock)
+	require.Equal(t, []types.Reopening{{
+		UserID:     dummyUserAdminID,
+		Reason:     "network outage",
+		CloseBlock: 20,
+	}}, form.Reopenings)
+	require.Equal(t, float64(types.Open), testutil.ToFloat64(PromFormStatus))
+
+	history, err := types.HistoryFromStore(ctx, fakeFormID, snap)
+	require.NoError(t, err)
+	require.Len(t, history.Events, 1)
+	require.Equal(t, types.FormEvent{
+		Command:    string(CmdReopenForm),
+		UserID:     dummyUserAdminID,
+		BlockIndex: 10,
+		Params: map[string]string{
+			"Reason":     "network outage",
+			"CloseBlock": "20",
+		},
+	}, history.Events[0])
+
+	// the ballots are rejected after the deadline
+	form.Voters = []int{123456}
+
+	formBuf, err = form.Serialize(ctx)
 	require.NoError(t, err)
 
 	err = snap.Set(dummyFormIDBuff, formBuf)
 	require.NoError(t, err)
 
-	err = cmd.castVote(snap, makeStep(t, FormArg, string(data)))
-	require.EqualError(t, err, "The user 123456 doesn't have the Voter permission on the form.")
+	blocks.height = 21
 
-	addVoter := types.AddVoter{FormID: fakeFormID, TargetUserID: dummyUserAdminID, PerformingUserID: dummyUserAdminID}
-	dataAddVoter, err := addVoter.Serialize(ctx)
-	require.NoError(t, err)
-	err = cmd.manageOwnersVotersForm(snap, makeStep(t, FormArg, string(dataAddVoter)))
+	castVote := types.CastVote{FormID: fakeFormID, VoterID: dummyUserAdminID}
+
+	data, err = castVote.Serialize(ctx)
 	require.NoError(t, err)
 
 	err = cmd.castVote(snap, makeStep(t, FormArg, string(data)))
-	require.EqualError(t, err, "the ballot has unexpected length: 1 != 0")
+	require.EqualError(t, err, "[FORM_NOT_OPEN] the form stopped accepting ballots at block 20")
 
-	dummyForm.BallotSize = 29
+	withdrawVote := types.WithdrawVote{FormID: fakeFormID, VoterID: dummyUserAdminID}
 
-	formBuf, err = dummyForm.Serialize(ctx)
+	data, err = withdrawVote.Serialize(ctx)
 	require.NoError(t, err)
 
-	err = snap.Set(dummyFormIDBuff, formBuf)
-	require.NoError(t, err)
+	err = cmd.withdrawVote(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "[FORM_NOT_OPEN] the form stopped accepting ballots at block 20")
+}
 
-	// encrypt a real message :
-	RandomStream := suite.RandomStream()
-	h := suite.Scalar().Pick(RandomStream)
-	pubKey := suite.Point().Mul(h, nil)
+func TestCommand_SuspendResumeForm(t *testing.T) {
+	initMetrics()
 
-	M := suite.Point().Embed([]byte("fakeVote"), random.New())
+	suspendForm := types.SuspendForm{
+		FormID: fakeFormID,
+		UserID: dummyUserAdminID,
+	}
 
-	// ElGamal-encrypt the point to produce ciphertext (K,C).
-	k := suite.Scalar().Pick(random.New()) // ephemeral private key
-	K := suite.Point().Mul(k, nil)         // ephemeral DH public key
-	S := suite.Point().Mul(k, pubKey)      // ephemeral DH shared secret
-	C := S.Add(S, M)                       // message blinded with secret
+	suspendData, err := suspendForm.Serialize(ctx)
+	require.NoError(t, err)
 
-	castVote.Ballot = types.Ciphervote{
-		types.EGPair{
-			K: K,
-			C: C,
-		},
+	resumeForm := types.ResumeForm{
+		FormID: fakeFormID,
+		UserID: dummyUserAdminID,
 	}
 
-	castVote.FormID = "X"
+	resumeData, err := resumeForm.Serialize(ctx)
+	require.NoError(t, err)
 
-	data, err = castVote.Serialize(ctx)
+	dummyForm, contract := initFormAndContract(123456)
+
+	formBuf, err := dummyForm.Serialize(ctx)
 	require.NoError(t, err)
 
+	cmd := evotingCommand{
+		Contract: &contract,
+	}
+
+	err = cmd.suspendForm(fake.NewSnapshot(), makeStep(t))
+	require.EqualError(t, err, getTransactionErr)
+
+	err = cmd.resumeForm(fake.NewSnapshot(), makeStep(t, FormArg, "dummy"))
+	require.EqualError(t, err, unmarshalTransactionErr)
+
+	err = cmd.suspendForm(fake.NewBadSnapshot(), makeStep(t, FormArg, string(suspendData)))
+	require.ErrorContains(t, err, "failed to get key")
+
+	snap := fake.NewSnapshot()
+
 	err = snap.Set(dummyFormIDBuff, formBuf)
 	require.NoError(t, err)
 
-	err = cmd.castVote(snap, makeStep(t, FormArg, string(data)))
-	require.EqualError(t, err, "failed to get form: failed to decode "+
-		"formIDHex: encoding/hex: invalid byte: U+0058 'X'")
+	err = cmd.suspendForm(snap, makeStep(t, FormArg, string(suspendData)))
+	require.EqualError(t, err, fmt.Sprintf("[FORM_NOT_OPEN] the form is not open, "+
+		"current status: %d", types.Initial))
 
-	dummyForm.FormID = fakeFormID
+	dummyForm.Status = types.Open
+	dummyForm.Owners = []int{654321}
 
 	formBuf, err = dummyForm.Serialize(ctx)
 	require.NoError(t, err)
@@ -307,48 +1252,91 @@ func TestCommand_CastVote(t *testing.T) {
 	err = snap.Set(dummyFormIDBuff, formBuf)
 	require.NoError(t, err)
 
-	castVote.FormID = fakeFormID
+	err = cmd.suspendForm(snap, makeStep(t, FormArg, string(suspendData)))
+	require.EqualError(t, err, "[NOT_OWNER] "+fmt.Sprintf(errNoOwnerPerms, dummyUserAdminID))
 
-	data, err = castVote.Serialize(ctx)
-	require.NoError(t, err)
+	err = cmd.resumeForm(snap, makeStep(t, FormArg, string(resumeData)))
+	require.EqualError(t, err, fmt.Sprintf("the form is not suspended, "+
+		"current status: %d", types.Open))
 
-	err = cmd.manageOwnersVotersForm(snap, makeStep(t, FormArg, string(dataAddVoter)))
+	dummyForm.Owners = []int{123456}
+
+	formBuf, err = dummyForm.Serialize(ctx)
 	require.NoError(t, err)
 
-	err = cmd.castVote(snap, makeStep(t, FormArg, string(data)))
+	err = snap.Set(dummyFormIDBuff, formBuf)
 	require.NoError(t, err)
 
-	res, err := snap.Get(dummyFormIDBuff)
+	err = cmd.suspendForm(snap, makeStep(t, FormArg, string(suspendData)))
 	require.NoError(t, err)
+	require.Equal(t, types.Suspended, getForm(t, snap).Status)
+	require.Equal(t, float64(types.Suspended), testutil.ToFloat64(PromFormStatus))
 
-	message, err := formFac.Deserialize(ctx, res)
+	// ballots are rejected while the form is suspended
+	castVote := types.CastVote{
+		FormID:  fakeFormID,
+		VoterID: dummyUserAdminID,
+		Ballot:  types.Ciphervote{},
+	}
+
+	castData, err := castVote.Serialize(ctx)
 	require.NoError(t, err)
 
-	form, ok := message.(types.Form)
-	require.True(t, ok)
+	err = cmd.castVote(snap, makeStep(t, FormArg, string(castData)))
+	require.EqualError(t, err, "[FORM_SUSPENDED] the form is suspended")
 
-	require.Equal(t, uint32(1), form.BallotCount)
-	suff, err := form.Suffragia(ctx, snap)
+	err = cmd.resumeForm(snap, makeStep(t, FormArg, string(resumeData)))
 	require.NoError(t, err)
-	require.True(t, castVote.Ballot.Equal(suff.Ciphervotes[0]))
+	require.Equal(t, types.Open, getForm(t, snap).Status)
+	require.Equal(t, float64(types.Open), testutil.ToFloat64(PromFormStatus))
 
-	require.Equal(t, castVote.VoterID, suff.VoterIDs[0])
-	require.Equal(t, float64(form.BallotCount), testutil.ToFloat64(PromFormBallots))
+	history, err := types.HistoryFromStore(ctx, fakeFormID, snap)
+	require.NoError(t, err)
+	require.Len(t, history.Events, 2)
+	require.Equal(t, string(CmdSuspendForm), history.Events[0].Command)
+	require.Equal(t, string(CmdResumeForm), history.Events[1].Command)
 }
 
-func TestCommand_CloseForm(t *testing.T) {
+func TestCommand_CreateRunoff(t *testing.T) {
 	initMetrics()
 
-	closeForm := types.CloseForm{
-		FormID: fakeFormID,
-		UserID: dummyUserAdminID,
+	createRunoff := types.CreateRunoff{
+		FormID:     fakeFormID,
+		UserID:     dummyUserAdminID,
+		QuestionID: "q1",
+		Rule:       types.RunoffRule{TopN: 2},
 	}
 
-	data, err := closeForm.Serialize(ctx)
+	data, err := createRunoff.Serialize(ctx)
 	require.NoError(t, err)
 
 	dummyForm, contract := initFormAndContract(123456)
-	dummyForm.FormID = fakeFormID
+	dummyForm.Status = types.Closed
+	dummyForm.Voters = []int{111111, 222222}
+	dummyForm.Configuration = types.Configuration{
+		Title: types.Title{En: "Election"},
+		Scaffold: []types.Subject{{
+			ID:    "s1",
+			Order: []types.ID{"q1"},
+			Selects: []types.Select{{
+				ID:   "q1",
+				MaxN: 1,
+				Choices: []types.Choice{
+					{Choice: "Alice"}, {Choice: "Bob"}, {Choice: "Carol"},
+				},
+			}},
+		}},
+	}
+
+	for _, choice := range []int{0, 2, 2, 1, 0, 2} {
+		selected := make([]bool, 3)
+		selected[choice] = true
+
+		dummyForm.DecryptedBallots = append(dummyForm.DecryptedBallots, types.Ballot{
+			SelectResultIDs: []types.ID{"q1"},
+			SelectResult:    [][]bool{selected},
+		})
+	}
 
 	formBuf, err := dummyForm.Serialize(ctx)
 	require.NoError(t, err)
@@ -357,34 +1345,23 @@ func TestCommand_CloseForm(t *testing.T) {
 		Contract: &contract,
 	}
 
-	err = cmd.closeForm(fake.NewSnapshot(), makeStep(t))
+	err = cmd.createRunoff(fake.NewSnapshot(), makeStep(t))
 	require.EqualError(t, err, getTransactionErr)
 
-	err = cmd.closeForm(fake.NewSnapshot(), makeStep(t, FormArg, "dummy"))
+	err = cmd.createRunoff(fake.NewSnapshot(), makeStep(t, FormArg, "dummy"))
 	require.EqualError(t, err, unmarshalTransactionErr)
 
-	err = cmd.closeForm(fake.NewBadSnapshot(), makeStep(t, FormArg, string(data)))
-	require.ErrorContains(t, err, "failed to get key")
-
 	snap := fake.NewSnapshot()
-	err = snap.Set(dummyFormIDBuff, invalidForm)
-	require.NoError(t, err)
-
-	err = cmd.closeForm(snap, makeStep(t, FormArg, string(data)))
-	require.ErrorContains(t, err, deserializeErr)
 
 	err = snap.Set(dummyFormIDBuff, formBuf)
 	require.NoError(t, err)
 
-	data, err = closeForm.Serialize(ctx)
-	require.NoError(t, err)
-
-	err = cmd.closeForm(snap, makeStep(t, FormArg, string(data)))
-	require.EqualError(t, err, fmt.Sprintf("the form is not open, "+
-		"current status: %d", types.Initial))
-	require.Equal(t, 0, testutil.CollectAndCount(PromFormStatus))
+	err = cmd.createRunoff(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, fmt.Sprintf("the result of the form is not "+
+		"available, current status: %d", types.Closed))
 
-	dummyForm.Status = types.Open
+	dummyForm.Status = types.ResultAvailable
+	dummyForm.Private = true
 
 	formBuf, err = dummyForm.Serialize(ctx)
 	require.NoError(t, err)
@@ -392,33 +1369,65 @@ func TestCommand_CloseForm(t *testing.T) {
 	err = snap.Set(dummyFormIDBuff, formBuf)
 	require.NoError(t, err)
 
-	err = cmd.closeForm(snap, makeStep(t, FormArg, string(data)))
-	require.EqualError(t, err, "at least two ballots are required")
+	createRunoff.QuestionID = "q2"
 
-	require.NoError(t, dummyForm.CastVote(ctx, snap, "123456", types.Ciphervote{}))
-	require.NoError(t, dummyForm.CastVote(ctx, snap, "654321", types.Ciphervote{}))
+	badData, err := createRunoff.Serialize(ctx)
+	require.NoError(t, err)
 
-	formBuf, err = dummyForm.Serialize(ctx)
+	err = cmd.createRunoff(snap, makeStep(t, FormArg, string(badData)))
+	require.EqualError(t, err, "failed to get candidates: question q2 not found in the tally")
+
+	// the runoff is checked against the limits like any form
+	parametersBuf, err := json.Marshal(types.Parameters{Limits: types.Limits{MaxChoices: 1}})
 	require.NoError(t, err)
 
-	err = snap.Set(dummyFormIDBuff, formBuf)
+	err = snap.Set([]byte(ParametersKey), parametersBuf)
 	require.NoError(t, err)
 
-	err = cmd.closeForm(snap, makeStep(t, FormArg, string(data)))
+	err = cmd.createRunoff(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "the configuration exceeds the limits: [LIMIT_EXCEEDED] a question "+
+		"of subject s1 has 2 choices, the limit is 1")
+
+	err = snap.Delete([]byte(ParametersKey))
 	require.NoError(t, err)
-	require.Equal(t, float64(types.Closed), testutil.ToFloat64(PromFormStatus))
 
-	res, err := snap.Get(dummyFormIDBuff)
+	step := makeStep(t, FormArg, string(data))
+
+	err = cmd.createRunoff(snap, step)
+	require.NoError(t, err)
+
+	h := sha256.New()
+	h.Write(step.Current.GetID())
+	runoffIDBuf := h.Sum(nil)
+
+	res, err := snap.Get(runoffIDBuf)
 	require.NoError(t, err)
 
 	message, err := formFac.Deserialize(ctx, res)
 	require.NoError(t, err)
 
-	form, ok := message.(types.Form)
+	runoff, ok := message.(types.Form)
 	require.True(t, ok)
 
-	require.Equal(t, types.Closed, form.Status)
-	require.Equal(t, float64(types.Closed), testutil.ToFloat64(PromFormStatus))
+	require.Equal(t, types.Initial, runoff.Status)
+	require.Equal(t, fakeFormID, runoff.ParentFormID)
+	require.Equal(t, dummyForm.Owners, runoff.Owners)
+	require.Equal(t, dummyForm.Voters, runoff.Voters)
+	require.True(t, runoff.Private)
+
+	// the runoff is listed among the forms of its owners and voters
+	owned, err := types.OwnerForms.FromStore(dummyForm.Owners[0], snap)
+	require.NoError(t, err)
+	require.Equal(t, []string{runoff.FormID}, owned)
+
+	voted, err := types.VoterForms.FromStore(111111, snap)
+	require.NoError(t, err)
+	require.Equal(t, []string{runoff.FormID}, voted)
+	require.Equal(t, []types.Choice{{Choice: "Alice"}, {Choice: "Carol"}},
+		runoff.Configuration.Scaffold[0].Selects[0].Choices)
+
+	parent := getForm(t, snap)
+	require.Equal(t, []string{runoff.FormID}, parent.RunoffFormIDs)
 }
 
 func TestCommand_ShuffleBallotsCannotShuffleTwice(t *testing.T) {
@@ -1056,6 +2065,94 @@ func TestCommand_DecryptBallots(t *testing.T) {
 	require.Equal(t, float64(types.ResultAvailable), testutil.ToFloat64(PromFormStatus))
 }
 
+func TestCommand_VerifyForm(t *testing.T) {
+	auditorID := "234567"
+
+	verifyForm := types.VerifyForm{
+		FormID: fakeFormID,
+		UserID: auditorID,
+	}
+
+	data, err := verifyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	dummyForm, contract := initFormAndContract(123456)
+	dummyForm.FormID = fakeFormID
+
+	shufflerKey, err := fakeCommonSigner.GetPublicKey().MarshalBinary()
+	require.NoError(t, err)
+
+	dummyForm.ShuffleThreshold = 1
+	dummyForm.ShuffleInstances = []types.ShuffleInstance{{
+		ShuffledBallots:   []types.Ciphervote{{{K: suite.Point(), C: suite.Point()}}},
+		ShufflerPublicKey: shufflerKey,
+	}}
+	dummyForm.PubsharesUnits.PubKeys = [][]byte{shufflerKey}
+	dummyForm.DecryptedBallots = []types.Ballot{{}}
+
+	cmd := evotingCommand{
+		Contract: &contract,
+	}
+
+	setForm := func(snap store.Snapshot) {
+		formBuf, err := dummyForm.Serialize(ctx)
+		require.NoError(t, err)
+		require.NoError(t, snap.Set(dummyFormIDBuff, formBuf))
+	}
+
+	lastEvent := func(snap store.Snapshot) types.FormEvent {
+		history, err := types.HistoryFromStore(ctx, fakeFormID, snap)
+		require.NoError(t, err)
+		return history.Events[len(history.Events)-1]
+	}
+
+	err = cmd.verifyForm(fake.NewSnapshot(), makeStep(t))
+	require.EqualError(t, err, getTransactionErr)
+
+	snap := fake.NewSnapshot()
+	setForm(snap)
+
+	err = cmd.verifyForm(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, fmt.Sprintf("the result of the form is not "+
+		"available, current status: %d", types.Initial))
+
+	dummyForm.Status = types.ResultAvailable
+	setForm(snap)
+
+	err = cmd.verifyForm(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "[FORBIDDEN] "+fmt.Sprintf(errNoAuditorPerms, auditorID))
+
+	require.NoError(t, dummyForm.AddRoleMember(types.RoleAuditor, auditorID))
+	setForm(snap)
+
+	err = cmd.verifyForm(snap, makeStep(t, FormArg, string(data)))
+	require.NoError(t, err)
+	require.Equal(t, types.FormEvent{
+		Command: string(CmdVerifyForm),
+		UserID:  auditorID,
+		Params:  map[string]string{"Result": "valid"},
+	}, lastEvent(snap))
+
+	// a failed verification is recorded as well
+	dummyForm.DecryptedBallots = []types.Ballot{{SelectResultIDs: []types.ID{"q1"}}}
+	setForm(snap)
+
+	err = cmd.verifyForm(snap, makeStep(t, FormArg, string(data)))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"Result": "invalid",
+		"Reason": "the public shares don't decrypt to the ballots of the result",
+	}, lastEvent(snap).Params)
+
+	dummyForm.ShuffleInstances = append(dummyForm.ShuffleInstances, dummyForm.ShuffleInstances[0])
+	setForm(snap)
+
+	err = cmd.verifyForm(snap, makeStep(t, FormArg, string(data)))
+	require.NoError(t, err)
+	require.Equal(t, "invalid shuffler: public key used twice: "+hex.EncodeToString(shufflerKey),
+		lastEvent(snap).Params["Reason"])
+}
+
 func TestCommand_CancelForm(t *testing.T) {
 	cancelForm := types.CancelForm{
 		FormID: fakeFormID,
@@ -1130,6 +2227,127 @@ func TestRegisterContract(t *testing.T) {
 		- 123456 add admin 777777
 		- 123456 remove admin 123456
 */
+func TestCommand_Tenants(t *testing.T) {
+	initMetrics()
+
+	_, contract := initFormAndContract(123456)
+
+	cmd := evotingCommand{
+		Contract: &contract,
+	}
+
+	serialize := func(msg serde.Message) string {
+		data, err := msg.Serialize(ctx)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	createTenant := types.CreateTenant{
+		TenantID:         "physics",
+		Name:             "Physics department",
+		AdminID:          "111111",
+		PerformingUserID: "123456",
+	}
+
+	err := cmd.manageTenants(fake.NewSnapshot(), makeStep(t))
+	require.EqualError(t, err, getTransactionErr)
+
+	err = cmd.manageTenants(fake.NewSnapshot(), makeStep(t, FormArg, "dummy"))
+	require.EqualError(t, err, unmarshalTransactionErr)
+
+	snap := fake.NewSnapshot()
+
+	addAdmin := types.AddAdmin{TargetUserID: "123456", PerformingUserID: "123456"}
+	err = cmd.manageAdminList(snap, makeStep(t, FormArg, serialize(addAdmin)))
+	require.NoError(t, err)
+
+	// only the admins of the deployment can create tenants
+	createTenant.PerformingUserID = "111111"
+	err = cmd.manageTenants(snap, makeStep(t, FormArg, serialize(createTenant)))
+	require.EqualError(t, err, "[NOT_ADMIN] The performing user is not an admin.")
+
+	createTenant.PerformingUserID = "123456"
+	err = cmd.manageTenants(snap, makeStep(t, FormArg, serialize(createTenant)))
+	require.NoError(t, err)
+
+	err = cmd.manageTenants(snap, makeStep(t, FormArg, serialize(createTenant)))
+	require.EqualError(t, err, `couldn't add tenant: tenant "physics" already exist`)
+
+	tenants, err := types.TenantsFromStore(ctx, snap, []byte(TenantsKey))
+	require.NoError(t, err)
+	require.Equal(t, []types.Tenant{{ID: "physics", Name: "Physics department"}},
+		tenants.Tenants)
+
+	addTenantAdmin := types.AddTenantAdmin{
+		TenantID:         "chemistry",
+		TargetUserID:     "222222",
+		PerformingUserID: "111111",
+	}
+
+	err = cmd.manageTenants(snap, makeStep(t, FormArg, serialize(addTenantAdmin)))
+	require.EqualError(t, err, `tenant "chemistry" not found`)
+
+	addTenantAdmin.TenantID = "physics"
+	err = cmd.manageTenants(snap, makeStep(t, FormArg, serialize(addTenantAdmin)))
+	require.NoError(t, err)
+
+	list, err := types.AdminListFromStore(ctx, adminListFac, snap, TenantAdminListId("physics"))
+	require.NoError(t, err)
+	require.Equal(t, []int{111111, 222222}, list.AdminList)
+
+	removeTenantAdmin := types.RemoveTenantAdmin{
+		TenantID:         "physics",
+		TargetUserID:     "111111",
+		PerformingUserID: "333333",
+	}
+
+	err = cmd.manageTenants(snap, makeStep(t, FormArg, serialize(removeTenantAdmin)))
+	require.EqualError(t, err, "[NOT_ADMIN] The performing user is not an admin of the tenant physics.")
+
+	// the admins of the deployment can't manage the admins of the tenants
+	removeTenantAdmin.PerformingUserID = "123456"
+	err = cmd.manageTenants(snap, makeStep(t, FormArg, serialize(removeTenantAdmin)))
+	require.EqualError(t, err, "[NOT_ADMIN] The performing user is not an admin of the tenant physics.")
+
+	removeTenantAdmin.PerformingUserID = "222222"
+	err = cmd.manageTenants(snap, makeStep(t, FormArg, serialize(removeTenantAdmin)))
+	require.NoError(t, err)
+
+	// the last admin of a tenant can't be removed
+	removeTenantAdmin.TargetUserID = "222222"
+	err = cmd.manageTenants(snap, makeStep(t, FormArg, serialize(removeTenantAdmin)))
+	require.EqualError(t, err, "couldn't remove admin: Error, cannot remove this "+
+		"Admin because it is the only one remaining.")
+
+	// forms of a tenant are created by its admins only
+	createForm := types.CreateForm{
+		UserID:   "123456",
+		TenantID: "physics",
+	}
+
+	err = cmd.createForm(snap, makeStep(t, FormArg, serialize(createForm)))
+	require.EqualError(t, err, "[NOT_ADMIN] The performing user is not an admin of the tenant physics.")
+
+	createForm.UserID = "222222"
+	step := makeStep(t, FormArg, serialize(createForm))
+
+	err = cmd.createForm(snap, step)
+	require.NoError(t, err)
+
+	h := sha256.New()
+	h.Write(step.Current.GetID())
+
+	res, err := snap.Get(h.Sum(nil))
+	require.NoError(t, err)
+
+	message, err := formFac.Deserialize(ctx, res)
+	require.NoError(t, err)
+
+	form, ok := message.(types.Form)
+	require.True(t, ok)
+	require.Equal(t, "physics", form.TenantID)
+}
+
 func TestCommand_AdminList(t *testing.T) {
 	initMetrics()
 
@@ -1242,7 +2460,19 @@ func TestCommand_AdminList(t *testing.T) {
 	require.NoError(t, err)
 
 	// Publish the command on the ledger.
-	err = cmd.manageAdminList(snap, makeStep(t, FormArg, string(data)))
+	step := makeStep(t, FormArg, string(data))
+	err = cmd.manageAdminList(snap, step)
+	require.NoError(t, err)
+
+	// With two admins, the other admin must approve the removal.
+	approve := types.ApproveAdminProposal{
+		ProposalID:       hex.EncodeToString(step.Current.GetID()),
+		PerformingUserID: dummyUID2,
+	}
+	data, err = approve.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.manageAdminProposals(snap, makeStep(t, FormArg, string(data)))
 	require.NoError(t, err)
 
 	// We retrieve the Admin Form from the ledger.
@@ -1511,6 +2741,13 @@ func TestCommand_VoterForm(t *testing.T) {
 
 	require.True(t, dummyUserVoterIndex == 0)
 
+	voterSciper, err := types.SciperToInt(dummyUserAdminID)
+	require.NoError(t, err)
+
+	voted, err := types.VoterForms.FromStore(voterSciper, snap)
+	require.NoError(t, err)
+	require.Equal(t, []string{fakeFormID}, voted)
+
 	// Now let's remove it
 
 	// We perform below the command on the ledger
@@ -1530,6 +2767,428 @@ func TestCommand_VoterForm(t *testing.T) {
 	dummyUserVoterIndex, _ = form.GetVoterIndex(dummyUserAdminID)
 
 	require.True(t, dummyUserVoterIndex == -1)
+
+	voted, err = types.VoterForms.FromStore(voterSciper, snap)
+	require.NoError(t, err)
+	require.Empty(t, voted)
+}
+
+func TestCommand_FormRoles(t *testing.T) {
+	registrarID := "234567"
+	voterID := "654321"
+
+	dummyForm, contract := initFormAndContract(123456)
+	dummyForm.FormID = fakeFormID
+
+	formBuf, err := dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	cmd := evotingCommand{
+		Contract: &contract,
+	}
+
+	snap := fake.NewSnapshot()
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	addVoter, err := types.AddVoter{
+		FormID:           fakeFormID,
+		TargetUserID:     voterID,
+		PerformingUserID: registrarID,
+	}.Serialize(ctx)
+	require.NoError(t, err)
+
+	// the user is not a registrar yet
+	err = cmd.manageOwnersVotersForm(snap, makeStep(t, FormArg, string(addVoter)))
+	require.EqualError(t, err, "[FORBIDDEN] "+fmt.Sprintf(errNoRegistrarPerms, registrarID))
+
+	// only owners can give roles
+	addRole := types.AddRole{
+		FormID:           fakeFormID,
+		Role:             types.RoleRegistrar,
+		TargetUserID:     registrarID,
+		PerformingUserID: registrarID,
+	}
+
+	data, err := addRole.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.manageOwnersVotersForm(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "[NOT_OWNER] "+fmt.Sprintf(errNoOwnerPerms, registrarID))
+
+	addRole.PerformingUserID = dummyUserAdminID
+	addRole.Role = "president"
+
+	data, err = addRole.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.manageOwnersVotersForm(snap, makeStep(t, FormArg, string(data)))
+	require.ErrorContains(t, err, `unknown role: "president"`)
+
+	addRole.Role = types.RoleRegistrar
+
+	data, err = addRole.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.manageOwnersVotersForm(snap, makeStep(t, FormArg, string(data)))
+	require.NoError(t, err)
+
+	err = cmd.manageOwnersVotersForm(snap, makeStep(t, FormArg, string(data)))
+	require.ErrorContains(t, err, "already has the role registrar")
+
+	isRegistrar, err := cmd.isRole(getForm(t, snap), registrarID, Registrars)
+	require.NoError(t, err)
+	require.True(t, isRegistrar)
+
+	// a registrar manages the voters
+	err = cmd.manageOwnersVotersForm(snap, makeStep(t, FormArg, string(addVoter)))
+	require.NoError(t, err)
+
+	isVoter, err := cmd.isRole(getForm(t, snap), voterID, Voters)
+	require.NoError(t, err)
+	require.True(t, isVoter)
+
+	// but can't open the form
+	openForm, err := types.OpenForm{FormID: fakeFormID, UserID: registrarID}.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.openForm(snap, makeStep(t, FormArg, string(openForm)))
+	require.EqualError(t, err, "[NOT_OWNER] "+fmt.Sprintf(errNoOwnerPerms, registrarID))
+
+	removeRole, err := types.RemoveRole{
+		FormID:           fakeFormID,
+		Role:             types.RoleRegistrar,
+		TargetUserID:     registrarID,
+		PerformingUserID: dummyUserAdminID,
+	}.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.manageOwnersVotersForm(snap, makeStep(t, FormArg, string(removeRole)))
+	require.NoError(t, err)
+
+	err = cmd.manageOwnersVotersForm(snap, makeStep(t, FormArg, string(removeRole)))
+	require.ErrorContains(t, err, "doesn't have the role registrar")
+
+	form := getForm(t, snap)
+	require.Empty(t, form.Registrars)
+
+	history, err := types.HistoryFromStore(ctx, fakeFormID, snap)
+	require.NoError(t, err)
+	require.Equal(t, string(CmdRemoveRoleForm), history.Events[len(history.Events)-1].Command)
+
+	// only the owners and the observers read the live status of a private
+	// form
+	observerID := "345678"
+
+	canRead, err := form.CanReadLiveStatus(observerID)
+	require.NoError(t, err)
+	require.True(t, canRead)
+
+	form.Private = true
+	formBuf, err = form.Serialize(ctx)
+	require.NoError(t, err)
+	require.NoError(t, snap.Set(dummyFormIDBuff, formBuf))
+
+	canRead, err = form.CanReadLiveStatus(observerID)
+	require.NoError(t, err)
+	require.False(t, canRead)
+
+	addRole.Role = types.RoleObserver
+	addRole.TargetUserID = observerID
+
+	data, err = addRole.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.manageOwnersVotersForm(snap, makeStep(t, FormArg, string(data)))
+	require.NoError(t, err)
+
+	form = getForm(t, snap)
+	require.True(t, form.Private)
+
+	for _, userID := range []string{observerID, dummyUserAdminID} {
+		canRead, err = form.CanReadLiveStatus(userID)
+		require.NoError(t, err)
+		require.True(t, canRead)
+	}
+
+	canRead, err = form.CanReadLiveStatus(voterID)
+	require.NoError(t, err)
+	require.False(t, canRead)
+}
+
+func TestCommand_AdminProposals(t *testing.T) {
+	adminA, adminB, adminC, adminD := "123456", "234567", "345678", "567890"
+
+	blocks := &fakeBlocks{BlockStore: blockstore.NewInMemory()}
+
+	_, contract := initFormAndContract(123456)
+	contract.blocks = blocks
+
+	cmd := evotingCommand{
+		Contract: &contract,
+	}
+
+	snap := fake.NewSnapshot()
+
+	run := func(msg serde.Message) (execution.Step, error) {
+		data, err := msg.Serialize(ctx)
+		require.NoError(t, err)
+
+		step := makeStep(t, FormArg, string(data))
+
+		switch msg.(type) {
+		case types.AddAdmin, types.RemoveAdmin:
+			return step, cmd.manageAdminList(snap, step)
+		default:
+			return step, cmd.manageAdminProposals(snap, step)
+		}
+	}
+
+	isAdmin := func(userID string) bool {
+		list, err := types.AdminListFromStore(ctx, adminListFac, snap, AdminListId)
+		require.NoError(t, err)
+
+		index, err := list.GetAdminIndex(userID)
+		require.NoError(t, err)
+
+		return index >= 0
+	}
+
+	getProposals := func() *types.AdminProposals {
+		proposals, err := types.AdminProposalsFromStore(snap, []byte(AdminProposalsKey))
+		require.NoError(t, err)
+
+		return &proposals
+	}
+
+	getProposal := func(proposalID string) (*types.AdminProposal, error) {
+		return getProposals().Get(proposalID)
+	}
+
+	// a single admin applies changes right away
+	_, err := run(types.ProposeAdminChange{Action: types.ProposalAddAdmin,
+		TargetUserID: adminA, PerformingUserID: adminA})
+	require.NoError(t, err)
+	_, err = run(types.AddAdmin{TargetUserID: adminB, PerformingUserID: adminA})
+	require.NoError(t, err)
+	require.True(t, isAdmin(adminB))
+
+	// with two admins, the default majority needs both of them
+	step, err := run(types.AddAdmin{TargetUserID: adminC, PerformingUserID: adminA})
+	require.NoError(t, err)
+	require.False(t, isAdmin(adminC))
+
+	proposalID := hex.EncodeToString(step.Current.GetID())
+
+	proposal, err := getProposal(proposalID)
+	require.NoError(t, err)
+	require.Equal(t, types.DefaultProposalLifetime, proposal.ExpiresAt-proposal.CreatedAt)
+
+	_, err = run(types.ApproveAdminProposal{ProposalID: proposalID,
+		PerformingUserID: adminB})
+	require.NoError(t, err)
+	require.True(t, isAdmin(adminC))
+
+	// settled proposals are pruned
+	_, err = getProposal(proposalID)
+	require.EqualError(t, err, fmt.Sprintf("proposal %q not found", proposalID))
+
+	_, err = run(types.ProposeAdminChange{Action: types.ProposalThreshold,
+		Threshold: 4, PerformingUserID: adminA})
+	require.ErrorContains(t, err, "the threshold must be between 2 and 3, got 4")
+
+	_, err = run(types.ProposeAdminChange{Action: types.ProposalThreshold,
+		Threshold: 1, PerformingUserID: adminA})
+	require.ErrorContains(t, err, "the threshold must be between 2 and 3, got 1")
+
+	_, err = run(types.ProposeAdminChange{Action: types.ProposalThreshold,
+		Threshold: 2, PerformingUserID: adminA,
+		Lifetime: types.MaxProposalLifetime + 1})
+	require.EqualError(t, err, fmt.Sprintf("the lifetime must be at most %d blocks, got %d",
+		types.MaxProposalLifetime, types.MaxProposalLifetime+1))
+
+	step, err = run(types.ProposeAdminChange{Action: types.ProposalThreshold,
+		Threshold: 2, PerformingUserID: adminA})
+	require.NoError(t, err)
+
+	_, err = run(types.ApproveAdminProposal{ProposalID: hex.EncodeToString(step.Current.GetID()),
+		PerformingUserID: adminC})
+	require.NoError(t, err)
+	require.Equal(t, 2, getProposals().Threshold)
+
+	// the expiry is a block index
+	blocks.height = 100
+
+	step, err = run(types.ProposeAdminChange{Action: types.ProposalRemoveAdmin,
+		TargetUserID: adminC, PerformingUserID: adminA, Lifetime: 10})
+	require.NoError(t, err)
+	require.True(t, isAdmin(adminC))
+
+	proposalID = hex.EncodeToString(step.Current.GetID())
+
+	proposal, err = getProposal(proposalID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), proposal.CreatedAt)
+	require.Equal(t, uint64(110), proposal.ExpiresAt)
+
+	_, err = run(types.ApproveAdminProposal{ProposalID: proposalID,
+		PerformingUserID: adminA})
+	require.EqualError(t, err, "failed to vote: the admin 123456 already voted on the proposal")
+
+	_, err = run(types.ApproveAdminProposal{ProposalID: proposalID,
+		PerformingUserID: "456789"})
+	require.EqualError(t, err, "[NOT_ADMIN] The performing user is not an admin.")
+
+	blocks.height = 111
+
+	_, err = run(types.ApproveAdminProposal{ProposalID: proposalID,
+		PerformingUserID: adminB})
+	require.EqualError(t, err, fmt.Sprintf("the proposal %s expired", proposalID))
+
+	_, err = run(types.ApproveAdminProposal{ProposalID: "unknown",
+		PerformingUserID: adminB})
+	require.EqualError(t, err, `proposal "unknown" not found`)
+
+	blocks.height = 110
+
+	_, err = run(types.ApproveAdminProposal{ProposalID: proposalID,
+		PerformingUserID: adminB})
+	require.NoError(t, err)
+	require.False(t, isAdmin(adminC))
+
+	// a single rejection is enough when there are two admins
+	step, err = run(types.AddAdmin{TargetUserID: adminD, PerformingUserID: adminA})
+	require.NoError(t, err)
+	require.False(t, isAdmin(adminD))
+
+	proposalID = hex.EncodeToString(step.Current.GetID())
+
+	_, err = run(types.RejectAdminProposal{ProposalID: proposalID,
+		PerformingUserID: adminB})
+	require.NoError(t, err)
+
+	_, err = run(types.ApproveAdminProposal{ProposalID: proposalID,
+		PerformingUserID: adminB})
+	require.EqualError(t, err, fmt.Sprintf("proposal %q not found", proposalID))
+	require.False(t, isAdmin(adminD))
+
+	// expired proposals are pruned when the proposals are stored
+	step, err = run(types.ProposeAdminChange{Action: types.ProposalAddAdmin,
+		TargetUserID: adminD, PerformingUserID: adminA, Lifetime: 5})
+	require.NoError(t, err)
+
+	expiredID := hex.EncodeToString(step.Current.GetID())
+
+	blocks.height = 200
+
+	_, err = run(types.ProposeAdminChange{Action: types.ProposalAddAdmin,
+		TargetUserID: adminC, PerformingUserID: adminA})
+	require.NoError(t, err)
+
+	_, err = getProposal(expiredID)
+	require.EqualError(t, err, fmt.Sprintf("proposal %q not found", expiredID))
+	require.Len(t, getProposals().Proposals, 1)
+}
+
+func TestCommand_RevokeAccess(t *testing.T) {
+	accessSrvc := NewAccessService(darc.NewService(ctx), ctx)
+	signer := bls.NewSigner()
+	other := bls.NewSigner()
+	all := bls.NewSigner()
+
+	identity, err := signer.GetPublicKey().MarshalBinary()
+	require.NoError(t, err)
+
+	otherIdentity, err := other.GetPublicKey().MarshalBinary()
+	require.NoError(t, err)
+
+	contract := NewContract(accessSrvc, fakeDKG{}, fakeAuthorityFactory{}, blockstore.NewInMemory())
+	cmd := evotingCommand{
+		Contract: &contract,
+	}
+
+	snap := fake.NewSnapshot()
+
+	err = accessSrvc.Grant(snap, NewCommandCreds(CmdCastVote), signer.GetPublicKey())
+	require.NoError(t, err)
+	err = accessSrvc.Grant(snap, NewCommandCreds(CmdCastVote), other.GetPublicKey())
+	require.NoError(t, err)
+	err = accessSrvc.Grant(snap, NewCreds(), all.GetPublicKey())
+	require.NoError(t, err)
+
+	// a node holding only CAST_VOTE is refused the other commands
+	require.NoError(t, contract.matchAccess(snap, CmdCastVote, signer.GetPublicKey()))
+	require.Error(t, contract.matchAccess(snap, CmdCloseForm, signer.GetPublicKey()))
+
+	// the credential of all the commands only applies to the commands that
+	// have not been granted on their own
+	require.NoError(t, contract.matchAccess(snap, CmdCloseForm, all.GetPublicKey()))
+	require.Error(t, contract.matchAccess(snap, CmdCastVote, all.GetPublicKey()))
+
+	// revoking requires its own credential, which the credential of all the
+	// commands doesn't give
+	require.Error(t, contract.matchAccess(snap, CmdRevokeAccess, all.GetPublicKey()))
+
+	err = accessSrvc.Grant(snap, NewCommandCreds(CmdRevokeAccess), signer.GetPublicKey())
+	require.NoError(t, err)
+
+	require.NoError(t, contract.matchAccess(snap, CmdRevokeAccess, signer.GetPublicKey()))
+	require.Error(t, contract.matchAccess(snap, CmdRevokeAccess, all.GetPublicKey()))
+
+	revoke := types.RevokeAccess{
+		Command:    string(CmdCastVote),
+		Identities: []string{"not base64"},
+	}
+
+	data, err := revoke.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.revokeAccess(snap, makeStep(t, FormArg, string(data)))
+	require.ErrorContains(t, err, "failed to decode base64ID")
+
+	revoke.Command = credentialAllCommand
+	revoke.Identities = []string{base64.StdEncoding.EncodeToString(identity)}
+
+	data, err = revoke.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.revokeAccess(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "the credential of all the commands can't be revoked")
+
+	revoke.Command = string(CmdCloseForm)
+
+	data, err = revoke.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.revokeAccess(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "failed to revoke CLOSE_FORM: the credential is not "+
+		"granted: rule 'go.dedis.ch/dela.Evoting:CLOSE_FORM' not found")
+
+	revoke.Command = string(CmdCastVote)
+
+	data, err = revoke.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.revokeAccess(snap, makeStep(t, FormArg, string(data)))
+	require.NoError(t, err)
+
+	require.Error(t, contract.matchAccess(snap, CmdCastVote, signer.GetPublicKey()))
+	require.NoError(t, contract.matchAccess(snap, CmdCastVote, other.GetPublicKey()))
+
+	err = cmd.revokeAccess(snap, makeStep(t, FormArg, string(data)))
+	require.ErrorContains(t, err, "the credential is not granted")
+
+	// the last holder of a command keeps it
+	revoke.Identities = []string{base64.StdEncoding.EncodeToString(otherIdentity)}
+
+	data, err = revoke.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.revokeAccess(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "failed to revoke CAST_VOTE: the last identities "+
+		"of the credential can't be revoked")
+
+	require.NoError(t, contract.matchAccess(snap, CmdCastVote, other.GetPublicKey()))
 }
 
 // -----------------------------------------------------------------------------
@@ -1562,7 +3221,7 @@ func initFormAndContract(initialOwner int) (types.Form, Contract) {
 	service := fakeAccess{err: fake.GetError()}
 	rosterFac := fakeAuthorityFactory{}
 
-	contract := NewContract(service, fakeDkg, rosterFac)
+	contract := NewContract(service, fakeDkg, rosterFac, blockstore.NewInMemory())
 
 	return dummyForm, contract
 }
@@ -1675,6 +3334,20 @@ func makeStep(t *testing.T, args ...string) execution.Step {
 	return execution.Step{Current: makeTx(t, args...)}
 }
 
+// getForm reads the dummy form back from the snapshot
+func getForm(t *testing.T, snap store.Snapshot) types.Form {
+	res, err := snap.Get(dummyFormIDBuff)
+	require.NoError(t, err)
+
+	message, err := formFac.Deserialize(ctx, res)
+	require.NoError(t, err)
+
+	form, ok := message.(types.Form)
+	require.True(t, ok)
+
+	return form
+}
+
 func makeTx(t *testing.T, args ...string) txn.Transaction {
 	options := []signed.TransactionOption{}
 	for i := 0; i < len(args)-1; i += 2 {
@@ -1754,6 +3427,14 @@ func (srvc fakeAccess) Grant(store.Snapshot, access.Credential, ...access.Identi
 	return srvc.err
 }
 
+func (srvc fakeAccess) Granted(store.Readable, access.Credential) (bool, error) {
+	return false, srvc.err
+}
+
+func (srvc fakeAccess) Revoke(store.Snapshot, access.Credential, ...access.Identity) error {
+	return srvc.err
+}
+
 type fakeStore struct {
 	store.Snapshot
 }
@@ -1778,6 +3459,14 @@ func (c fakeCmd) manageOwnersVotersForm(snap store.Snapshot, step execution.Step
 	return c.err
 }
 
+func (c fakeCmd) revokeAccess(snap store.Snapshot, step execution.Step) error {
+	return c.err
+}
+
+func (c fakeCmd) manageAdminProposals(snap store.Snapshot, step execution.Step) error {
+	return c.err
+}
+
 func (c fakeCmd) createForm(snap store.Snapshot, step execution.Step) error {
 	return c.err
 }
@@ -1790,6 +3479,38 @@ func (c fakeCmd) castVote(snap store.Snapshot, step execution.Step) error {
 	return c.err
 }
 
+func (c fakeCmd) withdrawVote(snap store.Snapshot, step execution.Step) error {
+	return c.err
+}
+
+func (c fakeCmd) setLimits(snap store.Snapshot, step execution.Step) error {
+	return c.err
+}
+
+func (c fakeCmd) manageDelegations(snap store.Snapshot, step execution.Step) error {
+	return c.err
+}
+
+func (c fakeCmd) reopenForm(snap store.Snapshot, step execution.Step) error {
+	return c.err
+}
+
+func (c fakeCmd) manageTenants(snap store.Snapshot, step execution.Step) error {
+	return c.err
+}
+
+func (c fakeCmd) createRunoff(snap store.Snapshot, step execution.Step) error {
+	return c.err
+}
+
+func (c fakeCmd) suspendForm(snap store.Snapshot, step execution.Step) error {
+	return c.err
+}
+
+func (c fakeCmd) resumeForm(snap store.Snapshot, step execution.Step) error {
+	return c.err
+}
+
 func (c fakeCmd) closeForm(snap store.Snapshot, step execution.Step) error {
 	return c.err
 }
@@ -1802,6 +3523,10 @@ func (c fakeCmd) combineShares(snap store.Snapshot, step execution.Step) error {
 	return c.err
 }
 
+func (c fakeCmd) verifyForm(snap store.Snapshot, step execution.Step) error {
+	return c.err
+}
+
 func (c fakeCmd) cancelForm(snap store.Snapshot, step execution.Step) error {
 	return c.err
 }
@@ -1823,6 +3548,17 @@ func (f fakeAuthorityFactory) AuthorityOf(ctx serde.Context, rosterBuf []byte) (
 	return fakeAuthority, nil
 }
 
+// fakeBlocks is a block store whose length is set by the test
+type fakeBlocks struct {
+	blockstore.BlockStore
+
+	height uint64
+}
+
+func (b *fakeBlocks) Len() uint64 {
+	return b.height
+}
+
 type fakeAuthority struct {
 	serde.Message
 	serde.Fingerprinter
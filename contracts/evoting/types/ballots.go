@@ -6,14 +6,17 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"golang.org/x/xerrors"
 )
 
 const (
-	selectID = "select"
-	rankID   = "rank"
-	textID   = "text"
+	selectID     = "select"
+	rankID       = "rank"
+	textID       = "text"
+	scoreID      = "score"
+	cumulativeID = "cumulative"
 )
 
 // Ballot contains all information about a simple ballot
@@ -26,6 +29,11 @@ type Ballot struct {
 	SelectResultIDs []ID
 	SelectResult    [][]bool
 
+	// SelectWriteIns contains the decoded write-in answers of each Select
+	// question, with an empty string for each unused slot. It is indexed like
+	// SelectResult.
+	SelectWriteIns [][]string
+
 	// RankResult contains the result of each Rank question. The result of a
 	// rank question is the list of ranks for each choice. A choice that hasn't
 	// been ranked will have a value < 0. The ID slice is used to map a question
@@ -38,6 +46,20 @@ type Ballot struct {
 	// used to map a question ID to its index in the TextResult slice
 	TextResultIDs []ID
 	TextResult    [][]string
+
+	// ScoreResult contains the result of each Score question. The result of a
+	// score question is the score given to each choice. A choice that hasn't
+	// been scored will have a value < 0. The ID slice is used to map a question
+	// ID to its index in the ScoreResult slice
+	ScoreResultIDs []ID
+	ScoreResult    [][]int
+
+	// CumulativeResult contains the result of each Cumulative question. The
+	// result of a cumulative question is the number of points given to each
+	// choice. The ID slice is used to map a question ID to its index in the
+	// CumulativeResult slice
+	CumulativeResultIDs []ID
+	CumulativeResult    [][]uint
 }
 
 // Unmarshal decodes the given string according to the format described in
@@ -48,6 +70,7 @@ func (b *Ballot) Unmarshal(marshalledBallot string, form Form) error {
 
 	b.SelectResultIDs = make([]ID, 0)
 	b.SelectResult = make([][]bool, 0)
+	b.SelectWriteIns = make([][]string, 0)
 
 	b.RankResultIDs = make([]ID, 0)
 	b.RankResult = make([][]int8, 0)
@@ -55,6 +78,12 @@ func (b *Ballot) Unmarshal(marshalledBallot string, form Form) error {
 	b.TextResultIDs = make([]ID, 0)
 	b.TextResult = make([][]string, 0)
 
+	b.ScoreResultIDs = make([]ID, 0)
+	b.ScoreResult = make([][]int, 0)
+
+	b.CumulativeResultIDs = make([]ID, 0)
+	b.CumulativeResult = make([][]uint, 0)
+
 	for _, line := range lines {
 		if line == "" {
 			// empty line, the valid part of the ballot is over
@@ -92,7 +121,13 @@ func (b *Ballot) Unmarshal(marshalledBallot string, form Form) error {
 				Choices: make([]Choice, q.GetChoicesLength()),
 			}
 
-			results, err := selectQ.unmarshalAnswers(selections)
+			configuredQ, ok := q.(Select)
+			if ok {
+				selectQ.WriteIns = configuredQ.WriteIns
+				selectQ.MaxWriteInLength = configuredQ.MaxWriteInLength
+			}
+
+			results, writeIns, err := selectQ.unmarshalAnswers(selections)
 			if err != nil {
 				b.invalidate()
 				return fmt.Errorf("could not unmarshal select answers: %v", err)
@@ -100,6 +135,7 @@ func (b *Ballot) Unmarshal(marshalledBallot string, form Form) error {
 
 			b.SelectResultIDs = append(b.SelectResultIDs, ID(questionID))
 			b.SelectResult = append(b.SelectResult, results)
+			b.SelectWriteIns = append(b.SelectWriteIns, writeIns)
 
 		case rankID:
 			ranks := strings.Split(question[2], ",")
@@ -138,6 +174,40 @@ func (b *Ballot) Unmarshal(marshalledBallot string, form Form) error {
 			b.TextResultIDs = append(b.TextResultIDs, ID(questionID))
 			b.TextResult = append(b.TextResult, results)
 
+		case scoreID:
+			scores := strings.Split(question[2], ",")
+
+			scoreQ, ok := q.(Score)
+			if !ok {
+				b.invalidate()
+				return fmt.Errorf("question %s is not a score question", questionID)
+			}
+
+			results, err := scoreQ.unmarshalAnswers(scores)
+			if err != nil {
+				b.invalidate()
+				return fmt.Errorf("could not unmarshal score answers: %v", err)
+			}
+			b.ScoreResultIDs = append(b.ScoreResultIDs, ID(questionID))
+			b.ScoreResult = append(b.ScoreResult, results)
+
+		case cumulativeID:
+			points := strings.Split(question[2], ",")
+
+			cumulativeQ, ok := q.(Cumulative)
+			if !ok {
+				b.invalidate()
+				return fmt.Errorf("question %s is not a cumulative question", questionID)
+			}
+
+			results, err := cumulativeQ.unmarshalAnswers(points)
+			if err != nil {
+				b.invalidate()
+				return fmt.Errorf("could not unmarshal cumulative answers: %v", err)
+			}
+			b.CumulativeResultIDs = append(b.CumulativeResultIDs, ID(questionID))
+			b.CumulativeResult = append(b.CumulativeResult, results)
+
 		default:
 			b.invalidate()
 			return fmt.Errorf("question type is unknown")
@@ -168,6 +238,11 @@ func (b *Ballot) invalidate() {
 	b.TextResult = nil
 	b.SelectResultIDs = nil
 	b.SelectResult = nil
+	b.SelectWriteIns = nil
+	b.ScoreResultIDs = nil
+	b.ScoreResult = nil
+	b.CumulativeResultIDs = nil
+	b.CumulativeResult = nil
 }
 
 // Equal performs a loose comparison of a ballot.
@@ -198,6 +273,22 @@ func (b *Ballot) Equal(other Ballot) bool {
 		}
 	}
 
+	if len(b.SelectWriteIns) != len(other.SelectWriteIns) {
+		return false
+	}
+
+	for i, wr := range b.SelectWriteIns {
+		if len(wr) != len(other.SelectWriteIns[i]) {
+			return false
+		}
+
+		for j, r := range wr {
+			if r != other.SelectWriteIns[i][j] {
+				return false
+			}
+		}
+	}
+
 	if len(b.RankResultIDs) != len(other.RankResultIDs) {
 		return false
 	}
@@ -250,6 +341,58 @@ func (b *Ballot) Equal(other Ballot) bool {
 		}
 	}
 
+	if len(b.ScoreResultIDs) != len(other.ScoreResultIDs) {
+		return false
+	}
+
+	for i, id := range b.ScoreResultIDs {
+		if id != other.ScoreResultIDs[i] {
+			return false
+		}
+	}
+
+	if len(b.ScoreResult) != len(other.ScoreResult) {
+		return false
+	}
+
+	for i, sr := range b.ScoreResult {
+		if len(sr) != len(other.ScoreResult[i]) {
+			return false
+		}
+
+		for j, r := range sr {
+			if r != other.ScoreResult[i][j] {
+				return false
+			}
+		}
+	}
+
+	if len(b.CumulativeResultIDs) != len(other.CumulativeResultIDs) {
+		return false
+	}
+
+	for i, id := range b.CumulativeResultIDs {
+		if id != other.CumulativeResultIDs[i] {
+			return false
+		}
+	}
+
+	if len(b.CumulativeResult) != len(other.CumulativeResult) {
+		return false
+	}
+
+	for i, cr := range b.CumulativeResult {
+		if len(cr) != len(other.CumulativeResult[i]) {
+			return false
+		}
+
+		for j, r := range cr {
+			if r != other.CumulativeResult[i][j] {
+				return false
+			}
+		}
+	}
+
 	return true
 }
 
@@ -275,7 +418,7 @@ type Choice struct {
 }
 
 // Subject is a wrapper around multiple questions that can be of type "select",
-// "rank", or "text".
+// "rank", "text", "score", or "cumulative".
 type Subject struct {
 	ID ID
 
@@ -285,10 +428,12 @@ type Subject struct {
 	// identifier. This is purely for display purpose.
 	Order []ID
 
-	Subjects []Subject
-	Selects  []Select
-	Ranks    []Rank
-	Texts    []Text
+	Subjects    []Subject
+	Selects     []Select
+	Ranks       []Rank
+	Texts       []Text
+	Scores      []Score
+	Cumulatives []Cumulative
 }
 
 // GetQuestion finds the question associated to a given ID and returns it
@@ -319,6 +464,18 @@ func (s *Subject) GetQuestion(ID ID) Question {
 		}
 	}
 
+	for _, score := range s.Scores {
+		if score.ID == ID {
+			return score
+		}
+	}
+
+	for _, cumulative := range s.Cumulatives {
+		if cumulative.ID == ID {
+			return cumulative
+		}
+	}
+
 	return nil
 }
 
@@ -356,6 +513,11 @@ func (s *Subject) MaxEncodedSize() int {
 
 		// 2 bytes per choice (0/1 and separating comma/newline)
 		size += len(selection.Choices) * 2
+
+		// base64-encoded write-in of at most 4 bytes per character and 1 byte
+		// for separating comma/newline per write-in slot
+		maxWriteIn := base64.StdEncoding.EncodedLen(4*int(selection.MaxWriteInLength)) + 1
+		size += maxWriteIn * int(selection.WriteIns)
 	}
 
 	for _, text := range s.Texts {
@@ -373,6 +535,33 @@ func (s *Subject) MaxEncodedSize() int {
 			int(math.Max(float64(len(text.Choices)-int(text.MaxN)), 0))
 	}
 
+	for _, score := range s.Scores {
+		size += len(score.GetID())
+		// the ID arrives Base64-encoded, but score.ID is decoded
+		// we need the size of the Base64-encoded string
+		size += len(base64.StdEncoding.EncodeToString([]byte(score.ID)))
+
+		// ':' separators ('id:id:choice')
+		size += 2
+
+		// digits of the highest score and separating comma/newline per choice
+		size += len(score.Choices) * (len(strconv.FormatUint(uint64(score.Max), 10)) + 1)
+	}
+
+	for _, cumulative := range s.Cumulatives {
+		size += len(cumulative.GetID())
+		// the ID arrives Base64-encoded, but cumulative.ID is decoded
+		// we need the size of the Base64-encoded string
+		size += len(base64.StdEncoding.EncodeToString([]byte(cumulative.ID)))
+
+		// ':' separators ('id:id:choice')
+		size += 2
+
+		// digits of the per-choice cap and separating comma/newline per choice
+		size += len(cumulative.Choices) *
+			(len(strconv.FormatUint(uint64(cumulative.MaxPerChoice), 10)) + 1)
+	}
+
 	// additional '\n' on last line
 	if size != 0 {
 		size++
@@ -402,6 +591,16 @@ func (s *Subject) isValid(uniqueIDs map[ID]bool) bool {
 		if !isValid(sform) {
 			return false
 		}
+
+		if sform.WriteIns > 0 && sform.MaxWriteInLength == 0 {
+			return false
+		}
+
+		switch sform.Majority {
+		case "", SimpleMajority, AbsoluteMajority, TwoThirdsMajority:
+		default:
+			return false
+		}
 	}
 
 	for _, text := range s.Texts {
@@ -412,9 +611,27 @@ func (s *Subject) isValid(uniqueIDs map[ID]bool) bool {
 		}
 	}
 
+	for _, score := range s.Scores {
+		uniqueIDs[score.ID] = true
+
+		if !isValid(score) || score.Min > score.Max {
+			return false
+		}
+	}
+
+	for _, cumulative := range s.Cumulatives {
+		uniqueIDs[cumulative.ID] = true
+
+		if !isValid(cumulative) || cumulative.MaxPerChoice == 0 ||
+			cumulative.MaxPerChoice > cumulative.Budget {
+			return false
+		}
+	}
+
 	// If some ID was not unique
 	currentMapSize := len(uniqueIDs)
-	if prevMapSize+len(s.Ranks)+len(s.Texts)+len(s.Selects)+1 > currentMapSize {
+	if prevMapSize+len(s.Ranks)+len(s.Texts)+len(s.Selects)+len(s.Scores)+
+		len(s.Cumulatives)+1 > currentMapSize {
 		return false
 	}
 
@@ -434,6 +651,38 @@ func (s *Subject) isValid(uniqueIDs map[ID]bool) bool {
 	return true
 }
 
+// checkMajorities returns an error if a question other than a Select
+// question of the subject sets a majority rule, or if a Select question with
+// write-ins does, since the write-ins can't win the outcome.
+func (s *Subject) checkMajorities() error {
+	for _, sform := range s.Selects {
+		if sform.Majority != "" && sform.WriteIns > 0 {
+			return xerrors.Errorf("select question %s can't have both write-ins and a majority rule", sform.ID)
+		}
+	}
+
+	for _, rank := range s.Ranks {
+		if rank.Majority != "" {
+			return xerrors.Errorf("rank question %s can't have a majority rule", rank.ID)
+		}
+	}
+
+	for _, text := range s.Texts {
+		if text.Majority != "" {
+			return xerrors.Errorf("text question %s can't have a majority rule", text.ID)
+		}
+	}
+
+	for _, subject := range s.Subjects {
+		err := subject.checkMajorities()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Question is an offering the primitives all questions should have to
 // verify the validity of an answer on a decrypted ballot.
 type Question interface {
@@ -444,21 +693,52 @@ type Question interface {
 }
 
 func isValid(q Question) bool {
-	return (q.GetMinN() <= q.GetMaxN()) && (q.GetMaxN() <= uint(q.GetChoicesLength()))
+	selectable := uint(q.GetChoicesLength())
+
+	// write-in slots are selectable alongside the predefined choices
+	sform, ok := q.(Select)
+	if ok {
+		selectable += sform.WriteIns
+	}
+
+	return (q.GetMinN() <= q.GetMaxN()) && (q.GetMaxN() <= selectable)
 }
 
 // Select describes a "select" question, which requires the user to select one
-// or multiple choices. implements Question
+// or multiple choices. Optionally, the user can fill up to WriteIns write-in
+// slots of at most MaxWriteInLength characters, which count as selected
+// answers alongside the choices. implements Question
 type Select struct {
 	ID ID
 
-	Title   Title
-	MaxN    uint
-	MinN    uint
-	Choices []Choice
-	Hint    Hint
+	Title            Title
+	MaxN             uint
+	MinN             uint
+	Choices          []Choice
+	WriteIns         uint
+	MaxWriteInLength uint
+	Hint             Hint
+
+	// Majority is the rule used to determine the outcome of the question.
+	// The question has no outcome if it is empty.
+	Majority MajorityRule
 }
 
+// MajorityRule defines how the winning choices of a Select question are
+// determined
+type MajorityRule string
+
+const (
+	// SimpleMajority designates the choices with the most votes
+	SimpleMajority MajorityRule = "simple"
+	// AbsoluteMajority designates the choices selected by more than half of
+	// the ballots
+	AbsoluteMajority MajorityRule = "absolute"
+	// TwoThirdsMajority designates the choices selected by at least two
+	// thirds of the ballots
+	TwoThirdsMajority MajorityRule = "two-thirds"
+)
+
 // GetID implements Question
 func (s Select) GetID() string {
 	return selectID
@@ -480,21 +760,25 @@ func (s Select) GetChoicesLength() int {
 }
 
 // unmarshalAnswers interprets the given raw answers into a slice of bool with
-// the answer for each choice and ensure the answers are correctly formatted
-func (s Select) unmarshalAnswers(sforms []string) ([]bool, error) {
-	if len(sforms) != len(s.Choices) {
-		return nil, fmt.Errorf("question %s has a wrong number of answers:"+
-			" expected %d got %d", s.ID, len(s.Choices), len(sforms))
+// the answer for each choice and a slice with the decoded write-in of each
+// write-in slot, and ensure the answers are correctly formatted. The write-ins
+// follow the choices and are encoded like Text answers.
+func (s Select) unmarshalAnswers(sforms []string) ([]bool, []string, error) {
+	expected := len(s.Choices) + int(s.WriteIns)
+
+	if len(sforms) != expected {
+		return nil, nil, fmt.Errorf("question %s has a wrong number of answers:"+
+			" expected %d got %d", s.ID, expected, len(sforms))
 	}
 
 	var selected uint = 0
 	results := make([]bool, 0)
 
-	for _, sform := range sforms {
+	for _, sform := range sforms[:len(s.Choices)] {
 		b, err := strconv.ParseBool(sform)
 
 		if err != nil {
-			return nil, fmt.Errorf("could not parse sform value for Q.%s: %v",
+			return nil, nil, fmt.Errorf("could not parse sform value for Q.%s: %v",
 				s.ID, err)
 		}
 
@@ -505,12 +789,34 @@ func (s Select) unmarshalAnswers(sforms []string) ([]bool, error) {
 		results = append(results, b)
 	}
 
+	writeIns := make([]string, 0, s.WriteIns)
+
+	for _, writeIn := range sforms[len(s.Choices):] {
+		writeInValue, err := base64.StdEncoding.DecodeString(writeIn)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not decode write-in for Q.%s: %v",
+				s.ID, err)
+		}
+
+		length := utf8.RuneCount(writeInValue)
+		if length > int(s.MaxWriteInLength) {
+			return nil, nil, fmt.Errorf("write-in for Q.%s is too long: %d > %d",
+				s.ID, length, s.MaxWriteInLength)
+		}
+
+		if len(writeInValue) > 0 {
+			selected++
+		}
+
+		writeIns = append(writeIns, string(writeInValue))
+	}
+
 	err := checkNumberOfAnswers(s.MaxN, s.MinN, selected, s.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check number of answers: %v", err)
+		return nil, nil, fmt.Errorf("failed to check number of answers: %v", err)
 	}
 
-	return results, nil
+	return results, writeIns, nil
 }
 
 // Rank describes a "rank" question, which requires the user to rank choices.
@@ -523,6 +829,10 @@ type Rank struct {
 	MinN    uint
 	Choices []Choice
 	Hint    Hint
+
+	// Majority is only supported on Select questions. It is decoded so that
+	// a configuration setting it is rejected instead of ignored.
+	Majority MajorityRule `json:",omitempty"`
 }
 
 func (r Rank) GetID() string {
@@ -598,6 +908,10 @@ type Text struct {
 	Regex     string
 	Choices   []Choice
 	Hint      Hint
+
+	// Majority is only supported on Select questions. It is decoded so that
+	// a configuration setting it is rejected instead of ignored.
+	Majority MajorityRule `json:",omitempty"`
 }
 
 func (t Text) GetID() string {
@@ -651,3 +965,166 @@ func (t Text) unmarshalAnswers(texts []string) ([]string, error) {
 
 	return results, nil
 }
+
+// Score describes a "score" question, which requires the user to give each
+// choice an integer score in [Min, Max]. implements Question
+type Score struct {
+	ID ID
+
+	Title   Title
+	MaxN    uint
+	MinN    uint
+	Min     uint
+	Max     uint
+	Choices []Choice
+	Hint    Hint
+}
+
+// GetID implements Question
+func (s Score) GetID() string {
+	return scoreID
+}
+
+// GetMaxN implements Question
+func (s Score) GetMaxN() uint {
+	return s.MaxN
+}
+
+// GetMinN implements Question
+func (s Score) GetMinN() uint {
+	return s.MinN
+}
+
+// GetChoicesLength implements Question
+func (s Score) GetChoicesLength() int {
+	return len(s.Choices)
+}
+
+// unmarshalAnswers interprets the given raw answers into a slice of integer
+// representing the score of each choice and ensures the answers are correctly
+// formatted
+func (s Score) unmarshalAnswers(scores []string) ([]int, error) {
+	if len(scores) != len(s.Choices) {
+		return nil, fmt.Errorf("question %s has a wrong number of answers:"+
+			" expected %d got %d", s.ID, len(s.Choices), len(scores))
+	}
+
+	var selected uint = 0
+	results := make([]int, 0, len(scores))
+
+	for _, score := range scores {
+		if len(score) <= 0 {
+			results = append(results, -1)
+			continue
+		}
+
+		selected++
+
+		scoreValue, err := strconv.ParseUint(score, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse score value for Q.%s: %v",
+				s.ID, err)
+		}
+
+		if uint(scoreValue) < s.Min || uint(scoreValue) > s.Max {
+			return nil, fmt.Errorf("invalid score not in range [%d, %d]: %d",
+				s.Min, s.Max, scoreValue)
+		}
+
+		results = append(results, int(scoreValue))
+	}
+
+	err := checkNumberOfAnswers(s.MaxN, s.MinN, selected, s.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check number of answers: %v", err)
+	}
+
+	return results, nil
+}
+
+// Cumulative describes a "cumulative" question, which lets the user distribute
+// at most Budget points among the choices, with at most MaxPerChoice points
+// for a single choice. MaxN and MinN bound the number of choices that receive
+// points. implements Question
+type Cumulative struct {
+	ID ID
+
+	Title        Title
+	MaxN         uint
+	MinN         uint
+	Budget       uint
+	MaxPerChoice uint
+	Choices      []Choice
+	Hint         Hint
+}
+
+// GetID implements Question
+func (c Cumulative) GetID() string {
+	return cumulativeID
+}
+
+// GetMaxN implements Question
+func (c Cumulative) GetMaxN() uint {
+	return c.MaxN
+}
+
+// GetMinN implements Question
+func (c Cumulative) GetMinN() uint {
+	return c.MinN
+}
+
+// GetChoicesLength implements Question
+func (c Cumulative) GetChoicesLength() int {
+	return len(c.Choices)
+}
+
+// unmarshalAnswers interprets the given raw answers into a slice with the
+// number of points given to each choice and ensures the answers respect the
+// budget and the per-choice cap
+func (c Cumulative) unmarshalAnswers(points []string) ([]uint, error) {
+	if len(points) != len(c.Choices) {
+		return nil, fmt.Errorf("question %s has a wrong number of answers:"+
+			" expected %d got %d", c.ID, len(c.Choices), len(points))
+	}
+
+	var selected uint = 0
+	var spent uint = 0
+	results := make([]uint, 0, len(points))
+
+	for _, point := range points {
+		if len(point) <= 0 {
+			results = append(results, 0)
+			continue
+		}
+
+		pointValue, err := strconv.ParseUint(point, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse points for Q.%s: %v",
+				c.ID, err)
+		}
+
+		if uint(pointValue) > c.MaxPerChoice {
+			return nil, fmt.Errorf("too many points for a single choice: %d > %d",
+				pointValue, c.MaxPerChoice)
+		}
+
+		if pointValue > 0 {
+			selected++
+		}
+
+		spent += uint(pointValue)
+		results = append(results, uint(pointValue))
+	}
+
+	if spent > c.Budget {
+		return nil, fmt.Errorf("question %s exceeds the budget: %d > %d",
+			c.ID, spent, c.Budget)
+	}
+
+	err := checkNumberOfAnswers(c.MaxN, c.MinN, selected, c.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check number of answers: %v", err)
+	}
+
+	return results, nil
+}
@@ -3,17 +3,24 @@ package types
 import (
 	"encoding/base64"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 )
 
 const (
-	selectIDTest       = "select:"
-	rankIDTest         = "rank:"
-	textIDTest         = "text:"
-	unmarshalingRankID = "could not unmarshal rank answers: "
-	unmarshalingTextID = "could not unmarshal text answers: "
+	selectIDTest         = "select:"
+	rankIDTest           = "rank:"
+	textIDTest           = "text:"
+	scoreIDTest          = "score:"
+	cumulativeIDTest     = "cumulative:"
+	unmarshalingSelectID = "could not unmarshal select answers: "
+	unmarshalingRankID   = "could not unmarshal rank answers: "
+	unmarshalingTextID   = "could not unmarshal text answers: "
+	unmarshalingScoreID  = "could not unmarshal score answers: "
+
+	unmarshalingCumulativeID = "could not unmarshal cumulative answers: "
 )
 
 // Creating a ballot for the first question, which is a select question.
@@ -301,6 +308,170 @@ func TestBallot_Unmarshal(t *testing.T) {
 	require.EqualError(t, err, "question type is unknown")
 }
 
+func TestBallot_UnmarshalScore(t *testing.T) {
+	form := Form{Configuration: Configuration{Scaffold: []Subject{{
+		Scores: []Score{{
+			ID:      decodedQuestionID(1),
+			MaxN:    3,
+			MinN:    2,
+			Min:     1,
+			Max:     10,
+			Choices: make([]Choice, 3),
+		}},
+		Ranks: []Rank{{
+			ID:      decodedQuestionID(2),
+			MaxN:    2,
+			MinN:    0,
+			Choices: make([]Choice, 2),
+		}},
+	}}}}
+
+	b := Ballot{}
+
+	err := b.Unmarshal(scoreIDTest+string(encodedQuestionID(1))+":1,,10\n\n", form)
+	require.NoError(t, err)
+
+	require.Equal(t, []ID{decodedQuestionID(1)}, b.ScoreResultIDs)
+	require.Equal(t, [][]int{{1, -1, 10}}, b.ScoreResult)
+
+	// with score out of range
+	err = b.Unmarshal(scoreIDTest+string(encodedQuestionID(1))+":0,5,5\n\n", form)
+	require.EqualError(t, err, unmarshalingScoreID+
+		"invalid score not in range [1, 10]: 0")
+	require.Nil(t, b.ScoreResult)
+
+	err = b.Unmarshal(scoreIDTest+string(encodedQuestionID(1))+":11,5,5\n\n", form)
+	require.EqualError(t, err, unmarshalingScoreID+
+		"invalid score not in range [1, 10]: 11")
+
+	// with wrong format answers
+	err = b.Unmarshal(scoreIDTest+string(encodedQuestionID(1))+":x,5,5\n\n", form)
+	require.EqualError(t, err, unmarshalingScoreID+
+		"could not parse score value for Q.Q1: strconv.ParseUint: parsing \"x\": invalid syntax")
+
+	// with wrong number of answers
+	err = b.Unmarshal(scoreIDTest+string(encodedQuestionID(1))+":5,5\n\n", form)
+	require.EqualError(t, err, unmarshalingScoreID+
+		"question Q1 has a wrong number of answers: expected 3 got 2")
+
+	// with not enough scored choices
+	err = b.Unmarshal(scoreIDTest+string(encodedQuestionID(1))+":5,,\n\n", form)
+	require.EqualError(t, err, unmarshalingScoreID+
+		"failed to check number of answers: question Q1 has not enough selected answers")
+
+	// with a score line targeting another type of question
+	err = b.Unmarshal(scoreIDTest+string(encodedQuestionID(2))+":1,1\n\n", form)
+	require.EqualError(t, err, "question Q2 is not a score question")
+}
+
+func TestBallot_UnmarshalCumulative(t *testing.T) {
+	form := Form{Configuration: Configuration{Scaffold: []Subject{{
+		Cumulatives: []Cumulative{{
+			ID:           decodedQuestionID(1),
+			MaxN:         3,
+			MinN:         1,
+			Budget:       5,
+			MaxPerChoice: 3,
+			Choices:      make([]Choice, 3),
+		}},
+		Ranks: []Rank{{
+			ID:      decodedQuestionID(2),
+			MaxN:    2,
+			MinN:    0,
+			Choices: make([]Choice, 2),
+		}},
+	}}}}
+
+	b := Ballot{}
+
+	err := b.Unmarshal(cumulativeIDTest+string(encodedQuestionID(1))+":3,,2\n\n", form)
+	require.NoError(t, err)
+
+	require.Equal(t, []ID{decodedQuestionID(1)}, b.CumulativeResultIDs)
+	require.Equal(t, [][]uint{{3, 0, 2}}, b.CumulativeResult)
+
+	// with too many points for a single choice
+	err = b.Unmarshal(cumulativeIDTest+string(encodedQuestionID(1))+":4,0,0\n\n", form)
+	require.EqualError(t, err, unmarshalingCumulativeID+
+		"too many points for a single choice: 4 > 3")
+	require.Nil(t, b.CumulativeResult)
+
+	// with more points than the budget
+	err = b.Unmarshal(cumulativeIDTest+string(encodedQuestionID(1))+":3,2,1\n\n", form)
+	require.EqualError(t, err, unmarshalingCumulativeID+
+		"question Q1 exceeds the budget: 6 > 5")
+
+	// with wrong format answers
+	err = b.Unmarshal(cumulativeIDTest+string(encodedQuestionID(1))+":x,1,1\n\n", form)
+	require.EqualError(t, err, unmarshalingCumulativeID+
+		"could not parse points for Q.Q1: strconv.ParseUint: parsing \"x\": invalid syntax")
+
+	// with wrong number of answers
+	err = b.Unmarshal(cumulativeIDTest+string(encodedQuestionID(1))+":1,1\n\n", form)
+	require.EqualError(t, err, unmarshalingCumulativeID+
+		"question Q1 has a wrong number of answers: expected 3 got 2")
+
+	// with no choice receiving points
+	err = b.Unmarshal(cumulativeIDTest+string(encodedQuestionID(1))+":0,,0\n\n", form)
+	require.EqualError(t, err, unmarshalingCumulativeID+
+		"failed to check number of answers: question Q1 has not enough selected answers")
+
+	// with a cumulative line targeting another type of question
+	err = b.Unmarshal(cumulativeIDTest+string(encodedQuestionID(2))+":1,1\n\n", form)
+	require.EqualError(t, err, "question Q2 is not a cumulative question")
+}
+
+func TestBallot_UnmarshalSelectWriteIns(t *testing.T) {
+	form := Form{Configuration: Configuration{Scaffold: []Subject{{
+		Selects: []Select{{
+			ID:               decodedQuestionID(1),
+			MaxN:             2,
+			MinN:             1,
+			Choices:          make([]Choice, 2),
+			WriteIns:         2,
+			MaxWriteInLength: 8,
+		}},
+	}}}}
+
+	jane := base64.StdEncoding.EncodeToString([]byte("Jane Doe"))
+
+	b := Ballot{}
+
+	err := b.Unmarshal(selectIDTest+string(encodedQuestionID(1))+":1,0,"+jane+",\n\n", form)
+	require.NoError(t, err)
+
+	require.Equal(t, []ID{decodedQuestionID(1)}, b.SelectResultIDs)
+	require.Equal(t, [][]bool{{true, false}}, b.SelectResult)
+	require.Equal(t, [][]string{{"Jane Doe", ""}}, b.SelectWriteIns)
+
+	// with only a write-in
+	err = b.Unmarshal(selectIDTest+string(encodedQuestionID(1))+":0,0,,"+jane+"\n\n", form)
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"", "Jane Doe"}}, b.SelectWriteIns)
+
+	// with write-ins counted alongside the choices
+	err = b.Unmarshal(selectIDTest+string(encodedQuestionID(1))+":1,1,"+jane+",\n\n", form)
+	require.EqualError(t, err, unmarshalingSelectID+
+		"failed to check number of answers: question Q1 has too many selected answers")
+	require.Nil(t, b.SelectWriteIns)
+
+	// with a too long write-in
+	tooLong := base64.StdEncoding.EncodeToString([]byte("Jane Doe Jr"))
+	err = b.Unmarshal(selectIDTest+string(encodedQuestionID(1))+":1,0,"+tooLong+",\n\n", form)
+	require.EqualError(t, err, unmarshalingSelectID+
+		"write-in for Q.Q1 is too long: 11 > 8")
+
+	// with a write-in that is not base64-encoded
+	err = b.Unmarshal(selectIDTest+string(encodedQuestionID(1))+":1,0,x,\n\n", form)
+	require.EqualError(t, err, unmarshalingSelectID+
+		"could not decode write-in for Q.Q1: illegal base64 data at input byte 0")
+
+	// without the write-in slots
+	err = b.Unmarshal(selectIDTest+string(encodedQuestionID(1))+":1,0\n\n", form)
+	require.EqualError(t, err, unmarshalingSelectID+
+		"question Q1 has a wrong number of answers: expected 4 got 2")
+}
+
 func TestSubject_MaxEncodedSize(t *testing.T) {
 	subject := Subject{
 		Subjects: []Subject{{
@@ -363,6 +534,53 @@ func TestSubject_MaxEncodedSize(t *testing.T) {
 
 	require.Equal(t, len(ballot2), size)
 	require.Equal(t, subject.MaxEncodedSize(), size)
+
+	scoreSubject := Subject{
+		Scores: []Score{{
+			ID:      decodedQuestionID(1),
+			MaxN:    3,
+			MinN:    0,
+			Min:     0,
+			Max:     10,
+			Choices: make([]Choice, 3),
+		}},
+	}
+
+	ballotScore := scoreIDTest + string(encodedQuestionID(1)) + ":10,10,10\n\n"
+
+	require.Equal(t, len(ballotScore), scoreSubject.MaxEncodedSize())
+
+	cumulativeSubject := Subject{
+		Cumulatives: []Cumulative{{
+			ID:           decodedQuestionID(1),
+			MaxN:         3,
+			MinN:         0,
+			Budget:       20,
+			MaxPerChoice: 10,
+			Choices:      make([]Choice, 3),
+		}},
+	}
+
+	ballotCumulative := cumulativeIDTest + string(encodedQuestionID(1)) + ":10,10,10\n\n"
+
+	require.Equal(t, len(ballotCumulative), cumulativeSubject.MaxEncodedSize())
+
+	writeInSubject := Subject{
+		Selects: []Select{{
+			ID:               decodedQuestionID(1),
+			MaxN:             3,
+			MinN:             0,
+			Choices:          make([]Choice, 2),
+			WriteIns:         1,
+			MaxWriteInLength: 3,
+		}},
+	}
+
+	// 3 characters of at most 4 bytes are at most 16 bytes once encoded
+	ballotWriteIn := selectIDTest + string(encodedQuestionID(1)) + ":1,1," +
+		strings.Repeat("x", 16) + "\n\n"
+
+	require.Equal(t, len(ballotWriteIn), writeInSubject.MaxEncodedSize())
 }
 
 func TestSubject_IsValid(t *testing.T) {
@@ -468,6 +686,136 @@ func TestSubject_IsValid(t *testing.T) {
 	valid = configuration.IsValid()
 	require.False(t, valid)
 
+	// with invalid Score question
+
+	invalidTexts := mainSubject.Texts
+	mainSubject.Texts = []Text{}
+	mainSubject.Scores = []Score{{
+		ID:      encodedQuestionID(4),
+		MaxN:    1,
+		MinN:    0,
+		Min:     5,
+		Max:     1,
+		Choices: make([]Choice, 1),
+	}}
+
+	configuration.Scaffold = []Subject{*mainSubject}
+
+	valid = configuration.IsValid()
+	require.False(t, valid)
+
+	mainSubject.Scores[0].Max = 10
+
+	configuration.Scaffold = []Subject{*mainSubject}
+
+	valid = configuration.IsValid()
+	require.True(t, valid)
+
+	mainSubject.Scores = []Score{}
+
+	// with invalid Cumulative question
+
+	mainSubject.Cumulatives = []Cumulative{{
+		ID:           encodedQuestionID(4),
+		MaxN:         1,
+		MinN:         0,
+		Budget:       2,
+		MaxPerChoice: 3,
+		Choices:      make([]Choice, 1),
+	}}
+
+	configuration.Scaffold = []Subject{*mainSubject}
+
+	valid = configuration.IsValid()
+	require.False(t, valid)
+
+	mainSubject.Cumulatives[0].MaxPerChoice = 0
+
+	configuration.Scaffold = []Subject{*mainSubject}
+
+	valid = configuration.IsValid()
+	require.False(t, valid)
+
+	mainSubject.Cumulatives[0].MaxPerChoice = 2
+
+	configuration.Scaffold = []Subject{*mainSubject}
+
+	valid = configuration.IsValid()
+	require.True(t, valid)
+
+	mainSubject.Cumulatives = []Cumulative{}
+
+	// with Select write-ins
+
+	mainSubject.Selects = []Select{{
+		ID:       encodedQuestionID(4),
+		MaxN:     2,
+		MinN:     0,
+		Choices:  make([]Choice, 1),
+		WriteIns: 1,
+	}}
+
+	configuration.Scaffold = []Subject{*mainSubject}
+
+	valid = configuration.IsValid()
+	require.False(t, valid)
+
+	mainSubject.Selects[0].MaxWriteInLength = 20
+
+	configuration.Scaffold = []Subject{*mainSubject}
+
+	valid = configuration.IsValid()
+	require.True(t, valid)
+
+	// with re-vote policies
+
+	configuration.RevotePolicy = "unknown"
+	valid = configuration.IsValid()
+	require.False(t, valid)
+
+	configuration.RevotePolicy = NoRevote
+	configuration.MaxRevotes = 1
+	valid = configuration.IsValid()
+	require.False(t, valid)
+
+	configuration.RevotePolicy = LastVoteWins
+	valid = configuration.IsValid()
+	require.True(t, valid)
+
+	configuration.RevotePolicy = ""
+	configuration.MaxRevotes = 0
+
+	// with quorum and majority rules
+
+	configuration.Quorum.MinTurnoutPercent = 101
+	valid = configuration.IsValid()
+	require.False(t, valid)
+
+	configuration.Quorum.MinTurnoutPercent = 50
+	valid = configuration.IsValid()
+	require.True(t, valid)
+
+	configuration.Scaffold[0].Selects[0].Majority = "unknown"
+	valid = configuration.IsValid()
+	require.False(t, valid)
+
+	configuration.Scaffold[0].Selects[0].Majority = TwoThirdsMajority
+	valid = configuration.IsValid()
+	require.True(t, valid)
+
+	configuration.Scaffold[0].Selects[0].Majority = ""
+	configuration.Quorum = Quorum{}
+
+	mainSubject.Selects[0].WriteIns = 0
+
+	configuration.Scaffold = []Subject{*mainSubject}
+
+	valid = configuration.IsValid()
+	require.False(t, valid)
+
+	mainSubject.Selects = []Select{}
+	mainSubject.Texts = invalidTexts
+
 	// with invalid sub subject
 
 	subSubject.Texts = mainSubject.Texts
@@ -495,6 +843,34 @@ func TestSubject_IsValid(t *testing.T) {
 	require.False(t, valid)
 }
 
+func TestConfiguration_CheckMajorities(t *testing.T) {
+	subSubject := Subject{
+		Selects: []Select{{ID: "s", Majority: AbsoluteMajority}},
+		Ranks:   []Rank{{ID: "r"}},
+		Texts:   []Text{{ID: "t"}},
+	}
+
+	configuration := Configuration{
+		Scaffold: []Subject{{Subjects: []Subject{subSubject}}},
+	}
+
+	require.NoError(t, configuration.CheckMajorities())
+
+	configuration.Scaffold[0].Subjects[0].Ranks[0].Majority = SimpleMajority
+	require.EqualError(t, configuration.CheckMajorities(),
+		"rank question r can't have a majority rule")
+
+	configuration.Scaffold[0].Subjects[0].Ranks[0].Majority = ""
+	configuration.Scaffold[0].Subjects[0].Texts[0].Majority = SimpleMajority
+	require.EqualError(t, configuration.CheckMajorities(),
+		"text question t can't have a majority rule")
+
+	configuration.Scaffold[0].Subjects[0].Texts[0].Majority = ""
+	configuration.Scaffold[0].Subjects[0].Selects[0].WriteIns = 1
+	require.EqualError(t, configuration.CheckMajorities(),
+		"select question s can't have both write-ins and a majority rule")
+}
+
 func TestBallot_Equal(t *testing.T) {
 	type check struct {
 		ballot    Ballot
@@ -680,6 +1056,60 @@ func TestBallot_Equal(t *testing.T) {
 			},
 			require.True,
 		},
+		{
+			Ballot{ScoreResultIDs: []ID{"1"}},
+			Ballot{ScoreResultIDs: []ID{"0"}},
+			require.False,
+		},
+		{
+			Ballot{
+				ScoreResultIDs: []ID{"1"},
+				ScoreResult:    [][]int{{1}},
+			},
+			Ballot{
+				ScoreResultIDs: []ID{"1"},
+				ScoreResult:    [][]int{{2}},
+			},
+			require.False,
+		},
+		{
+			Ballot{
+				ScoreResultIDs: []ID{"1"},
+				ScoreResult:    [][]int{{1}},
+			},
+			Ballot{
+				ScoreResultIDs: []ID{"1"},
+				ScoreResult:    [][]int{{1}},
+			},
+			require.True,
+		},
+		{
+			Ballot{CumulativeResultIDs: []ID{"1"}},
+			Ballot{CumulativeResultIDs: []ID{"0"}},
+			require.False,
+		},
+		{
+			Ballot{
+				CumulativeResultIDs: []ID{"1"},
+				CumulativeResult:    [][]uint{{1}},
+			},
+			Ballot{
+				CumulativeResultIDs: []ID{"1"},
+				CumulativeResult:    [][]uint{{2}},
+			},
+			require.False,
+		},
+		{
+			Ballot{
+				CumulativeResultIDs: []ID{"1"},
+				CumulativeResult:    [][]uint{{1}},
+			},
+			Ballot{
+				CumulativeResultIDs: []ID{"1"},
+				CumulativeResult:    [][]uint{{1}},
+			},
+			require.True,
+		},
 	}
 
 	for _, e := range table {
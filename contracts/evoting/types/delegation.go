@@ -0,0 +1,152 @@
+package types
+
+import "golang.org/x/xerrors"
+
+// Delegation records that a voter of the form delegated their vote to another
+// voter of the form, who casts the voter's ballot on their behalf.
+type Delegation struct {
+	// VoterID is the SCIPER of the voter who delegated their vote
+	VoterID int
+	// DelegateID is the SCIPER of the voter holding the delegation
+	DelegateID int
+}
+
+// GetDelegate returns the SCIPER of the voter holding the delegation of the
+// given voter, or -1 if the voter didn't delegate their vote.
+func (form *Form) GetDelegate(voterID string) (int, error) {
+	sciperInt, err := SciperToInt(voterID)
+	if err != nil {
+		return -1, xerrors.Errorf("failed to convert SCIPER to integer: %v", err)
+	}
+
+	for _, delegation := range form.Delegations {
+		if delegation.VoterID == sciperInt {
+			return delegation.DelegateID, nil
+		}
+	}
+
+	return -1, nil
+}
+
+// CountDelegations returns the number of delegations held by the given voter.
+func (form *Form) CountDelegations(delegateID string) (int, error) {
+	sciperInt, err := SciperToInt(delegateID)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to convert SCIPER to integer: %v", err)
+	}
+
+	count := 0
+
+	for _, delegation := range form.Delegations {
+		if delegation.DelegateID == sciperInt {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// AddDelegation delegates the vote of a voter to another voter of the form.
+// Delegations can't be chained, and a delegate holds at most
+// Configuration.MaxDelegations of them.
+func (form *Form) AddDelegation(voterID, delegateID string) error {
+	if form.Configuration.MaxDelegations == 0 {
+		return Reject(RejectInvalidDelegation, "the form doesn't allow delegations")
+	}
+
+	if voterID == delegateID {
+		return xerrors.Errorf("the voter %s can't delegate to themselves", voterID)
+	}
+
+	for _, userID := range []string{voterID, delegateID} {
+		index, err := form.GetVoterIndex(userID)
+		if err != nil {
+			return xerrors.Errorf("failed GetVoterIndex: %v", err)
+		}
+
+		if index < 0 {
+			return Reject(RejectNotVoter, "the user %s is not a voter of the form", userID)
+		}
+	}
+
+	delegate, err := form.GetDelegate(voterID)
+	if err != nil {
+		return xerrors.Errorf("failed GetDelegate: %v", err)
+	}
+
+	if delegate >= 0 {
+		return xerrors.Errorf("the voter %s already delegated their vote to %d",
+			voterID, delegate)
+	}
+
+	delegate, err = form.GetDelegate(delegateID)
+	if err != nil {
+		return xerrors.Errorf("failed GetDelegate: %v", err)
+	}
+
+	if delegate >= 0 {
+		return xerrors.Errorf("the voter %s delegated their vote and can't hold delegations",
+			delegateID)
+	}
+
+	held, err := form.CountDelegations(voterID)
+	if err != nil {
+		return xerrors.Errorf("failed CountDelegations: %v", err)
+	}
+
+	if held > 0 {
+		return xerrors.Errorf("the voter %s holds delegations and can't delegate their vote",
+			voterID)
+	}
+
+	held, err = form.CountDelegations(delegateID)
+	if err != nil {
+		return xerrors.Errorf("failed CountDelegations: %v", err)
+	}
+
+	if held >= int(form.Configuration.MaxDelegations) {
+		return xerrors.Errorf("the voter %s already holds the maximum of %d delegations",
+			delegateID, form.Configuration.MaxDelegations)
+	}
+
+	// both SCIPERs convert since GetVoterIndex succeeded
+	voterInt, _ := SciperToInt(voterID)
+	delegateInt, _ := SciperToInt(delegateID)
+
+	form.Delegations = append(form.Delegations, Delegation{
+		VoterID:    voterInt,
+		DelegateID: delegateInt,
+	})
+
+	return nil
+}
+
+// RevokeDelegation removes the delegation of the given voter.
+func (form *Form) RevokeDelegation(voterID string) error {
+	sciperInt, err := SciperToInt(voterID)
+	if err != nil {
+		return xerrors.Errorf("failed to convert SCIPER to integer: %v", err)
+	}
+
+	for i, delegation := range form.Delegations {
+		if delegation.VoterID == sciperInt {
+			form.Delegations = append(form.Delegations[:i], form.Delegations[i+1:]...)
+			return nil
+		}
+	}
+
+	return xerrors.Errorf("the voter %s didn't delegate their vote", voterID)
+}
+
+// removeDelegationsOf removes the delegations given or held by the voter.
+func (form *Form) removeDelegationsOf(sciper int) {
+	delegations := form.Delegations[:0]
+
+	for _, delegation := range form.Delegations {
+		if delegation.VoterID != sciper && delegation.DelegateID != sciper {
+			delegations = append(delegations, delegation)
+		}
+	}
+
+	form.Delegations = delegations
+}
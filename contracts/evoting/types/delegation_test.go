@@ -0,0 +1,62 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestForm_Delegations(t *testing.T) {
+	form := Form{Voters: []int{111111, 222222, 333333, 444444}}
+
+	err := form.AddDelegation("111111", "222222")
+	require.EqualError(t, err, "[INVALID_DELEGATION] the form doesn't allow delegations")
+
+	form.Configuration.MaxDelegations = 1
+
+	err = form.AddDelegation("111111", "111111")
+	require.EqualError(t, err, "the voter 111111 can't delegate to themselves")
+
+	err = form.AddDelegation("111111", "555555")
+	require.EqualError(t, err, "[NOT_VOTER] the user 555555 is not a voter of the form")
+
+	err = form.AddDelegation("111111", "222222")
+	require.NoError(t, err)
+
+	delegate, err := form.GetDelegate("111111")
+	require.NoError(t, err)
+	require.Equal(t, 222222, delegate)
+
+	err = form.AddDelegation("111111", "333333")
+	require.EqualError(t, err, "the voter 111111 already delegated their vote to 222222")
+
+	err = form.AddDelegation("333333", "222222")
+	require.EqualError(t, err, "the voter 222222 already holds the maximum of 1 delegations")
+
+	// delegations can't be chained
+	err = form.AddDelegation("222222", "333333")
+	require.EqualError(t, err, "the voter 222222 holds delegations and can't delegate their vote")
+
+	err = form.AddDelegation("333333", "111111")
+	require.EqualError(t, err, "the voter 111111 delegated their vote and can't hold delegations")
+
+	form.Configuration.MaxDelegations = 2
+
+	err = form.AddDelegation("333333", "222222")
+	require.NoError(t, err)
+
+	count, err := form.CountDelegations("222222")
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	err = form.RevokeDelegation("111111")
+	require.NoError(t, err)
+
+	err = form.RevokeDelegation("111111")
+	require.EqualError(t, err, "the voter 111111 didn't delegate their vote")
+
+	// removing a voter removes their delegations
+	err = form.RemoveVoter("222222")
+	require.NoError(t, err)
+	require.Empty(t, form.Delegations)
+}
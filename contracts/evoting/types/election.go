@@ -41,6 +41,8 @@ const (
 	ResultAvailable Status = 5
 	// Canceled is when the form has been canceled
 	Canceled Status = 6
+	// Suspended is when the form is open but voting is temporarily halted
+	Suspended Status = 7
 )
 
 // BallotsPerBlock to improve performance, so that (de)serializing only touches
@@ -84,9 +86,13 @@ type Form struct {
 	SuffragiaIDs [][]byte
 
 	// BallotCount is the total number of ballots cast, including double
-	// ballots.
+	// ballots and withdrawals.
 	BallotCount uint32
 
+	// LiveBallots is the number of voters with a ballot that has not been
+	// withdrawn, which is the number of ballots that will be shuffled.
+	LiveBallots uint32
+
 	// SuffragiaHashes holds a slice of hashes to all SuffragiaIDs.
 	// LG: not really sure if this is needed. In case a Form has also to be
 	// proven to be correct outside the nodes, the hashes are definitely
@@ -119,6 +125,69 @@ type Form struct {
 
 	// Store the list of SCIPER of user that are Voters on the form.
 	Voters []int
+
+	// CloseBlock is the index of the last block in which ballots are
+	// accepted, or 0 for no deadline. The form is still closed with the
+	// CLOSE_FORM command, and a reopening extends the deadline.
+	CloseBlock uint64
+
+	// Reopenings records each time the form was reopened after being closed.
+	Reopenings []Reopening
+
+	// ParentFormID is the hex-encoded ID of the form this form is a runoff
+	// of, or empty if it is not a runoff.
+	ParentFormID string
+
+	// RunoffFormIDs are the hex-encoded IDs of the runoffs of this form.
+	RunoffFormIDs []string
+
+	// TenantID is the tenant the form belongs to, or empty if it belongs to
+	// the whole deployment.
+	TenantID string
+
+	// Auditors are the SCIPERs of the users that can verify the tally of the
+	// form.
+	Auditors []int
+
+	// Registrars are the SCIPERs of the users that can manage the voters of
+	// the form.
+	Registrars []int
+
+	// Observers are the SCIPERs of the users that can read the live status
+	// of the form when it is private.
+	Observers []int
+
+	// Private tells if the live status of the form, its ballot counts and
+	// the users who voted, is only served to its owners and observers.
+	Private bool
+
+	// Delegations are the votes delegated between voters of the form.
+	Delegations []Delegation
+}
+
+// FormRole is a role on a form besides its owners and voters.
+type FormRole string
+
+const (
+	// RoleAuditor can verify the tally of the form
+	RoleAuditor FormRole = "auditor"
+	// RoleRegistrar can manage the voters of the form
+	RoleRegistrar FormRole = "registrar"
+	// RoleObserver can read the live status of the form when it is private
+	RoleObserver FormRole = "observer"
+)
+
+// Reopening records who reopened a form, why, and the deadline that was set.
+type Reopening struct {
+	UserID     string
+	Reason     string
+	CloseBlock uint64
+}
+
+// IsPastDeadline returns true if the form no longer accepts ballots at the
+// given block index.
+func (form *Form) IsPastDeadline(height uint64) bool {
+	return form.CloseBlock != 0 && height > form.CloseBlock
 }
 
 // Serialize implements serde.Message
@@ -182,7 +251,7 @@ func FormFromStore(ctx serde.Context, formFac serde.Factory, formIDHex string,
 		return form, xerrors.Errorf("while getting data for form: %v", err)
 	}
 	if len(formBuff) == 0 {
-		return form, xerrors.Errorf("no form found")
+		return form, Reject(RejectFormNotFound, "no form found")
 	}
 
 	message, err := formFac.Deserialize(ctx, formBuff)
@@ -198,18 +267,147 @@ func FormFromStore(ctx serde.Context, formFac serde.Factory, formIDHex string,
 	return form, nil
 }
 
+// ChunkSize is the maximum number of bytes of a ballot encrypted in one chunk
+// of El Gamal pairs.
+const ChunkSize = 29
+
 // ChunksPerBallot returns the number of chunks of El Gamal pairs needed to
-// represent an encrypted ballot, knowing that one chunk is 29 bytes at most.
+// represent an encrypted ballot, knowing that one chunk is ChunkSize bytes at
+// most.
 func (form *Form) ChunksPerBallot() int {
-	if form.BallotSize%29 == 0 {
-		return form.BallotSize / 29
+	if form.BallotSize%ChunkSize == 0 {
+		return form.BallotSize / ChunkSize
 	}
 
-	return form.BallotSize/29 + 1
+	return form.BallotSize/ChunkSize + 1
 }
 
 // CastVote stores the new vote in the memory.
 func (form *Form) CastVote(ctx serde.Context, st store.Snapshot, userID string, ciphervote Ciphervote) error {
+	err := form.updateVoterRecord(ctx, st, userID, func(record *VoterRecord) {
+		if !record.Live {
+			form.LiveBallots++
+		}
+
+		record.Ballots++
+		record.Live = true
+	})
+	if err != nil {
+		return xerrors.Errorf("couldn't update voter record: %v", err)
+	}
+
+	return form.updateSuffragia(ctx, st, func(suff *Suffragia) {
+		// all the ballots are kept in the blocks, the re-vote policy is applied
+		// when reading the suffragia
+		suff.AddVote(userID, ciphervote)
+		if TestCastBallots {
+			for i := uint32(1); i < BallotsPerBlock; i++ {
+				suff.CastVote(fmt.Sprintf("%s-%d", userID, i), ciphervote)
+			}
+			form.BallotCount += BallotsPerBlock - 1
+			form.LiveBallots += BallotsPerBlock - 1
+		}
+	})
+}
+
+// WithdrawVote stores a tombstone that withdraws the previous ballots of the
+// user.
+func (form *Form) WithdrawVote(ctx serde.Context, st store.Snapshot, userID string) error {
+	err := form.updateVoterRecord(ctx, st, userID, func(record *VoterRecord) {
+		if record.Live {
+			form.LiveBallots--
+		}
+
+		record.Live = false
+	})
+	if err != nil {
+		return xerrors.Errorf("couldn't update voter record: %v", err)
+	}
+
+	return form.updateSuffragia(ctx, st, func(suff *Suffragia) {
+		suff.AddTombstone(userID)
+	})
+}
+
+// VoterRecord returns the record of the ballots of the user, which is empty if
+// the user has not voted.
+func (form *Form) VoterRecord(ctx serde.Context, rd store.Readable, userID string) (VoterRecord, error) {
+	formIDBuf, err := hex.DecodeString(form.FormID)
+	if err != nil {
+		return VoterRecord{}, xerrors.Errorf("couldn't decode formID: %v", err)
+	}
+
+	return voterRecordFromStore(ctx, rd, VoterRecordKey(formIDBuf, userID))
+}
+
+// DeleteVoterRecords deletes the records of the users who cast a ballot,
+// found in the blocks of ballots.
+func (form *Form) DeleteVoterRecords(ctx serde.Context, st store.Snapshot) error {
+	formIDBuf, err := hex.DecodeString(form.FormID)
+	if err != nil {
+		return xerrors.Errorf("couldn't decode formID: %v", err)
+	}
+
+	deleted := make(map[string]bool)
+
+	for _, id := range form.SuffragiaIDs {
+		suff, err := form.suffragiaBlock(ctx, st, id)
+		if err != nil {
+			return err
+		}
+
+		for _, uid := range suff.VoterIDs {
+			if deleted[uid] {
+				continue
+			}
+
+			err = st.Delete(VoterRecordKey(formIDBuf, uid))
+			if err != nil {
+				return xerrors.Errorf("couldn't delete voter record: %v", err)
+			}
+
+			deleted[uid] = true
+		}
+	}
+
+	return nil
+}
+
+// updateVoterRecord applies the update on the record of the user and stores
+// it.
+func (form *Form) updateVoterRecord(ctx serde.Context, st store.Snapshot, userID string,
+	update func(*VoterRecord)) error {
+
+	formIDBuf, err := hex.DecodeString(form.FormID)
+	if err != nil {
+		return xerrors.Errorf("couldn't decode formID: %v", err)
+	}
+
+	key := VoterRecordKey(formIDBuf, userID)
+
+	record, err := voterRecordFromStore(ctx, st, key)
+	if err != nil {
+		return err
+	}
+
+	update(&record)
+
+	buf, err := record.Serialize(ctx)
+	if err != nil {
+		return xerrors.Errorf("couldn't serialize voter record: %v", err)
+	}
+
+	err = st.Set(key, buf)
+	if err != nil {
+		return xerrors.Errorf("couldn't store voter record: %v", err)
+	}
+
+	return nil
+}
+
+// updateSuffragia applies the update on the current block of ballots, or on a
+// new block if the current one is full, and stores it.
+func (form *Form) updateSuffragia(ctx serde.Context, st store.Snapshot, update func(*Suffragia)) error {
 	var suff Suffragia
 	var blockID []byte
 	if form.BallotCount%BallotsPerBlock == 0 {
@@ -245,20 +443,15 @@ func (form *Form) CastVote(ctx serde.Context, st store.Snapshot, userID string,
 		suff = msg.(Suffragia)
 	}
 
-	suff.CastVote(userID, ciphervote)
-	if TestCastBallots {
-		for i := uint32(1); i < BallotsPerBlock; i++ {
-			suff.CastVote(fmt.Sprintf("%s-%d", userID, i), ciphervote)
-		}
-		form.BallotCount += BallotsPerBlock - 1
-	}
+	update(&suff)
+
 	buf, err := suff.Serialize(ctx)
 	if err != nil {
 		return xerrors.Errorf("couldn't marshal ballots block: %v", err)
 	}
 	err = st.Set(blockID, buf)
 	if err != nil {
-		xerrors.Errorf("couldn't set new ballots block: %v", err)
+		return xerrors.Errorf("couldn't set new ballots block: %v", err)
 	}
 	form.BallotCount += 1
 	return nil
@@ -266,29 +459,46 @@ func (form *Form) CastVote(ctx serde.Context, st store.Snapshot, userID string,
 
 // Suffragia returns all ballots from the storage. This should only
 // be called rarely, as it might take a long time.
-// It overwrites ballots cast by the same user and keeps only
-// the latest ballot.
+// It keeps only one ballot per user, depending on the re-vote policy: the
+// latest ballot, or the first one with FirstVoteOnly. A tombstone removes the
+// ballot cast before it by the same user.
 func (form *Form) Suffragia(ctx serde.Context, rd store.Readable) (Suffragia, error) {
 	var suff Suffragia
+	firstVoteOnly := form.Configuration.GetRevotePolicy() == FirstVoteOnly
+
 	for _, id := range form.SuffragiaIDs {
-		buf, err := rd.Get(id)
+		suffTmp, err := form.suffragiaBlock(ctx, rd, id)
 		if err != nil {
-			return suff, xerrors.Errorf("couldn't get ballot block: %v", err)
+			return suff, err
 		}
-		format := suffragiaFormat.Get(ctx.GetFormat())
-		ctx = serde.WithFactory(ctx, CiphervoteKey{}, CiphervoteFactory{})
-		msg, err := format.Decode(ctx, buf)
-		if err != nil {
-			return suff, xerrors.Errorf("couldn't unmarshal ballots block in cast: %v", err)
-		}
-		suffTmp := msg.(Suffragia)
 		for i, uid := range suffTmp.VoterIDs {
-			suff.CastVote(uid, suffTmp.Ciphervotes[i])
+			if suffTmp.IsTombstone(i) {
+				suff.RemoveVote(uid)
+			} else if firstVoteOnly {
+				suff.CastFirstVote(uid, suffTmp.Ciphervotes[i])
+			} else {
+				suff.CastVote(uid, suffTmp.Ciphervotes[i])
+			}
 		}
 	}
 	return suff, nil
 }
 
+// suffragiaBlock reads the block of ballots stored under the given ID.
+func (form *Form) suffragiaBlock(ctx serde.Context, rd store.Readable, id []byte) (Suffragia, error) {
+	buf, err := rd.Get(id)
+	if err != nil {
+		return Suffragia{}, xerrors.Errorf("couldn't get ballot block: %v", err)
+	}
+	format := suffragiaFormat.Get(ctx.GetFormat())
+	ctx = serde.WithFactory(ctx, CiphervoteKey{}, CiphervoteFactory{})
+	msg, err := format.Decode(ctx, buf)
+	if err != nil {
+		return Suffragia{}, xerrors.Errorf("couldn't unmarshal ballots block in cast: %v", err)
+	}
+	return msg.(Suffragia), nil
+}
+
 // RandomVector is a slice of kyber.Scalar (encoded) which is used to prove
 // and verify the proof of a shuffle
 type RandomVector [][]byte
@@ -343,6 +553,64 @@ type Configuration struct {
 	Title          Title
 	Scaffold       []Subject
 	AdditionalInfo string
+
+	// RevotePolicy defines which ballot is kept when a voter casts several
+	// ballots. An empty policy is equivalent to LastVoteWins.
+	RevotePolicy RevotePolicy
+
+	// MaxRevotes caps the number of times a voter can change their ballot
+	// with the LastVoteWins policy. 0 means no cap.
+	MaxRevotes uint
+
+	// Quorum defines the minimum turnout for the result to be valid
+	Quorum Quorum
+
+	// MaxDelegations is the number of delegations a voter can hold. 0 means
+	// voters can't delegate their vote.
+	MaxDelegations uint
+}
+
+// Quorum defines the minimum turnout of a form. A zero value means no
+// minimum. When both are set, both must be reached.
+type Quorum struct {
+	// MinTurnoutPercent is the minimum percentage of the form's voters that
+	// must cast a ballot
+	MinTurnoutPercent uint
+	// MinBallots is the minimum number of ballots that must be cast
+	MinBallots uint
+}
+
+// IsReached returns true if the given number of ballots, cast by the given
+// number of voters, reaches the quorum.
+func (quorum Quorum) IsReached(ballots int, voters int) bool {
+	if ballots < int(quorum.MinBallots) {
+		return false
+	}
+
+	return ballots*100 >= int(quorum.MinTurnoutPercent)*voters
+}
+
+// RevotePolicy defines how the ballots cast by the same voter are handled
+type RevotePolicy string
+
+const (
+	// LastVoteWins keeps the latest ballot of each voter
+	LastVoteWins RevotePolicy = "last-vote-wins"
+	// FirstVoteOnly keeps the first ballot of each voter and rejects the
+	// following ones until it is withdrawn
+	FirstVoteOnly RevotePolicy = "first-vote-only"
+	// NoRevote rejects any ballot from a voter that already voted
+	NoRevote RevotePolicy = "no-revote"
+)
+
+// GetRevotePolicy returns the re-vote policy of the configuration, with the
+// default policy if none is set.
+func (configuration *Configuration) GetRevotePolicy() RevotePolicy {
+	if configuration.RevotePolicy == "" {
+		return LastVoteWins
+	}
+
+	return configuration.RevotePolicy
 }
 
 // MaxBallotSize returns the maximum number of bytes required to store a ballot
@@ -368,9 +636,36 @@ func (configuration *Configuration) GetQuestion(ID ID) Question {
 	return nil
 }
 
+// CheckMajorities returns an error if a majority rule is set on a question
+// that has no outcome, as only Select questions without write-ins have one.
+func (configuration *Configuration) CheckMajorities() error {
+	for _, subject := range configuration.Scaffold {
+		err := subject.checkMajorities()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // IsValid returns true if and only if the whole configuration is coherent and
 // valid.
 func (configuration *Configuration) IsValid() bool {
+	if configuration.Quorum.MinTurnoutPercent > 100 {
+		return false
+	}
+
+	switch configuration.GetRevotePolicy() {
+	case LastVoteWins:
+	case FirstVoteOnly, NoRevote:
+		if configuration.MaxRevotes > 0 {
+			return false
+		}
+	default:
+		return false
+	}
+
 	// serves as a set to check each ID is unique
 	uniqueIDs := make(map[ID]bool)
 
@@ -456,7 +751,11 @@ func (form *Form) RemoveVoter(userID string) error {
 		return xerrors.Errorf("Error while retrieving the index of the element.")
 	}
 
+	sciperInt := form.Voters[index]
 	form.Voters = append(form.Voters[:index], form.Voters[index+1:]...)
+
+	// delegations only hold between voters of the form
+	form.removeDelegationsOf(sciperInt)
 	return nil
 }
 
@@ -509,6 +808,95 @@ func (form *Form) RemoveOwner(userID string) error {
 	return nil
 }
 
+// roleMembers returns the members of the role on the form.
+func (form *Form) roleMembers(role FormRole) (*[]int, error) {
+	switch role {
+	case RoleAuditor:
+		return &form.Auditors, nil
+	case RoleRegistrar:
+		return &form.Registrars, nil
+	case RoleObserver:
+		return &form.Observers, nil
+	default:
+		return nil, xerrors.Errorf("unknown role: %q", role)
+	}
+}
+
+// GetRoleIndex return the index of the user in the members of the role if the
+// user has it, else return -1
+func (form *Form) GetRoleIndex(role FormRole, userID string) (int, error) {
+	members, err := form.roleMembers(role)
+	if err != nil {
+		return -1, err
+	}
+
+	sciperInt, err := SciperToInt(userID)
+	if err != nil {
+		return -1, xerrors.Errorf("failed to convert SCIPER to integer: %v", err)
+	}
+
+	for i, member := range *members {
+		if member == sciperInt {
+			return i, nil
+		}
+	}
+
+	return -1, nil
+}
+
+// AddRoleMember gives the role to the user on the form.
+func (form *Form) AddRoleMember(role FormRole, userID string) error {
+	index, err := form.GetRoleIndex(role, userID)
+	if err != nil {
+		return xerrors.Errorf("failed GetRoleIndex: %v", err)
+	}
+
+	if index >= 0 {
+		return xerrors.Errorf("the user %s already has the role %s", userID, role)
+	}
+
+	// the role is valid and the SCIPER converts since GetRoleIndex succeeded
+	members, _ := form.roleMembers(role)
+	sciperInt, _ := SciperToInt(userID)
+
+	*members = append(*members, sciperInt)
+
+	return nil
+}
+
+// RemoveRoleMember removes the role from the user on the form.
+func (form *Form) RemoveRoleMember(role FormRole, userID string) error {
+	index, err := form.GetRoleIndex(role, userID)
+	if err != nil {
+		return xerrors.Errorf("failed GetRoleIndex: %v", err)
+	}
+
+	if index < 0 {
+		return xerrors.Errorf("the user %s doesn't have the role %s", userID, role)
+	}
+
+	members, _ := form.roleMembers(role)
+	*members = append((*members)[:index], (*members)[index+1:]...)
+
+	return nil
+}
+
+// CanReadLiveStatus tells if the user can read the live status of the form.
+// Anyone can read the one of a public form, only the owners and the observers
+// the one of a private form.
+func (form *Form) CanReadLiveStatus(userID string) (bool, error) {
+	if !form.Private {
+		return true, nil
+	}
+
+	sciperInt, err := SciperToInt(userID)
+	if err != nil {
+		return false, xerrors.Errorf("failed to convert SCIPER to integer: %v", err)
+	}
+
+	return containsSciper(form.Owners, sciperInt) || containsSciper(form.Observers, sciperInt), nil
+}
+
 func SciperToInt(userID string) (int, error) {
 	sciperInt, err := strconv.Atoi(userID)
 	if err != nil {
@@ -521,3 +909,14 @@ func SciperToInt(userID string) (int, error) {
 
 	return sciperInt, nil
 }
+
+// containsSciper returns true if the SCIPER is in the list
+func containsSciper(scipers []int, sciper int) bool {
+	for _, s := range scipers {
+		if s == sciper {
+			return true
+		}
+	}
+
+	return false
+}
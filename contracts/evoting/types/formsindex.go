@@ -0,0 +1,287 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"strconv"
+
+	"go.dedis.ch/dela/core/store"
+	"golang.org/x/xerrors"
+)
+
+// indexSuffix is appended to the form ID to compute the key of its index
+// entry
+const indexSuffix = "index"
+
+// userFormsSuffix is appended to the index and the SCIPER of a user to compute
+// the key of the forms of the user
+const userFormsSuffix = "forms"
+
+// FormIndexEntry is a compact record of the state of a form, used to list and
+// sort the forms and polled by the clients without reading each form and its
+// shuffles. It is stored in JSON at the index key of the form and updated when
+// one of its fields changes.
+type FormIndexEntry struct {
+	FormID     string
+	Title      Title
+	Status     Status
+	OwnerCount int
+	// Private tells if the ballot counts are only served to the owners and
+	// observers of the form
+	Private bool
+
+	VoterCount       int
+	BallotCount      uint32
+	LiveBallots      uint32
+	ShuffleRounds    int
+	ShuffleThreshold int
+	PubsharesUnits   int
+	ResultCount      int
+
+	// Pubkey is the hex-encoded public key of the form, once it is opened
+	Pubkey        string
+	TenantID      string
+	ParentFormID  string
+	RunoffFormIDs []string
+
+	// Version counts the changes of the entry. The contract has no clock, so
+	// CreatedBlock and UpdatedBlock are the indexes of the blocks that created
+	// the entry and last changed it.
+	Version      uint64
+	CreatedBlock uint64
+	UpdatedBlock uint64
+}
+
+// NewFormIndexEntry returns the index entry of the form, without its version
+// and blocks.
+func NewFormIndexEntry(form Form) (FormIndexEntry, error) {
+	entry := FormIndexEntry{
+		FormID:           form.FormID,
+		Title:            form.Configuration.Title,
+		Status:           form.Status,
+		OwnerCount:       len(form.Owners),
+		Private:          form.Private,
+		VoterCount:       len(form.Voters),
+		BallotCount:      form.BallotCount,
+		LiveBallots:      form.LiveBallots,
+		ShuffleRounds:    len(form.ShuffleInstances),
+		ShuffleThreshold: form.ShuffleThreshold,
+		PubsharesUnits:   len(form.PubsharesUnits.Pubshares),
+		ResultCount:      len(form.DecryptedBallots),
+		TenantID:         form.TenantID,
+		ParentFormID:     form.ParentFormID,
+	}
+
+	if form.Pubkey != nil {
+		pubkeyBuf, err := form.Pubkey.MarshalBinary()
+		if err != nil {
+			return entry, xerrors.Errorf("failed to marshal pubkey: %v", err)
+		}
+
+		entry.Pubkey = hex.EncodeToString(pubkeyBuf)
+	}
+
+	// an entry without runoffs has none, whether it is read from the store or
+	// computed from a form
+	if len(form.RunoffFormIDs) > 0 {
+		entry.RunoffFormIDs = append([]string{}, form.RunoffFormIDs...)
+	}
+
+	return entry, nil
+}
+
+// Update returns the entry of the form after the change made in the block, and
+// false if the form changed none of the fields of the previous entry.
+func (entry FormIndexEntry) Update(form Form, block uint64) (FormIndexEntry, bool, error) {
+	next, err := NewFormIndexEntry(form)
+	if err != nil {
+		return entry, false, xerrors.Errorf("failed to create index entry: %v", err)
+	}
+
+	next.Version = entry.Version
+	next.CreatedBlock = entry.CreatedBlock
+	next.UpdatedBlock = entry.UpdatedBlock
+
+	if reflect.DeepEqual(next, entry) {
+		return entry, false, nil
+	}
+
+	if next.Version == 0 {
+		next.CreatedBlock = block
+	}
+
+	next.Version++
+	next.UpdatedBlock = block
+
+	return next, true, nil
+}
+
+// FormIndexKey returns the key at which the index entry of the form is
+// stored.
+func FormIndexKey(formIDBuf []byte) []byte {
+	h := sha256.New()
+	h.Write(formIDBuf)
+	h.Write([]byte(indexSuffix))
+
+	return h.Sum(nil)
+}
+
+// FormIndexEntryFromStore returns the index entry of the form from the store,
+// and false if the form has none, such as forms created before the index.
+func FormIndexEntryFromStore(formIDHex string, store store.Readable) (FormIndexEntry, bool, error) {
+	var entry FormIndexEntry
+
+	formIDBuf, err := hex.DecodeString(formIDHex)
+	if err != nil {
+		return entry, false, xerrors.Errorf("failed to decode formIDHex: %v", err)
+	}
+
+	entryBuf, err := store.Get(FormIndexKey(formIDBuf))
+	if err != nil {
+		return entry, false, xerrors.Errorf("while getting data for index entry: %v", err)
+	}
+
+	if len(entryBuf) == 0 {
+		return entry, false, nil
+	}
+
+	err = json.Unmarshal(entryBuf, &entry)
+	if err != nil {
+		return entry, false, xerrors.Errorf("failed to unmarshal index entry: %v", err)
+	}
+
+	return entry, true, nil
+}
+
+// UserForms is an index of the forms by user, kept by the contract for the
+// users with a role on the forms, so that the forms of a user are listed
+// without reading every form. The IDs of the forms of a user are stored in
+// JSON at the key of the user.
+type UserForms string
+
+const (
+	// OwnerForms indexes the forms by owner
+	OwnerForms UserForms = "owner"
+	// VoterForms indexes the forms by voter
+	VoterForms UserForms = "voter"
+)
+
+// Key returns the key at which the forms of the user are stored.
+func (index UserForms) Key(sciper int) []byte {
+	h := sha256.New()
+	h.Write([]byte(index))
+	h.Write([]byte(strconv.Itoa(sciper)))
+	h.Write([]byte(userFormsSuffix))
+
+	return h.Sum(nil)
+}
+
+// FromStore returns the hex-encoded IDs of the forms of the user, in the order
+// they were added.
+func (index UserForms) FromStore(sciper int, store store.Readable) ([]string, error) {
+	formsBuf, err := store.Get(index.Key(sciper))
+	if err != nil {
+		return nil, xerrors.Errorf("while getting data for %s forms: %v", index, err)
+	}
+
+	if len(formsBuf) == 0 {
+		return nil, nil
+	}
+
+	var formIDs []string
+
+	err = json.Unmarshal(formsBuf, &formIDs)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to unmarshal %s forms: %v", index, err)
+	}
+
+	return formIDs, nil
+}
+
+// Add adds the form to the forms of the users.
+func (index UserForms) Add(snap store.Snapshot, formID string, scipers ...int) error {
+	for _, sciper := range scipers {
+		formIDs, err := index.FromStore(sciper, snap)
+		if err != nil {
+			return err
+		}
+
+		if containsString(formIDs, formID) {
+			continue
+		}
+
+		err = index.set(snap, sciper, append(formIDs, formID))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Remove removes the form from the forms of the users.
+func (index UserForms) Remove(snap store.Snapshot, formID string, scipers ...int) error {
+	for _, sciper := range scipers {
+		formIDs, err := index.FromStore(sciper, snap)
+		if err != nil {
+			return err
+		}
+
+		kept := make([]string, 0, len(formIDs))
+
+		for _, id := range formIDs {
+			if id != formID {
+				kept = append(kept, id)
+			}
+		}
+
+		if len(kept) == len(formIDs) {
+			continue
+		}
+
+		err = index.set(snap, sciper, kept)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// set stores the forms of the user, or deletes the key of a user without
+// forms.
+func (index UserForms) set(snap store.Snapshot, sciper int, formIDs []string) error {
+	if len(formIDs) == 0 {
+		err := snap.Delete(index.Key(sciper))
+		if err != nil {
+			return xerrors.Errorf("failed to delete %s forms: %v", index, err)
+		}
+
+		return nil
+	}
+
+	formsBuf, err := json.Marshal(formIDs)
+	if err != nil {
+		return xerrors.Errorf("failed to marshal %s forms: %v", index, err)
+	}
+
+	err = snap.Set(index.Key(sciper), formsBuf)
+	if err != nil {
+		return xerrors.Errorf("failed to set %s forms: %v", index, err)
+	}
+
+	return nil
+}
+
+// containsString returns true if the string is in the list
+func containsString(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,99 @@
+package types
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/dela/testing/fake"
+)
+
+func TestFormIndexEntry_Update(t *testing.T) {
+	form := Form{
+		FormID:           "abcd",
+		Configuration:    Configuration{Title: Title{En: "title"}},
+		Status:           Open,
+		Owners:           []int{123456},
+		Voters:           []int{123456, 234567},
+		BallotCount:      3,
+		LiveBallots:      2,
+		ShuffleInstances: make([]ShuffleInstance, 2),
+		ShuffleThreshold: 2,
+		TenantID:         "tenant",
+	}
+
+	entry, changed, err := FormIndexEntry{}.Update(form, 10)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Equal(t, FormIndexEntry{
+		FormID:           "abcd",
+		Title:            Title{En: "title"},
+		Status:           Open,
+		OwnerCount:       1,
+		VoterCount:       2,
+		BallotCount:      3,
+		LiveBallots:      2,
+		ShuffleRounds:    2,
+		ShuffleThreshold: 2,
+		TenantID:         "tenant",
+		Version:          1,
+		CreatedBlock:     10,
+		UpdatedBlock:     10,
+	}, entry)
+
+	_, changed, err = entry.Update(form, 11)
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	form.Status = Closed
+
+	entry, changed, err = entry.Update(form, 12)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Equal(t, Closed, entry.Status)
+	require.Equal(t, uint64(2), entry.Version)
+	require.Equal(t, uint64(10), entry.CreatedBlock)
+	require.Equal(t, uint64(12), entry.UpdatedBlock)
+
+	form.Pubkey = suite.Point().Base()
+	form.RunoffFormIDs = []string{"ef01"}
+
+	entry, changed, err = entry.Update(form, 13)
+	require.NoError(t, err)
+	require.True(t, changed)
+	pubkeyBuf, err := form.Pubkey.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, hex.EncodeToString(pubkeyBuf), entry.Pubkey)
+	require.Equal(t, []string{"ef01"}, entry.RunoffFormIDs)
+}
+
+func TestUserForms(t *testing.T) {
+	snap := fake.NewSnapshot()
+
+	require.NoError(t, OwnerForms.Add(snap, "ab", 123456, 234567))
+	require.NoError(t, OwnerForms.Add(snap, "cd", 123456))
+	require.NoError(t, OwnerForms.Add(snap, "ab", 123456))
+
+	formIDs, err := OwnerForms.FromStore(123456, snap)
+	require.NoError(t, err)
+	require.Equal(t, []string{"ab", "cd"}, formIDs)
+
+	// the indexes of the roles are apart
+	formIDs, err = VoterForms.FromStore(123456, snap)
+	require.NoError(t, err)
+	require.Empty(t, formIDs)
+
+	require.NoError(t, OwnerForms.Remove(snap, "ab", 123456, 234567))
+
+	formIDs, err = OwnerForms.FromStore(123456, snap)
+	require.NoError(t, err)
+	require.Equal(t, []string{"cd"}, formIDs)
+
+	// the key of a user without forms is deleted
+	formsBuf, err := snap.Get(OwnerForms.Key(234567))
+	require.NoError(t, err)
+	require.Empty(t, formsBuf)
+
+	_, err = OwnerForms.FromStore(123456, fake.NewBadSnapshot())
+	require.ErrorContains(t, err, "while getting data for owner forms")
+}
@@ -0,0 +1,249 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+
+	"go.dedis.ch/dela/core/store"
+	"go.dedis.ch/dela/serde"
+	"go.dedis.ch/dela/serde/registry"
+	"golang.org/x/xerrors"
+)
+
+// historySuffix is appended to the form ID to compute the key of its history
+const historySuffix = "history"
+
+// countParam is the parameter of an event that counts merged commands
+const countParam = "Count"
+
+var formEventFormat = registry.NewSimpleRegistry()
+
+// RegisterFormEventFormat registers the engine for the provided format
+func RegisterFormEventFormat(format serde.Format, engine serde.FormatEngine) {
+	formEventFormat.Register(format, engine)
+}
+
+// FormHistory contains the events of a form, in the order they happened.
+// Each event is stored at its own key, and the history key of the form holds
+// the number of events, so that recording an event doesn't rewrite the
+// previous ones.
+type FormHistory struct {
+	Events []FormEvent
+}
+
+// FormEvent records a command that changed the state of a form.
+//
+// - implements serde.Message
+type FormEvent struct {
+	Command string
+
+	// UserID is the user that performed the command. It is empty for counted
+	// commands such as cast votes.
+	UserID string `json:",omitempty"`
+
+	// BlockIndex is the index of the block that includes the command.
+	BlockIndex uint64
+
+	// Params contains the parameters of the command, such as the user that
+	// was added or removed.
+	Params map[string]string `json:",omitempty"`
+}
+
+// Serialize implements serde.Message
+func (event FormEvent) Serialize(ctx serde.Context) ([]byte, error) {
+	format := formEventFormat.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, event)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode form event: %v", err)
+	}
+
+	return data, nil
+}
+
+// HistoryKey returns the key at which the number of events of the form is
+// stored.
+func HistoryKey(formIDBuf []byte) []byte {
+	h := sha256.New()
+	h.Write(formIDBuf)
+	h.Write([]byte(historySuffix))
+
+	return h.Sum(nil)
+}
+
+// HistoryEventKey returns the key at which the event of the form with the
+// given index is stored.
+func HistoryEventKey(formIDBuf []byte, index uint64) []byte {
+	indexBuf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(indexBuf, index)
+
+	h := sha256.New()
+	h.Write(formIDBuf)
+	h.Write([]byte(historySuffix))
+	h.Write(indexBuf)
+
+	return h.Sum(nil)
+}
+
+// HistoryFromStore returns the history of the form from the store. The
+// history is empty if the form has none.
+func HistoryFromStore(ctx serde.Context, formIDHex string, rd store.Readable) (FormHistory, error) {
+	history := FormHistory{Events: make([]FormEvent, 0)}
+
+	formIDBuf, err := hex.DecodeString(formIDHex)
+	if err != nil {
+		return history, xerrors.Errorf("failed to decode formIDHex: %v", err)
+	}
+
+	length, err := historyLength(rd, formIDBuf)
+	if err != nil {
+		return history, err
+	}
+
+	for i := uint64(0); i < length; i++ {
+		event, err := eventFromStore(ctx, rd, HistoryEventKey(formIDBuf, i))
+		if err != nil {
+			return history, xerrors.Errorf("failed to get event %d: %v", i, err)
+		}
+
+		history.Events = append(history.Events, event)
+	}
+
+	return history, nil
+}
+
+// AppendEvent stores the event after the last one of the history of the form.
+func AppendEvent(ctx serde.Context, snap store.Snapshot, formIDBuf []byte, event FormEvent) error {
+	length, err := historyLength(snap, formIDBuf)
+	if err != nil {
+		return err
+	}
+
+	err = setEvent(ctx, snap, HistoryEventKey(formIDBuf, length), event)
+	if err != nil {
+		return err
+	}
+
+	lengthBuf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(lengthBuf, length+1)
+
+	err = snap.Set(HistoryKey(formIDBuf), lengthBuf)
+	if err != nil {
+		return xerrors.Errorf("failed to set history length: %v", err)
+	}
+
+	return nil
+}
+
+// CountEvent counts a command in the last event of the history if it is an
+// event of the same command in the same block, or in a new event otherwise.
+// It keeps the history small for frequent commands such as cast votes, while
+// an event is never changed once its block is committed.
+func CountEvent(ctx serde.Context, snap store.Snapshot, formIDBuf []byte, command string,
+	blockIndex uint64) error {
+
+	length, err := historyLength(snap, formIDBuf)
+	if err != nil {
+		return err
+	}
+
+	if length > 0 {
+		key := HistoryEventKey(formIDBuf, length-1)
+
+		last, err := eventFromStore(ctx, snap, key)
+		if err != nil {
+			return xerrors.Errorf("failed to get last event: %v", err)
+		}
+
+		count, err := strconv.Atoi(last.Params[countParam])
+
+		if err == nil && last.Command == command && last.BlockIndex == blockIndex {
+			last.Params[countParam] = strconv.Itoa(count + 1)
+
+			return setEvent(ctx, snap, key, last)
+		}
+	}
+
+	return AppendEvent(ctx, snap, formIDBuf, FormEvent{
+		Command:    command,
+		BlockIndex: blockIndex,
+		Params:     map[string]string{countParam: "1"},
+	})
+}
+
+// DeleteHistory deletes the events of the form and their number.
+func DeleteHistory(snap store.Snapshot, formIDBuf []byte) error {
+	length, err := historyLength(snap, formIDBuf)
+	if err != nil {
+		return err
+	}
+
+	for i := uint64(0); i < length; i++ {
+		err = snap.Delete(HistoryEventKey(formIDBuf, i))
+		if err != nil {
+			return xerrors.Errorf("failed to delete event %d: %v", i, err)
+		}
+	}
+
+	err = snap.Delete(HistoryKey(formIDBuf))
+	if err != nil {
+		return xerrors.Errorf("failed to delete history length: %v", err)
+	}
+
+	return nil
+}
+
+// historyLength returns the number of events of the form.
+func historyLength(rd store.Readable, formIDBuf []byte) (uint64, error) {
+	lengthBuf, err := rd.Get(HistoryKey(formIDBuf))
+	if err != nil {
+		return 0, xerrors.Errorf("while getting data for history: %v", err)
+	}
+
+	if len(lengthBuf) == 0 {
+		return 0, nil
+	}
+
+	if len(lengthBuf) != 8 {
+		return 0, xerrors.Errorf("invalid history length: %x", lengthBuf)
+	}
+
+	return binary.LittleEndian.Uint64(lengthBuf), nil
+}
+
+func eventFromStore(ctx serde.Context, rd store.Readable, key []byte) (FormEvent, error) {
+	buf, err := rd.Get(key)
+	if err != nil {
+		return FormEvent{}, xerrors.Errorf("failed to get event: %v", err)
+	}
+
+	format := formEventFormat.Get(ctx.GetFormat())
+
+	msg, err := format.Decode(ctx, buf)
+	if err != nil {
+		return FormEvent{}, xerrors.Errorf("failed to decode event: %v", err)
+	}
+
+	event, ok := msg.(FormEvent)
+	if !ok {
+		return FormEvent{}, xerrors.Errorf("wrong message type: %T", msg)
+	}
+
+	return event, nil
+}
+
+func setEvent(ctx serde.Context, snap store.Snapshot, key []byte, event FormEvent) error {
+	buf, err := event.Serialize(ctx)
+	if err != nil {
+		return xerrors.Errorf("failed to serialize event: %v", err)
+	}
+
+	err = snap.Set(key, buf)
+	if err != nil {
+		return xerrors.Errorf("failed to set event: %v", err)
+	}
+
+	return nil
+}
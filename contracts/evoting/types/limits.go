@@ -0,0 +1,236 @@
+package types
+
+import (
+	"encoding/json"
+
+	"go.dedis.ch/dela/core/store"
+	"golang.org/x/xerrors"
+)
+
+// defaultMaxChunks is the number of chunks of the largest ballot allowed by
+// default.
+const defaultMaxChunks = 1000
+
+// DefaultLimits are the limits used until admins set others. They are large
+// enough for usual forms.
+var DefaultLimits = Limits{
+	MaxConfigurationSize: 1 << 20,
+	MaxQuestions:         200,
+	MaxChoices:           200,
+	MaxTextLength:        5000,
+	MaxBallotSize:        defaultMaxChunks * ChunkSize,
+	MaxVoters:            100000,
+}
+
+// HardLimits are the highest limits that admins can set, so that a single
+// admin can't make each vote and shuffle arbitrarily expensive.
+var HardLimits = Limits{
+	MaxConfigurationSize: 8 << 20,
+	MaxQuestions:         1000,
+	MaxChoices:           1000,
+	MaxTextLength:        50000,
+	MaxBallotSize:        10 * defaultMaxChunks * ChunkSize,
+	MaxVoters:            1000000,
+}
+
+// Parameters contains the chain-level parameters of the evoting contract. It
+// is stored in JSON.
+type Parameters struct {
+	Limits Limits
+}
+
+// Limits bounds the resources used by a form, which protects the nodes
+// from forms that make each vote and shuffle expensive. Each limit is between
+// 1 and its hard limit. In an update of the limits, a limit of 0 keeps the
+// current one.
+type Limits struct {
+	// MaxConfigurationSize is the maximum size in bytes of the JSON-encoded
+	// configuration
+	MaxConfigurationSize uint
+	// MaxQuestions is the maximum number of questions of a form
+	MaxQuestions uint
+	// MaxChoices is the maximum number of choices of a question
+	MaxChoices uint
+	// MaxTextLength is the maximum length of a text answer or a write-in
+	MaxTextLength uint
+	// MaxBallotSize is the maximum size in bytes of an encoded ballot, which
+	// defines the number of chunks to encrypt, shuffle and decrypt
+	MaxBallotSize uint
+	// MaxVoters is the maximum number of voters of a form
+	MaxVoters uint
+}
+
+// namedLimit is a limit with the name of its field
+type namedLimit struct {
+	name  string
+	value *uint
+}
+
+// Merge returns the limits with the limits of the update that are not 0.
+func (limits Limits) Merge(update Limits) Limits {
+	current := limits.fields()
+
+	for i, limit := range update.fields() {
+		if *limit.value > 0 {
+			*current[i].value = *limit.value
+		}
+	}
+
+	return limits
+}
+
+// Validate returns an error if a limit is 0 or above its hard limit.
+func (limits Limits) Validate() error {
+	hard := HardLimits.fields()
+
+	for i, limit := range limits.fields() {
+		if *limit.value == 0 || *limit.value > *hard[i].value {
+			return xerrors.Errorf("%s must be between 1 and %d, got %d",
+				limit.name, *hard[i].value, *limit.value)
+		}
+	}
+
+	return nil
+}
+
+// fields returns the limits in the order of the fields
+func (limits *Limits) fields() []namedLimit {
+	return []namedLimit{
+		{"MaxConfigurationSize", &limits.MaxConfigurationSize},
+		{"MaxQuestions", &limits.MaxQuestions},
+		{"MaxChoices", &limits.MaxChoices},
+		{"MaxTextLength", &limits.MaxTextLength},
+		{"MaxBallotSize", &limits.MaxBallotSize},
+		{"MaxVoters", &limits.MaxVoters},
+	}
+}
+
+// CheckConfiguration returns an error if the configuration exceeds the
+// limits.
+func (limits Limits) CheckConfiguration(configuration Configuration) error {
+	configurationBuf, err := json.Marshal(configuration)
+	if err != nil {
+		return xerrors.Errorf("failed to marshal configuration: %v", err)
+	}
+
+	if uint(len(configurationBuf)) > limits.MaxConfigurationSize {
+		return Reject(RejectLimitExceeded, "the configuration has %d bytes, the limit is %d",
+			len(configurationBuf), limits.MaxConfigurationSize)
+	}
+
+	questions := uint(0)
+
+	for _, subject := range configuration.Scaffold {
+		count, err := limits.checkSubject(subject)
+		if err != nil {
+			return err
+		}
+
+		questions += count
+	}
+
+	if questions > limits.MaxQuestions {
+		return Reject(RejectLimitExceeded, "the form has %d questions, the limit is %d",
+			questions, limits.MaxQuestions)
+	}
+
+	size := uint(configuration.MaxBallotSize())
+	if size > limits.MaxBallotSize {
+		return Reject(RejectLimitExceeded, "the ballot has %d bytes, the limit is %d",
+			size, limits.MaxBallotSize)
+	}
+
+	return nil
+}
+
+// CheckVoters returns an error if a form can't have the number of voters
+func (limits Limits) CheckVoters(voters int) error {
+	if uint(voters) > limits.MaxVoters {
+		return Reject(RejectLimitExceeded, "the form would have %d voters, the limit is %d",
+			voters, limits.MaxVoters)
+	}
+
+	return nil
+}
+
+// checkSubject checks the questions of the subject and its subjects, and
+// returns their number.
+func (limits Limits) checkSubject(subject Subject) (uint, error) {
+	questions := make([]Question, 0)
+
+	for _, q := range subject.Selects {
+		if q.MaxWriteInLength > limits.MaxTextLength {
+			return 0, Reject(RejectLimitExceeded, "the question %s allows write-ins of %d "+
+				"characters, the limit is %d", q.ID, q.MaxWriteInLength, limits.MaxTextLength)
+		}
+
+		questions = append(questions, q)
+	}
+
+	for _, q := range subject.Ranks {
+		questions = append(questions, q)
+	}
+
+	for _, q := range subject.Texts {
+		if q.MaxLength > limits.MaxTextLength {
+			return 0, Reject(RejectLimitExceeded, "the question %s allows answers of %d "+
+				"characters, the limit is %d", q.ID, q.MaxLength, limits.MaxTextLength)
+		}
+
+		questions = append(questions, q)
+	}
+
+	for _, q := range subject.Scores {
+		questions = append(questions, q)
+	}
+
+	for _, q := range subject.Cumulatives {
+		questions = append(questions, q)
+	}
+
+	for _, q := range questions {
+		if uint(q.GetChoicesLength()) > limits.MaxChoices {
+			return 0, Reject(RejectLimitExceeded, "a question of subject %s has %d choices, "+
+				"the limit is %d", subject.ID, q.GetChoicesLength(), limits.MaxChoices)
+		}
+	}
+
+	count := uint(len(questions))
+
+	for _, sub := range subject.Subjects {
+		subCount, err := limits.checkSubject(sub)
+		if err != nil {
+			return 0, err
+		}
+
+		count += subCount
+	}
+
+	return count, nil
+}
+
+// ParametersFromStore returns the parameters stored at the given key. The
+// limits that are not stored, such as limits added later, have their default.
+func ParametersFromStore(store store.Readable, key []byte) (Parameters, error) {
+	parameters := Parameters{Limits: DefaultLimits}
+
+	parametersBuf, err := store.Get(key)
+	if err != nil {
+		return parameters, xerrors.Errorf("while getting data for parameters: %v", err)
+	}
+
+	if len(parametersBuf) == 0 {
+		return parameters, nil
+	}
+
+	var stored Parameters
+
+	err = json.Unmarshal(parametersBuf, &stored)
+	if err != nil {
+		return parameters, xerrors.Errorf("failed to unmarshal parameters: %v", err)
+	}
+
+	parameters.Limits = DefaultLimits.Merge(stored.Limits)
+
+	return parameters, nil
+}
@@ -0,0 +1,103 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/dela/testing/fake"
+)
+
+func TestLimits_CheckConfiguration(t *testing.T) {
+	configuration := Configuration{
+		Scaffold: []Subject{{
+			ID: "s1",
+			Selects: []Select{{
+				ID:      "q1",
+				MaxN:    1,
+				Choices: []Choice{{Choice: "a"}, {Choice: "b"}, {Choice: "c"}},
+			}},
+			Subjects: []Subject{{
+				ID:    "s2",
+				Texts: []Text{{ID: "q2", MaxN: 1, MaxLength: 100, Choices: []Choice{{Choice: "t"}}}},
+			}},
+		}},
+	}
+
+	require.NoError(t, DefaultLimits.CheckConfiguration(configuration))
+
+	err := DefaultLimits.Merge(Limits{MaxQuestions: 1}).CheckConfiguration(configuration)
+	require.EqualError(t, err, "[LIMIT_EXCEEDED] the form has 2 questions, the limit is 1")
+
+	err = DefaultLimits.Merge(Limits{MaxChoices: 2}).CheckConfiguration(configuration)
+	require.EqualError(t, err, "[LIMIT_EXCEEDED] a question of subject s1 has 3 choices, the limit is 2")
+
+	err = DefaultLimits.Merge(Limits{MaxTextLength: 50}).CheckConfiguration(configuration)
+	require.EqualError(t, err, "[LIMIT_EXCEEDED] the question q2 allows answers of 100 characters, the limit is 50")
+
+	err = DefaultLimits.Merge(Limits{MaxBallotSize: 10}).CheckConfiguration(configuration)
+	require.ErrorContains(t, err, "the limit is 10")
+
+	err = DefaultLimits.Merge(Limits{MaxConfigurationSize: 10}).CheckConfiguration(configuration)
+	require.ErrorContains(t, err, "the limit is 10")
+}
+
+func TestLimits_CheckVoters(t *testing.T) {
+	require.NoError(t, DefaultLimits.CheckVoters(1000))
+	require.NoError(t, Limits{MaxVoters: 2}.CheckVoters(2))
+	require.EqualError(t, Limits{MaxVoters: 2}.CheckVoters(3),
+		"[LIMIT_EXCEEDED] the form would have 3 voters, the limit is 2")
+}
+
+func TestLimits_Merge(t *testing.T) {
+	limits := DefaultLimits.Merge(Limits{MaxVoters: 10})
+
+	expected := DefaultLimits
+	expected.MaxVoters = 10
+	require.Equal(t, expected, limits)
+
+	// the limits are copied
+	require.Equal(t, uint(100000), DefaultLimits.MaxVoters)
+}
+
+func TestLimits_Validate(t *testing.T) {
+	require.NoError(t, DefaultLimits.Validate())
+	require.NoError(t, HardLimits.Validate())
+
+	err := DefaultLimits.Merge(Limits{MaxVoters: HardLimits.MaxVoters + 1}).Validate()
+	require.EqualError(t, err, "MaxVoters must be between 1 and 1000000, got 1000001")
+
+	limits := DefaultLimits
+	limits.MaxQuestions = 0
+
+	err = limits.Validate()
+	require.EqualError(t, err, "MaxQuestions must be between 1 and 1000, got 0")
+}
+
+func TestParametersFromStore(t *testing.T) {
+	snap := fake.NewSnapshot()
+
+	parameters, err := ParametersFromStore(snap, []byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, DefaultLimits, parameters.Limits)
+
+	require.NoError(t, snap.Set([]byte("key"), []byte(`{"Limits":{"MaxVoters":10}}`)))
+
+	parameters, err = ParametersFromStore(snap, []byte("key"))
+	require.NoError(t, err)
+	// the limits missing from the store keep their default
+	expected := DefaultLimits
+	expected.MaxVoters = 10
+	require.Equal(t, expected, parameters.Limits)
+
+	// a limit of 0 is not a limit that can be set
+	require.NoError(t, snap.Set([]byte("key"), []byte(`{"Limits":{"MaxVoters":0}}`)))
+
+	parameters, err = ParametersFromStore(snap, []byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, DefaultLimits, parameters.Limits)
+
+	require.NoError(t, snap.Set([]byte("key"), []byte("{")))
+
+	_, err = ParametersFromStore(snap, []byte("key"))
+	require.ErrorContains(t, err, "failed to unmarshal parameters")
+}
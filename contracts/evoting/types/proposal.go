@@ -0,0 +1,280 @@
+package types
+
+import (
+	"encoding/json"
+
+	"go.dedis.ch/dela/core/store"
+	"golang.org/x/xerrors"
+)
+
+// ProposalAction is the change of the admin list made by a proposal
+type ProposalAction string
+
+const (
+	// ProposalAddAdmin adds the target user to the admin list
+	ProposalAddAdmin ProposalAction = "add"
+	// ProposalRemoveAdmin removes the target user from the admin list
+	ProposalRemoveAdmin ProposalAction = "remove"
+	// ProposalThreshold sets the number of approvals needed by a proposal
+	ProposalThreshold ProposalAction = "threshold"
+)
+
+// ProposalStatus is the status of a proposal
+type ProposalStatus string
+
+const (
+	// ProposalPending is a proposal waiting for approvals
+	ProposalPending ProposalStatus = "pending"
+	// ProposalApplied is a proposal approved by enough admins
+	ProposalApplied ProposalStatus = "applied"
+	// ProposalRejected is a proposal rejected by enough admins that it can't
+	// be approved anymore
+	ProposalRejected ProposalStatus = "rejected"
+)
+
+// DefaultProposalLifetime is the number of blocks during which a proposal can
+// be approved, if the proposal doesn't set one.
+const DefaultProposalLifetime uint64 = 1000
+
+// MaxProposalLifetime is the highest number of blocks during which a proposal
+// can be approved, so that every proposal is eventually pruned.
+const MaxProposalLifetime uint64 = 100 * DefaultProposalLifetime
+
+// AdminProposals contains the pending proposals to change the admin list. A
+// change applies once Threshold admins approved it. It is stored in JSON.
+type AdminProposals struct {
+	// Threshold is the number of admins that must approve a proposal. A
+	// threshold of 0 is a majority of the admins.
+	Threshold int
+	Proposals []AdminProposal
+}
+
+// AdminProposal is a change of the admin list that must be approved by the
+// admins.
+type AdminProposal struct {
+	// ID is the hex-encoded ID of the transaction that made the proposal
+	ID           string
+	Action       ProposalAction
+	TargetUserID string `json:",omitempty"`
+	Threshold    int    `json:",omitempty"`
+	ProposerID   string
+	// CreatedAt is the index of the block that made the proposal, and
+	// ExpiresAt the index of the last block in which it can be approved.
+	CreatedAt  uint64
+	ExpiresAt  uint64
+	Approvals  []int
+	Rejections []int
+	Status     ProposalStatus
+}
+
+// GetThreshold returns the number of approvals needed by a proposal given the
+// number of admins. As soon as there are two admins, a single admin can't
+// change the admin list alone.
+func (proposals AdminProposals) GetThreshold(admins int) int {
+	threshold := proposals.Threshold
+	if threshold < 1 {
+		threshold = admins/2 + 1
+	}
+
+	if threshold < minThreshold(admins) {
+		threshold = minThreshold(admins)
+	}
+
+	// the threshold can't be reached if admins were removed meanwhile
+	if threshold > admins {
+		threshold = admins
+	}
+
+	return threshold
+}
+
+// Get returns the proposal with the given ID
+func (proposals *AdminProposals) Get(proposalID string) (*AdminProposal, error) {
+	for i := range proposals.Proposals {
+		if proposals.Proposals[i].ID == proposalID {
+			return &proposals.Proposals[i], nil
+		}
+	}
+
+	return nil, xerrors.Errorf("proposal %q not found", proposalID)
+}
+
+// Prune removes the proposals that are settled or expired at the given block
+// index, so that only the pending proposals are kept.
+func (proposals *AdminProposals) Prune(height uint64) {
+	pending := make([]AdminProposal, 0, len(proposals.Proposals))
+
+	for _, proposal := range proposals.Proposals {
+		if proposal.Status == ProposalPending && !proposal.IsExpired(height) {
+			pending = append(pending, proposal)
+		}
+	}
+
+	proposals.Proposals = pending
+}
+
+// IsExpired returns true if the proposal is pending after its expiry at the
+// given block index.
+func (proposal AdminProposal) IsExpired(height uint64) bool {
+	return proposal.Status == ProposalPending && height > proposal.ExpiresAt
+}
+
+// Approve adds the approval of the admin to the proposal
+func (proposal *AdminProposal) Approve(sciper int) error {
+	err := proposal.checkVote(sciper)
+	if err != nil {
+		return err
+	}
+
+	proposal.Approvals = append(proposal.Approvals, sciper)
+
+	return nil
+}
+
+// Reject adds the rejection of the admin to the proposal
+func (proposal *AdminProposal) Reject(sciper int) error {
+	err := proposal.checkVote(sciper)
+	if err != nil {
+		return err
+	}
+
+	proposal.Rejections = append(proposal.Rejections, sciper)
+
+	return nil
+}
+
+// checkVote checks that the admin can still approve or reject the proposal
+func (proposal AdminProposal) checkVote(sciper int) error {
+	if proposal.Status != ProposalPending {
+		return xerrors.Errorf("the proposal is %s", proposal.Status)
+	}
+
+	for _, voter := range append(proposal.Approvals, proposal.Rejections...) {
+		if voter == sciper {
+			return xerrors.Errorf("the admin %d already voted on the proposal", sciper)
+		}
+	}
+
+	return nil
+}
+
+// CountApprovals returns the number of approvals from current admins
+func (proposal AdminProposal) CountApprovals(list AdminList) int {
+	return countAdmins(proposal.Approvals, list)
+}
+
+// CountRejections returns the number of rejections from current admins
+func (proposal AdminProposal) CountRejections(list AdminList) int {
+	return countAdmins(proposal.Rejections, list)
+}
+
+// Validate checks that the proposal can be applied to the admin list
+func (proposal AdminProposal) Validate(list AdminList) error {
+	switch proposal.Action {
+	case ProposalAddAdmin:
+		index, err := list.GetAdminIndex(proposal.TargetUserID)
+		if err != nil {
+			return xerrors.Errorf("invalid target: %v", err)
+		}
+
+		if index >= 0 {
+			return xerrors.Errorf("the user %s is already an admin", proposal.TargetUserID)
+		}
+	case ProposalRemoveAdmin:
+		index, err := list.GetAdminIndex(proposal.TargetUserID)
+		if err != nil {
+			return xerrors.Errorf("invalid target: %v", err)
+		}
+
+		if index < 0 {
+			return xerrors.Errorf("the user %s is not an admin", proposal.TargetUserID)
+		}
+	case ProposalThreshold:
+		min := minThreshold(len(list.AdminList))
+
+		if proposal.Threshold < min || proposal.Threshold > len(list.AdminList) {
+			return xerrors.Errorf("the threshold must be between %d and %d, got %d",
+				min, len(list.AdminList), proposal.Threshold)
+		}
+	default:
+		return xerrors.Errorf("unknown action: %q", proposal.Action)
+	}
+
+	return nil
+}
+
+// Apply applies the proposal to the admin list or the threshold, and marks it
+// as applied.
+func (proposals *AdminProposals) Apply(proposal *AdminProposal, list *AdminList) error {
+	var err error
+
+	switch proposal.Action {
+	case ProposalAddAdmin:
+		err = list.AddAdmin(proposal.TargetUserID)
+	case ProposalRemoveAdmin:
+		err = list.RemoveAdmin(proposal.TargetUserID)
+	case ProposalThreshold:
+		err = proposal.Validate(*list)
+		if err == nil {
+			proposals.Threshold = proposal.Threshold
+		}
+	default:
+		err = xerrors.Errorf("unknown action: %q", proposal.Action)
+	}
+
+	if err != nil {
+		return xerrors.Errorf("failed to apply the proposal: %v", err)
+	}
+
+	proposal.Status = ProposalApplied
+
+	return nil
+}
+
+// AdminProposalsFromStore returns the proposals stored at the given key. There
+// are no proposals if nothing is stored.
+func AdminProposalsFromStore(store store.Readable, key []byte) (AdminProposals, error) {
+	proposals := AdminProposals{Proposals: make([]AdminProposal, 0)}
+
+	proposalsBuf, err := store.Get(key)
+	if err != nil {
+		return proposals, xerrors.Errorf("while getting data for proposals: %v", err)
+	}
+
+	if len(proposalsBuf) == 0 {
+		return proposals, nil
+	}
+
+	err = json.Unmarshal(proposalsBuf, &proposals)
+	if err != nil {
+		return proposals, xerrors.Errorf("failed to unmarshal proposals: %v", err)
+	}
+
+	return proposals, nil
+}
+
+// minThreshold returns the lowest threshold allowed given the number of
+// admins.
+func minThreshold(admins int) int {
+	if admins >= 2 {
+		return 2
+	}
+
+	return 1
+}
+
+// countAdmins returns the number of SCIPERs that are in the admin list
+func countAdmins(scipers []int, list AdminList) int {
+	count := 0
+
+	for _, sciper := range scipers {
+		for _, admin := range list.AdminList {
+			if admin == sciper {
+				count++
+				break
+			}
+		}
+	}
+
+	return count
+}
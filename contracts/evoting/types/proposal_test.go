@@ -0,0 +1,83 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminProposals_GetThreshold(t *testing.T) {
+	// the default is a majority of the admins
+	require.Equal(t, 1, AdminProposals{}.GetThreshold(1))
+	require.Equal(t, 2, AdminProposals{}.GetThreshold(3))
+	require.Equal(t, 3, AdminProposals{}.GetThreshold(4))
+
+	require.Equal(t, 2, AdminProposals{Threshold: 1}.GetThreshold(2))
+	require.Equal(t, 2, AdminProposals{Threshold: 2}.GetThreshold(3))
+	require.Equal(t, 2, AdminProposals{Threshold: 3}.GetThreshold(2))
+}
+
+func TestAdminProposals_Prune(t *testing.T) {
+	proposals := AdminProposals{Proposals: []AdminProposal{
+		{ID: "pending", Status: ProposalPending, ExpiresAt: 100},
+		{ID: "expired", Status: ProposalPending, ExpiresAt: 10},
+		{ID: "applied", Status: ProposalApplied, ExpiresAt: 100},
+		{ID: "rejected", Status: ProposalRejected, ExpiresAt: 100},
+	}}
+
+	proposals.Prune(50)
+
+	require.Len(t, proposals.Proposals, 1)
+	require.Equal(t, "pending", proposals.Proposals[0].ID)
+}
+
+func TestAdminProposal_IsExpired(t *testing.T) {
+	proposal := AdminProposal{Status: ProposalPending, ExpiresAt: 100}
+
+	require.False(t, proposal.IsExpired(100))
+	require.True(t, proposal.IsExpired(101))
+
+	proposal.Status = ProposalApplied
+	require.False(t, proposal.IsExpired(101))
+}
+
+func TestAdminProposal_Validate(t *testing.T) {
+	list := AdminList{AdminList: []int{123456, 234567}}
+
+	err := AdminProposal{Action: ProposalAddAdmin, TargetUserID: "123456"}.Validate(list)
+	require.EqualError(t, err, "the user 123456 is already an admin")
+
+	err = AdminProposal{Action: ProposalRemoveAdmin, TargetUserID: "345678"}.Validate(list)
+	require.EqualError(t, err, "the user 345678 is not an admin")
+
+	err = AdminProposal{Action: ProposalThreshold, Threshold: 0}.Validate(list)
+	require.EqualError(t, err, "the threshold must be between 2 and 2, got 0")
+
+	// a single admin can't change the admin list alone
+	err = AdminProposal{Action: ProposalThreshold, Threshold: 1}.Validate(list)
+	require.EqualError(t, err, "the threshold must be between 2 and 2, got 1")
+
+	err = AdminProposal{Action: ProposalThreshold, Threshold: 1}.Validate(AdminList{AdminList: []int{123456}})
+	require.NoError(t, err)
+
+	err = AdminProposal{Action: "promote"}.Validate(list)
+	require.EqualError(t, err, `unknown action: "promote"`)
+
+	err = AdminProposal{Action: ProposalAddAdmin, TargetUserID: "345678"}.Validate(list)
+	require.NoError(t, err)
+}
+
+func TestAdminProposal_Votes(t *testing.T) {
+	list := AdminList{AdminList: []int{123456, 234567}}
+	proposal := AdminProposal{Status: ProposalPending, Approvals: []int{123456}}
+
+	err := proposal.Reject(123456)
+	require.EqualError(t, err, "the admin 123456 already voted on the proposal")
+
+	require.NoError(t, proposal.Approve(345678))
+	require.NoError(t, proposal.Reject(234567))
+
+	// only the votes of current admins count
+	require.Equal(t, 1, proposal.CountApprovals(list))
+	require.Equal(t, 1, proposal.CountRejections(list))
+}
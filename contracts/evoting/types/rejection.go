@@ -0,0 +1,94 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Rejection is the stable code of an error of the smart contract that a
+// client can act upon. The message of the error starts with the code in
+// brackets, so that the code is kept in the reason of a rejected transaction.
+type Rejection string
+
+const (
+	// RejectFormNotFound is a form that doesn't exist
+	RejectFormNotFound Rejection = "FORM_NOT_FOUND"
+	// RejectFormNotOpen is a form that isn't open
+	RejectFormNotOpen Rejection = "FORM_NOT_OPEN"
+	// RejectFormSuspended is a form that is suspended
+	RejectFormSuspended Rejection = "FORM_SUSPENDED"
+	// RejectNotVoter is a user who isn't a voter of the form
+	RejectNotVoter Rejection = "NOT_VOTER"
+	// RejectNotOwner is a user who isn't an owner of the form
+	RejectNotOwner Rejection = "NOT_OWNER"
+	// RejectNotAdmin is a user who isn't an admin
+	RejectNotAdmin Rejection = "NOT_ADMIN"
+	// RejectForbidden is a user without the role, or an identity without the
+	// credential, needed by the transaction
+	RejectForbidden Rejection = "FORBIDDEN"
+	// RejectBallotLength is a ballot with a wrong number of chunks
+	RejectBallotLength Rejection = "BALLOT_LENGTH"
+	// RejectRevoteNotAllowed is a voter who can't vote again
+	RejectRevoteNotAllowed Rejection = "REVOTE_NOT_ALLOWED"
+	// RejectInvalidDelegation is a delegation that isn't allowed
+	RejectInvalidDelegation Rejection = "INVALID_DELEGATION"
+	// RejectLimitExceeded is a form or a ballot that exceeds the resource
+	// limits
+	RejectLimitExceeded Rejection = "LIMIT_EXCEEDED"
+	// RejectInvalidFormStatus is a form whose status doesn't allow the
+	// command
+	RejectInvalidFormStatus Rejection = "INVALID_FORM_STATUS"
+	// RejectInvalidRequest is a transaction with a missing or invalid field
+	RejectInvalidRequest Rejection = "INVALID_REQUEST"
+)
+
+// rejections are the known codes
+var rejections = map[Rejection]struct{}{
+	RejectFormNotFound:      {},
+	RejectFormNotOpen:       {},
+	RejectFormSuspended:     {},
+	RejectNotVoter:          {},
+	RejectNotOwner:          {},
+	RejectNotAdmin:          {},
+	RejectForbidden:         {},
+	RejectBallotLength:      {},
+	RejectRevoteNotAllowed:  {},
+	RejectInvalidDelegation: {},
+	RejectLimitExceeded:     {},
+	RejectInvalidFormStatus: {},
+	RejectInvalidRequest:    {},
+}
+
+var rejectionPattern = regexp.MustCompile(`\[([A-Z_]+)\]`)
+
+// RejectionError is an error of the smart contract with its code.
+type RejectionError struct {
+	Code    Rejection
+	Message string
+}
+
+// Error implements error.
+func (e RejectionError) Error() string {
+	return fmt.Sprintf("[%s] %s", e.Code, e.Message)
+}
+
+// Reject returns an error with the code and the formatted message.
+func Reject(code Rejection, format string, args ...interface{}) error {
+	return RejectionError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// RejectionOf returns the code of the first rejection error in the message of
+// an error, or in the reason of a rejected transaction. The wrapping errors
+// come first, so that their code is returned before the wrapped ones.
+func RejectionOf(reason string) (Rejection, bool) {
+	for _, match := range rejectionPattern.FindAllStringSubmatch(reason, -1) {
+		code := Rejection(match[1])
+
+		_, found := rejections[code]
+		if found {
+			return code, true
+		}
+	}
+
+	return "", false
+}
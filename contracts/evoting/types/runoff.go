@@ -0,0 +1,178 @@
+package types
+
+import (
+	"sort"
+
+	"golang.org/x/xerrors"
+)
+
+// RunoffRule defines how the candidates of a runoff are picked from the tally
+// of a Select question of the parent form. Exactly one of the fields must be
+// set.
+type RunoffRule struct {
+	// TopN picks the N choices with the most votes. Choices tied with the
+	// last one are also picked.
+	TopN uint
+	// MinPercent picks the choices selected by at least this percentage of
+	// the ballots that answered the question.
+	MinPercent uint
+}
+
+// IsValid returns true if exactly one rule is set
+func (rule RunoffRule) IsValid() bool {
+	if rule.TopN > 0 && rule.MinPercent > 0 {
+		return false
+	}
+
+	return rule.TopN > 0 || (rule.MinPercent > 0 && rule.MinPercent <= 100)
+}
+
+// RunoffCandidate is a choice of a question, or a name written in, that
+// qualifies for a runoff
+type RunoffCandidate struct {
+	// Choice is the index of the choice, or -1 for a write-in
+	Choice int
+	// WriteIn is the normalized name written in, if Choice is -1
+	WriteIn string
+}
+
+// RunoffCandidates returns the choices of the question that qualify for the
+// runoff according to the rule, in the order of the choices, followed by the
+// qualified write-ins, in the order of the tally.
+func RunoffCandidates(rule RunoffRule, tally Tally, questionID ID) ([]RunoffCandidate, error) {
+	idx := findIndex(tally.SelectTallyIDs, questionID)
+	if idx < 0 {
+		return nil, xerrors.Errorf("question %s not found in the tally", questionID)
+	}
+
+	entries := make([]RunoffCandidate, 0)
+	totals := make([]uint, 0)
+
+	for i, total := range tally.SelectTally[idx] {
+		entries = append(entries, RunoffCandidate{Choice: i})
+		totals = append(totals, total)
+	}
+
+	if idx < len(tally.SelectWriteInTally) {
+		for _, writeIn := range tally.SelectWriteInTally[idx] {
+			entries = append(entries, RunoffCandidate{Choice: -1, WriteIn: writeIn.Name})
+			totals = append(totals, writeIn.Count)
+		}
+	}
+
+	candidates := make([]RunoffCandidate, 0)
+
+	if rule.TopN > 0 {
+		sorted := make([]uint, len(totals))
+		copy(sorted, totals)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] > sorted[j] })
+
+		n := int(rule.TopN)
+		if n > len(sorted) {
+			n = len(sorted)
+		}
+
+		// choices tied with the last qualified one also qualify
+		var cutoff uint
+		if n > 0 {
+			cutoff = sorted[n-1]
+		}
+
+		for i, total := range totals {
+			if total >= cutoff && total > 0 {
+				candidates = append(candidates, entries[i])
+			}
+		}
+	} else {
+		ballots := tally.SelectBallots[idx]
+
+		for i, total := range totals {
+			if total > 0 && total*100 >= rule.MinPercent*ballots {
+				candidates = append(candidates, entries[i])
+			}
+		}
+	}
+
+	if len(candidates) < 2 {
+		return nil, xerrors.Errorf("a runoff requires at least two candidates, got %d",
+			len(candidates))
+	}
+
+	return candidates, nil
+}
+
+// NewRunoffConfiguration returns the configuration of a runoff on the
+// question of the parent configuration, restricted to the given candidates.
+// The qualified write-ins become choices of the runoff. Voters select exactly
+// one of the candidates.
+func NewRunoffConfiguration(parent Configuration, questionID ID,
+	candidates []RunoffCandidate) (Configuration, error) {
+
+	subject, question, found := findSelect(parent.Scaffold, questionID)
+	if !found {
+		return Configuration{}, xerrors.Errorf("select question %s not found", questionID)
+	}
+
+	choices := make([]Choice, len(candidates))
+	for i, candidate := range candidates {
+		if candidate.Choice < 0 {
+			if candidate.WriteIn == "" {
+				return Configuration{}, xerrors.Errorf("empty write-in candidate")
+			}
+
+			choices[i] = Choice{Choice: candidate.WriteIn}
+			continue
+		}
+
+		if candidate.Choice >= len(question.Choices) {
+			return Configuration{}, xerrors.Errorf("invalid candidate: %d", candidate.Choice)
+		}
+
+		choices[i] = question.Choices[candidate.Choice]
+	}
+
+	runoffSelect := Select{
+		ID:       question.ID,
+		Title:    question.Title,
+		MaxN:     1,
+		MinN:     1,
+		Choices:  choices,
+		Hint:     question.Hint,
+		Majority: question.Majority,
+	}
+
+	configuration := Configuration{
+		Title:          parent.Title,
+		AdditionalInfo: parent.AdditionalInfo,
+		RevotePolicy:   parent.RevotePolicy,
+		MaxRevotes:     parent.MaxRevotes,
+		Quorum:         parent.Quorum,
+		Scaffold: []Subject{{
+			ID:      subject.ID,
+			Title:   subject.Title,
+			Order:   []ID{question.ID},
+			Selects: []Select{runoffSelect},
+		}},
+	}
+
+	return configuration, nil
+}
+
+// findSelect returns the Select question with the given ID and the subject
+// that directly contains it.
+func findSelect(subjects []Subject, questionID ID) (Subject, Select, bool) {
+	for _, subject := range subjects {
+		for _, s := range subject.Selects {
+			if s.ID == questionID {
+				return subject, s, true
+			}
+		}
+
+		parent, s, found := findSelect(subject.Subjects, questionID)
+		if found {
+			return parent, s, true
+		}
+	}
+
+	return Subject{}, Select{}, false
+}
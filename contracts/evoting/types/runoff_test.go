@@ -0,0 +1,99 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunoffRule_IsValid(t *testing.T) {
+	require.False(t, RunoffRule{}.IsValid())
+	require.False(t, RunoffRule{TopN: 2, MinPercent: 10}.IsValid())
+	require.False(t, RunoffRule{MinPercent: 101}.IsValid())
+	require.True(t, RunoffRule{TopN: 2}.IsValid())
+	require.True(t, RunoffRule{MinPercent: 15}.IsValid())
+}
+
+func TestRunoffCandidates(t *testing.T) {
+	tally := Tally{
+		SelectTallyIDs: []ID{"q1"},
+		SelectTally:    [][]uint{{10, 30, 20, 20, 0}},
+		SelectBallots:  []uint{80},
+	}
+
+	_, err := RunoffCandidates(RunoffRule{TopN: 2}, tally, "q2")
+	require.EqualError(t, err, "question q2 not found in the tally")
+
+	// choices tied with the last qualified one also qualify
+	candidates, err := RunoffCandidates(RunoffRule{TopN: 2}, tally, "q1")
+	require.NoError(t, err)
+	require.Equal(t, []RunoffCandidate{{Choice: 1}, {Choice: 2}, {Choice: 3}}, candidates)
+
+	candidates, err = RunoffCandidates(RunoffRule{TopN: 10}, tally, "q1")
+	require.NoError(t, err)
+	require.Equal(t, []RunoffCandidate{{Choice: 0}, {Choice: 1}, {Choice: 2}, {Choice: 3}},
+		candidates)
+
+	candidates, err = RunoffCandidates(RunoffRule{MinPercent: 25}, tally, "q1")
+	require.NoError(t, err)
+	require.Equal(t, []RunoffCandidate{{Choice: 1}, {Choice: 2}, {Choice: 3}}, candidates)
+
+	_, err = RunoffCandidates(RunoffRule{MinPercent: 30}, tally, "q1")
+	require.EqualError(t, err, "a runoff requires at least two candidates, got 1")
+
+	// write-ins qualify like the other choices
+	tally.SelectWriteInTally = [][]WriteInCount{{{Name: "dave", Count: 25}, {Name: "eve", Count: 5}}}
+
+	candidates, err = RunoffCandidates(RunoffRule{TopN: 2}, tally, "q1")
+	require.NoError(t, err)
+	require.Equal(t, []RunoffCandidate{{Choice: 1}, {Choice: -1, WriteIn: "dave"}}, candidates)
+}
+
+func TestNewRunoffConfiguration(t *testing.T) {
+	parent := Configuration{
+		Title:        Title{En: "Election"},
+		RevotePolicy: NoRevote,
+		Scaffold: []Subject{{
+			ID: "s1",
+			Subjects: []Subject{{
+				ID:    "s2",
+				Order: []ID{"q1", "q2"},
+				Selects: []Select{{
+					ID:       "q1",
+					MaxN:     2,
+					Choices:  []Choice{{Choice: "A"}, {Choice: "B"}, {Choice: "C"}},
+					WriteIns: 1,
+					Majority: AbsoluteMajority,
+				}},
+				Texts: []Text{{ID: "q2"}},
+			}},
+		}},
+	}
+
+	_, err := NewRunoffConfiguration(parent, "q2", []RunoffCandidate{{Choice: 0}, {Choice: 1}})
+	require.EqualError(t, err, "select question q2 not found")
+
+	_, err = NewRunoffConfiguration(parent, "q1", []RunoffCandidate{{Choice: 0}, {Choice: 3}})
+	require.EqualError(t, err, "invalid candidate: 3")
+
+	configuration, err := NewRunoffConfiguration(parent, "q1",
+		[]RunoffCandidate{{Choice: 0}, {Choice: 2}, {Choice: -1, WriteIn: "dave"}})
+	require.NoError(t, err)
+	require.True(t, configuration.IsValid())
+
+	require.Equal(t, Configuration{
+		Title:        Title{En: "Election"},
+		RevotePolicy: NoRevote,
+		Scaffold: []Subject{{
+			ID:    "s2",
+			Order: []ID{"q1"},
+			Selects: []Select{{
+				ID:       "q1",
+				MaxN:     1,
+				MinN:     1,
+				Choices:  []Choice{{Choice: "A"}, {Choice: "C"}, {Choice: "dave"}},
+				Majority: AbsoluteMajority,
+			}},
+		}},
+	}, configuration)
+}
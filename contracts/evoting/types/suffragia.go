@@ -21,6 +21,11 @@ func RegisterSuffragiaFormat(format serde.Format, engine serde.FormatEngine) {
 type Suffragia struct {
 	VoterIDs    []string
 	Ciphervotes []Ciphervote
+
+	// Tombstones marks the entries that withdraw the previous ballots of their
+	// voter instead of casting a ballot. It is either empty, when there is no
+	// tombstone, or as long as VoterIDs.
+	Tombstones []bool
 }
 
 // Serialize implements the serde.Message
@@ -44,8 +49,74 @@ func (s *Suffragia) CastVote(voterID string, ciphervote Ciphervote) {
 		}
 	}
 
+	s.AddVote(voterID, ciphervote)
+}
+
+// CastFirstVote adds a new vote and its associated user, unless the user
+// already has a vote.
+func (s *Suffragia) CastFirstVote(voterID string, ciphervote Ciphervote) {
+	for _, u := range s.VoterIDs {
+		if u == voterID {
+			return
+		}
+	}
+
+	s.AddVote(voterID, ciphervote)
+}
+
+// AddVote adds a new vote and its associated user, keeping any previous vote
+// of the user.
+func (s *Suffragia) AddVote(voterID string, ciphervote Ciphervote) {
 	s.VoterIDs = append(s.VoterIDs, voterID)
 	s.Ciphervotes = append(s.Ciphervotes, ciphervote.Copy())
+
+	if len(s.Tombstones) > 0 {
+		s.Tombstones = append(s.Tombstones, false)
+	}
+}
+
+// AddTombstone adds a tombstone for the user, which withdraws the ballots
+// the user cast before.
+func (s *Suffragia) AddTombstone(voterID string) {
+	for len(s.Tombstones) < len(s.VoterIDs) {
+		s.Tombstones = append(s.Tombstones, false)
+	}
+
+	s.VoterIDs = append(s.VoterIDs, voterID)
+	s.Ciphervotes = append(s.Ciphervotes, Ciphervote{})
+	s.Tombstones = append(s.Tombstones, true)
+}
+
+// IsTombstone returns true if the entry at the given index is a tombstone.
+func (s *Suffragia) IsTombstone(i int) bool {
+	return i < len(s.Tombstones) && s.Tombstones[i]
+}
+
+// RemoveVote removes the vote of the user, if any.
+func (s *Suffragia) RemoveVote(voterID string) {
+	for i, u := range s.VoterIDs {
+		if u == voterID {
+			s.VoterIDs = append(s.VoterIDs[:i], s.VoterIDs[i+1:]...)
+			s.Ciphervotes = append(s.Ciphervotes[:i], s.Ciphervotes[i+1:]...)
+
+			if len(s.Tombstones) > 0 {
+				s.Tombstones = append(s.Tombstones[:i], s.Tombstones[i+1:]...)
+			}
+
+			return
+		}
+	}
+}
+
+// HasVoted returns true if the user has a vote.
+func (s *Suffragia) HasVoted(voterID string) bool {
+	for _, u := range s.VoterIDs {
+		if u == voterID {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Hash returns the hash of this list of ballots.
@@ -53,6 +124,9 @@ func (s *Suffragia) Hash(ctx serde.Context) ([]byte, error) {
 	h := sha256.New()
 	for i, u := range s.VoterIDs {
 		h.Write([]byte(u))
+		if s.IsTombstone(i) {
+			h.Write([]byte("tombstone"))
+		}
 		buf, err := s.Ciphervotes[i].Serialize(ctx)
 		if err != nil {
 			return nil, xerrors.Errorf("couldn't serialize ciphervote: %v", err)
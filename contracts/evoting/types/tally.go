@@ -0,0 +1,324 @@
+package types
+
+import (
+	"sort"
+	"strings"
+)
+
+// Tally contains the aggregated results of the decrypted ballots of a form.
+// Each tally maps question IDs, through the corresponding ID slice, to the
+// totals of their choices. Invalid ballots are not taken into account.
+type Tally struct {
+	// SelectTally contains, for each Select question, the number of ballots
+	// that selected each choice
+	SelectTallyIDs []ID
+	SelectTally    [][]uint
+
+	// SelectWriteInTally contains, for each Select question, the number of
+	// ballots that wrote in each normalized name. It is indexed like
+	// SelectTally.
+	SelectWriteInTally [][]WriteInCount
+
+	// SelectBallots contains, for each Select question, the number of valid
+	// ballots that answered it. It is indexed like SelectTally.
+	SelectBallots []uint
+
+	// ScoreTally contains, for each Score question, the sum of the scores
+	// given to each choice
+	ScoreTallyIDs []ID
+	ScoreTally    [][]uint
+
+	// CumulativeTally contains, for each Cumulative question, the sum of the
+	// points given to each choice
+	CumulativeTallyIDs []ID
+	CumulativeTally    [][]uint
+}
+
+// NewTally aggregates the given ballots according to the questions of the
+// configuration. Every question of the configuration has an entry in the
+// tally, even if no ballot answered it.
+func NewTally(configuration Configuration, ballots []Ballot) Tally {
+	tally := Tally{
+		SelectTallyIDs:     make([]ID, 0),
+		SelectTally:        make([][]uint, 0),
+		SelectWriteInTally: make([][]WriteInCount, 0),
+		SelectBallots:      make([]uint, 0),
+		ScoreTallyIDs:      make([]ID, 0),
+		ScoreTally:         make([][]uint, 0),
+		CumulativeTallyIDs: make([]ID, 0),
+		CumulativeTally:    make([][]uint, 0),
+	}
+
+	for _, subject := range configuration.Scaffold {
+		tally.addSubject(subject)
+	}
+
+	writeIns := make([]map[string]uint, len(tally.SelectTallyIDs))
+	for i := range writeIns {
+		writeIns[i] = make(map[string]uint)
+	}
+
+	for _, ballot := range ballots {
+		tally.addBallot(ballot, writeIns)
+	}
+
+	for _, counts := range writeIns {
+		tally.SelectWriteInTally = append(tally.SelectWriteInTally, sortWriteIns(counts))
+	}
+
+	return tally
+}
+
+// WriteInCount is the number of ballots that wrote in a given name
+type WriteInCount struct {
+	Name  string
+	Count uint
+}
+
+// NormalizeWriteIn returns the form of a write-in that is used for the tally:
+// surrounding spaces are trimmed, inner spaces are collapsed, and the result
+// is case-folded.
+func NormalizeWriteIn(writeIn string) string {
+	return strings.ToLower(strings.Join(strings.Fields(writeIn), " "))
+}
+
+// addSubject recursively creates an empty entry for each question of the
+// subject
+func (t *Tally) addSubject(subject Subject) {
+	for _, s := range subject.Selects {
+		t.SelectTallyIDs = append(t.SelectTallyIDs, s.ID)
+		t.SelectTally = append(t.SelectTally, make([]uint, len(s.Choices)))
+		t.SelectBallots = append(t.SelectBallots, 0)
+	}
+
+	for _, s := range subject.Scores {
+		t.ScoreTallyIDs = append(t.ScoreTallyIDs, s.ID)
+		t.ScoreTally = append(t.ScoreTally, make([]uint, len(s.Choices)))
+	}
+
+	for _, c := range subject.Cumulatives {
+		t.CumulativeTallyIDs = append(t.CumulativeTallyIDs, c.ID)
+		t.CumulativeTally = append(t.CumulativeTally, make([]uint, len(c.Choices)))
+	}
+
+	for _, s := range subject.Subjects {
+		t.addSubject(s)
+	}
+}
+
+// addBallot adds the answers of the ballot to the tally. Answers to unknown
+// questions are ignored. The normalized write-ins are counted in the map of
+// their Select question, once per ballot.
+func (t *Tally) addBallot(ballot Ballot, writeIns []map[string]uint) {
+	for i, id := range ballot.SelectResultIDs {
+		totals := findTotals(t.SelectTallyIDs, t.SelectTally, id)
+
+		for j, selected := range ballot.SelectResult[i] {
+			if selected && j < len(totals) {
+				totals[j]++
+			}
+		}
+
+		index := findIndex(t.SelectTallyIDs, id)
+		if index < 0 {
+			continue
+		}
+
+		t.SelectBallots[index]++
+
+		if i >= len(ballot.SelectWriteIns) {
+			continue
+		}
+
+		// the same name written in several slots counts once per ballot
+		seen := make(map[string]bool)
+
+		for _, writeIn := range ballot.SelectWriteIns[i] {
+			name := NormalizeWriteIn(writeIn)
+			if name != "" && !seen[name] {
+				seen[name] = true
+				writeIns[index][name]++
+			}
+		}
+	}
+
+	for i, id := range ballot.ScoreResultIDs {
+		totals := findTotals(t.ScoreTallyIDs, t.ScoreTally, id)
+
+		for j, score := range ballot.ScoreResult[i] {
+			if score > 0 && j < len(totals) {
+				totals[j] += uint(score)
+			}
+		}
+	}
+
+	for i, id := range ballot.CumulativeResultIDs {
+		totals := findTotals(t.CumulativeTallyIDs, t.CumulativeTally, id)
+
+		for j, points := range ballot.CumulativeResult[i] {
+			if j < len(totals) {
+				totals[j] += points
+			}
+		}
+	}
+}
+
+// findTotals returns the totals of the question with the given ID, or nil if
+// the question is unknown
+func findTotals(ids []ID, totals [][]uint, id ID) []uint {
+	index := findIndex(ids, id)
+	if index < 0 {
+		return nil
+	}
+
+	return totals[index]
+}
+
+// findIndex returns the index of the given ID, or -1 if it is not found
+func findIndex(ids []ID, id ID) int {
+	for i, questionID := range ids {
+		if questionID == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// sortWriteIns returns the write-in counts ordered by decreasing count, then
+// by name, so that the tally is deterministic
+func sortWriteIns(counts map[string]uint) []WriteInCount {
+	result := make([]WriteInCount, 0, len(counts))
+
+	for name, count := range counts {
+		result = append(result, WriteInCount{Name: name, Count: count})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// OutcomeStatus is the status of the outcome of a form or a question
+type OutcomeStatus string
+
+const (
+	// OutcomeValid means that the quorum and the majorities are reached
+	OutcomeValid OutcomeStatus = "valid"
+	// OutcomeInvalid means that the quorum or a majority is not reached
+	OutcomeInvalid OutcomeStatus = "invalid"
+	// OutcomeTied means that several choices have the same number of votes
+	OutcomeTied OutcomeStatus = "tied"
+)
+
+// Outcome contains the outcome of a form, based on its quorum and on the
+// majority rules of its questions.
+type Outcome struct {
+	Status OutcomeStatus
+
+	// Turnout is the number of decrypted ballots, including the ones that
+	// could not be decoded and are left empty
+	Turnout       uint
+	QuorumReached bool
+
+	// Questions contains the outcome of each Select question with a majority
+	// rule
+	Questions []QuestionOutcome
+}
+
+// QuestionOutcome contains the outcome of a question
+type QuestionOutcome struct {
+	ID     ID
+	Status OutcomeStatus
+
+	// Winners contains the indexes of the winning choices. With a tie, it
+	// contains the tied choices.
+	Winners []int
+}
+
+// NewOutcome computes the outcome of the form from its tally. The form is
+// valid if the quorum is reached and no question is invalid or tied.
+func NewOutcome(form *Form, tally Tally) Outcome {
+	outcome := Outcome{
+		Turnout:       uint(len(form.DecryptedBallots)),
+		QuorumReached: form.Configuration.Quorum.IsReached(len(form.DecryptedBallots), len(form.Voters)),
+		Questions:     make([]QuestionOutcome, 0),
+	}
+
+	outcome.Status = OutcomeValid
+	if !outcome.QuorumReached {
+		outcome.Status = OutcomeInvalid
+	}
+
+	for i, id := range tally.SelectTallyIDs {
+		question, ok := form.Configuration.GetQuestion(id).(Select)
+		if !ok || question.Majority == "" {
+			continue
+		}
+
+		questionOutcome := newQuestionOutcome(id, question.Majority,
+			tally.SelectTally[i], tally.SelectBallots[i])
+
+		if outcome.Status == OutcomeValid && questionOutcome.Status != OutcomeValid {
+			outcome.Status = questionOutcome.Status
+		}
+
+		if questionOutcome.Status == OutcomeInvalid {
+			outcome.Status = OutcomeInvalid
+		}
+
+		outcome.Questions = append(outcome.Questions, questionOutcome)
+	}
+
+	return outcome
+}
+
+// newQuestionOutcome applies the majority rule on the totals of a question
+// answered by the given number of ballots.
+func newQuestionOutcome(id ID, rule MajorityRule, totals []uint, ballots uint) QuestionOutcome {
+	outcome := QuestionOutcome{
+		ID:      id,
+		Status:  OutcomeInvalid,
+		Winners: make([]int, 0),
+	}
+
+	switch rule {
+	case SimpleMajority:
+		var best uint = 0
+
+		for i, total := range totals {
+			if total > best {
+				best = total
+				outcome.Winners = []int{i}
+			} else if total == best && best > 0 {
+				outcome.Winners = append(outcome.Winners, i)
+			}
+		}
+
+		switch {
+		case len(outcome.Winners) == 1:
+			outcome.Status = OutcomeValid
+		case len(outcome.Winners) > 1:
+			outcome.Status = OutcomeTied
+		}
+	case AbsoluteMajority, TwoThirdsMajority:
+		for i, total := range totals {
+			if rule == AbsoluteMajority && total*2 > ballots ||
+				rule == TwoThirdsMajority && total*3 >= ballots*2 && total > 0 {
+				outcome.Winners = append(outcome.Winners, i)
+			}
+		}
+
+		if len(outcome.Winners) > 0 {
+			outcome.Status = OutcomeValid
+		}
+	}
+
+	return outcome
+}
@@ -0,0 +1,163 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTally(t *testing.T) {
+	configuration := Configuration{Scaffold: []Subject{{
+		Selects: []Select{{
+			ID:       "S1",
+			Choices:  make([]Choice, 2),
+			WriteIns: 2,
+		}},
+		Subjects: []Subject{{
+			Scores: []Score{{
+				ID:      "S2",
+				Choices: make([]Choice, 2),
+			}},
+			Cumulatives: []Cumulative{{
+				ID:      "C1",
+				Choices: make([]Choice, 3),
+			}},
+		}},
+	}}}
+
+	ballots := []Ballot{
+		// the same name written in both slots counts once
+		{
+			SelectResultIDs:     []ID{"S1"},
+			SelectResult:        [][]bool{{true, false}},
+			SelectWriteIns:      [][]string{{" Jane  Doe", "JANE DOE"}},
+			ScoreResultIDs:      []ID{"S2"},
+			ScoreResult:         [][]int{{3, -1}},
+			CumulativeResultIDs: []ID{"C1"},
+			CumulativeResult:    [][]uint{{2, 0, 1}},
+		},
+		{
+			SelectResultIDs:     []ID{"S1", "unknown"},
+			SelectResult:        [][]bool{{true, true}, {true}},
+			SelectWriteIns:      [][]string{{"jane doe ", "JOHN"}, {"x"}},
+			ScoreResultIDs:      []ID{"S2"},
+			ScoreResult:         [][]int{{4, 5}},
+			CumulativeResultIDs: []ID{"C1"},
+			CumulativeResult:    [][]uint{{1, 1, 1}},
+		},
+		// invalid ballot
+		{},
+	}
+
+	tally := NewTally(configuration, ballots)
+
+	require.Equal(t, []ID{"S1"}, tally.SelectTallyIDs)
+	require.Equal(t, [][]uint{{2, 1}}, tally.SelectTally)
+	require.Equal(t, []uint{2}, tally.SelectBallots)
+	require.Equal(t, [][]WriteInCount{{
+		{Name: "jane doe", Count: 2},
+		{Name: "john", Count: 1},
+	}}, tally.SelectWriteInTally)
+	require.Equal(t, []ID{"S2"}, tally.ScoreTallyIDs)
+	require.Equal(t, [][]uint{{7, 5}}, tally.ScoreTally)
+	require.Equal(t, []ID{"C1"}, tally.CumulativeTallyIDs)
+	require.Equal(t, [][]uint{{3, 1, 2}}, tally.CumulativeTally)
+
+	// without any ballot
+	tally = NewTally(configuration, nil)
+
+	require.Equal(t, [][]uint{{0, 0}}, tally.SelectTally)
+	require.Equal(t, [][]WriteInCount{{}}, tally.SelectWriteInTally)
+	require.Equal(t, [][]uint{{0, 0}}, tally.ScoreTally)
+	require.Equal(t, [][]uint{{0, 0, 0}}, tally.CumulativeTally)
+}
+
+func TestNormalizeWriteIn(t *testing.T) {
+	require.Equal(t, "jane doe", NormalizeWriteIn("  Jane \t DOE "))
+	require.Equal(t, "", NormalizeWriteIn("   "))
+}
+
+func TestNewOutcome(t *testing.T) {
+	form := Form{
+		Configuration: Configuration{
+			Quorum: Quorum{MinTurnoutPercent: 50},
+			Scaffold: []Subject{{
+				Selects: []Select{{
+					ID:       "S1",
+					Choices:  make([]Choice, 3),
+					Majority: SimpleMajority,
+				}, {
+					ID:       "S2",
+					Choices:  make([]Choice, 2),
+					Majority: AbsoluteMajority,
+				}, {
+					ID:       "S3",
+					Choices:  make([]Choice, 2),
+					Majority: TwoThirdsMajority,
+				}, {
+					ID:      "S4",
+					Choices: make([]Choice, 2),
+				}},
+			}},
+		},
+		Voters: []int{100000, 100001, 100002, 100003},
+	}
+
+	vote := func(s1, s2, s3 []bool) Ballot {
+		return Ballot{
+			SelectResultIDs: []ID{"S1", "S2", "S3"},
+			SelectResult:    [][]bool{s1, s2, s3},
+		}
+	}
+
+	form.DecryptedBallots = []Ballot{
+		vote([]bool{true, false, false}, []bool{true, false}, []bool{true, false}),
+		vote([]bool{true, false, false}, []bool{true, false}, []bool{true, false}),
+		vote([]bool{false, true, false}, []bool{false, true}, []bool{false, true}),
+	}
+
+	outcome := NewOutcome(&form, NewTally(form.Configuration, form.DecryptedBallots))
+
+	require.Equal(t, OutcomeValid, outcome.Status)
+	require.Equal(t, uint(3), outcome.Turnout)
+	require.True(t, outcome.QuorumReached)
+	require.Equal(t, []QuestionOutcome{
+		{ID: "S1", Status: OutcomeValid, Winners: []int{0}},
+		{ID: "S2", Status: OutcomeValid, Winners: []int{0}},
+		{ID: "S3", Status: OutcomeValid, Winners: []int{0}},
+	}, outcome.Questions)
+
+	// with a tie and majorities that are not reached
+	form.DecryptedBallots = append(form.DecryptedBallots,
+		vote([]bool{false, true, false}, []bool{false, true}, []bool{false, true}))
+
+	outcome = NewOutcome(&form, NewTally(form.Configuration, form.DecryptedBallots))
+
+	require.Equal(t, OutcomeInvalid, outcome.Status)
+	require.Equal(t, []QuestionOutcome{
+		{ID: "S1", Status: OutcomeTied, Winners: []int{0, 1}},
+		{ID: "S2", Status: OutcomeInvalid, Winners: []int{}},
+		{ID: "S3", Status: OutcomeInvalid, Winners: []int{}},
+	}, outcome.Questions)
+
+	// with only a tie
+	form.Configuration.Scaffold[0].Selects = form.Configuration.Scaffold[0].Selects[:1]
+
+	outcome = NewOutcome(&form, NewTally(form.Configuration, form.DecryptedBallots))
+	require.Equal(t, OutcomeTied, outcome.Status)
+
+	// with a quorum that is not reached
+	form.Configuration.Quorum = Quorum{MinBallots: 5}
+
+	outcome = NewOutcome(&form, NewTally(form.Configuration, form.DecryptedBallots))
+	require.Equal(t, OutcomeInvalid, outcome.Status)
+	require.False(t, outcome.QuorumReached)
+}
+
+func TestQuorum_IsReached(t *testing.T) {
+	require.True(t, Quorum{}.IsReached(0, 10))
+	require.True(t, Quorum{MinTurnoutPercent: 50}.IsReached(5, 10))
+	require.False(t, Quorum{MinTurnoutPercent: 50}.IsReached(4, 10))
+	require.True(t, Quorum{MinBallots: 3}.IsReached(3, 10))
+	require.False(t, Quorum{MinBallots: 3, MinTurnoutPercent: 10}.IsReached(2, 10))
+}
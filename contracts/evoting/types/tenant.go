@@ -0,0 +1,99 @@
+package types
+
+import (
+	"go.dedis.ch/dela/core/store"
+	"go.dedis.ch/dela/serde"
+	"go.dedis.ch/dela/serde/registry"
+	"golang.org/x/xerrors"
+)
+
+var tenantsFormat = registry.NewSimpleRegistry()
+
+// RegisterTenantsFormat registers the engine for the provided format
+func RegisterTenantsFormat(format serde.Format, engine serde.FormatEngine) {
+	tenantsFormat.Register(format, engine)
+}
+
+// Tenants contains the organizations of a deployment. Each tenant has its own
+// admin list, which only its admins can change, and its forms can only be
+// created by its admins.
+//
+// - implements serde.Message
+type Tenants struct {
+	Tenants []Tenant
+}
+
+// Serialize implements serde.Message
+func (tenants Tenants) Serialize(ctx serde.Context) ([]byte, error) {
+	format := tenantsFormat.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, tenants)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode tenants: %v", err)
+	}
+
+	return data, nil
+}
+
+// Tenant is an organization, such as a department, that manages its own
+// forms.
+type Tenant struct {
+	ID   string
+	Name string
+}
+
+// Contains returns the index of the tenant with the given ID, or -1 if there
+// is none.
+func (tenants Tenants) Contains(tenantID string) int {
+	for i, tenant := range tenants.Tenants {
+		if tenant.ID == tenantID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Add adds a tenant or returns an error if its ID is already used
+func (tenants *Tenants) Add(tenant Tenant) error {
+	if tenant.ID == "" {
+		return xerrors.Errorf("the tenant ID is empty")
+	}
+
+	if tenants.Contains(tenant.ID) >= 0 {
+		return xerrors.Errorf("tenant %q already exist", tenant.ID)
+	}
+
+	tenants.Tenants = append(tenants.Tenants, tenant)
+
+	return nil
+}
+
+// TenantsFromStore returns the tenants stored at the given key. There are no
+// tenants if nothing is stored.
+func TenantsFromStore(ctx serde.Context, store store.Readable, key []byte) (Tenants, error) {
+	tenants := Tenants{Tenants: make([]Tenant, 0)}
+
+	tenantsBuf, err := store.Get(key)
+	if err != nil {
+		return tenants, xerrors.Errorf("while getting data for tenants: %v", err)
+	}
+
+	if len(tenantsBuf) == 0 {
+		return tenants, nil
+	}
+
+	format := tenantsFormat.Get(ctx.GetFormat())
+
+	msg, err := format.Decode(ctx, tenantsBuf)
+	if err != nil {
+		return tenants, xerrors.Errorf("failed to decode tenants: %v", err)
+	}
+
+	tenants, ok := msg.(Tenants)
+	if !ok {
+		return tenants, xerrors.Errorf("wrong message type: %T", msg)
+	}
+
+	return tenants, nil
+}
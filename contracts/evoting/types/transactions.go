@@ -94,6 +94,15 @@ type CreateForm struct {
 	Configuration Configuration
 	// UserID of the owner that is performing the action
 	UserID string
+	// TenantID is the tenant of the form, or empty for a form of the whole
+	// deployment
+	TenantID string
+	// CloseBlock is the index of the last block in which ballots are
+	// accepted, or 0 for no deadline
+	CloseBlock uint64
+	// Private tells if the live status of the form is only served to its
+	// owners and observers
+	Private bool
 }
 
 // Serialize implements serde.Message
@@ -130,6 +139,34 @@ func (openForm OpenForm) Serialize(ctx serde.Context) ([]byte, error) {
 	return data, nil
 }
 
+// ReopenForm defines the transaction to reopen a closed form
+//
+// - implements serde.Message
+type ReopenForm struct {
+	// FormID is hex-encoded
+	FormID string
+	// UserID of the owner that is performing the action
+	UserID string
+	// Reason explains why the form is reopened
+	Reason string
+	// CloseBlock is the new index of the last block in which ballots are
+	// accepted. It must extend the deadline of a form that has one, and is
+	// optional otherwise.
+	CloseBlock uint64
+}
+
+// Serialize implements serde.Message
+func (reopenForm ReopenForm) Serialize(ctx serde.Context) ([]byte, error) {
+	format := transactionFormats.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, reopenForm)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode reopen form: %v", err)
+	}
+
+	return data, nil
+}
+
 // CastVote defines the transaction to cast a vote
 //
 // - implements serde.Message
@@ -138,6 +175,9 @@ type CastVote struct {
 	FormID  string
 	VoterID string
 	Ballot  Ciphervote
+	// DelegatorID is set when the voter casts the ballot on behalf of a voter
+	// who delegated their vote to them. The ballot is then the delegator's.
+	DelegatorID string
 }
 
 // Serialize implements serde.Message
@@ -152,6 +192,75 @@ func (castVote CastVote) Serialize(ctx serde.Context) ([]byte, error) {
 	return data, nil
 }
 
+// DelegateVote defines the transaction to delegate the vote of a voter to
+// another voter of the form
+//
+// - implements serde.Message
+type DelegateVote struct {
+	// FormID is hex-encoded
+	FormID     string
+	VoterID    string
+	DelegateID string
+}
+
+// Serialize implements serde.Message
+func (delegateVote DelegateVote) Serialize(ctx serde.Context) ([]byte, error) {
+	format := transactionFormats.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, delegateVote)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode delegate vote: %v", err)
+	}
+
+	return data, nil
+}
+
+// RevokeDelegation defines the transaction to revoke the delegation of the
+// vote of a voter
+//
+// - implements serde.Message
+type RevokeDelegation struct {
+	// FormID is hex-encoded
+	FormID  string
+	VoterID string
+}
+
+// Serialize implements serde.Message
+func (revokeDelegation RevokeDelegation) Serialize(ctx serde.Context) ([]byte, error) {
+	format := transactionFormats.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, revokeDelegation)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode revoke delegation: %v", err)
+	}
+
+	return data, nil
+}
+
+// WithdrawVote defines the transaction to withdraw the vote of a voter
+//
+// - implements serde.Message
+type WithdrawVote struct {
+	// FormID is hex-encoded
+	FormID  string
+	VoterID string
+	// DelegatorID is set when the voter withdraws the ballot they cast on
+	// behalf of a voter who delegated their vote to them.
+	DelegatorID string
+}
+
+// Serialize implements serde.Message
+func (withdrawVote WithdrawVote) Serialize(ctx serde.Context) ([]byte, error) {
+	format := transactionFormats.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, withdrawVote)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode withdraw vote: %v", err)
+	}
+
+	return data, nil
+}
+
 // CloseForm defines the transaction to close a form
 //
 // - implements serde.Message
@@ -174,6 +283,77 @@ func (closeForm CloseForm) Serialize(ctx serde.Context) ([]byte, error) {
 	return data, nil
 }
 
+// CreateRunoff defines the transaction to create a runoff of a form whose
+// result is available
+//
+// - implements serde.Message
+type CreateRunoff struct {
+	// FormID is the hex-encoded ID of the parent form
+	FormID string
+	// UserID of the owner that is performing the action
+	UserID string
+	// QuestionID is the Select question of the parent form the runoff is on
+	QuestionID ID
+	// Rule defines the candidates of the runoff
+	Rule RunoffRule
+}
+
+// Serialize implements serde.Message
+func (createRunoff CreateRunoff) Serialize(ctx serde.Context) ([]byte, error) {
+	format := transactionFormats.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, createRunoff)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode create runoff: %v", err)
+	}
+
+	return data, nil
+}
+
+// SuspendForm defines the transaction to temporarily suspend an open form
+//
+// - implements serde.Message
+type SuspendForm struct {
+	// FormID is hex-encoded
+	FormID string
+	// UserID of the owner that is performing the action
+	UserID string
+}
+
+// Serialize implements serde.Message
+func (suspendForm SuspendForm) Serialize(ctx serde.Context) ([]byte, error) {
+	format := transactionFormats.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, suspendForm)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode suspend form: %v", err)
+	}
+
+	return data, nil
+}
+
+// ResumeForm defines the transaction to resume a suspended form
+//
+// - implements serde.Message
+type ResumeForm struct {
+	// FormID is hex-encoded
+	FormID string
+	// UserID of the owner that is performing the action
+	UserID string
+}
+
+// Serialize implements serde.Message
+func (resumeForm ResumeForm) Serialize(ctx serde.Context) ([]byte, error) {
+	format := transactionFormats.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, resumeForm)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode resume form: %v", err)
+	}
+
+	return data, nil
+}
+
 // ShuffleBallots defines the transaction to shuffle the ballots
 //
 // - implements serde.Message
@@ -378,6 +558,71 @@ func (addAdmin AddAdmin) Serialize(ctx serde.Context) ([]byte, error) {
 	return data, nil
 }
 
+// CreateTenant defines the transaction to create a tenant with its initial
+// admin
+//
+// - implements serde.Message
+type CreateTenant struct {
+	TenantID         string
+	Name             string
+	AdminID          string
+	PerformingUserID string
+}
+
+// Serialize implements serde.Message
+func (createTenant CreateTenant) Serialize(ctx serde.Context) ([]byte, error) {
+	format := transactionFormats.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, createTenant)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode create tenant: %v", err)
+	}
+
+	return data, nil
+}
+
+// AddTenantAdmin defines the transaction to add an admin to a tenant
+//
+// - implements serde.Message
+type AddTenantAdmin struct {
+	TenantID         string
+	TargetUserID     string
+	PerformingUserID string
+}
+
+// Serialize implements serde.Message
+func (addTenantAdmin AddTenantAdmin) Serialize(ctx serde.Context) ([]byte, error) {
+	format := transactionFormats.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, addTenantAdmin)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode add tenant admin: %v", err)
+	}
+
+	return data, nil
+}
+
+// RemoveTenantAdmin defines the transaction to remove an admin from a tenant
+//
+// - implements serde.Message
+type RemoveTenantAdmin struct {
+	TenantID         string
+	TargetUserID     string
+	PerformingUserID string
+}
+
+// Serialize implements serde.Message
+func (removeTenantAdmin RemoveTenantAdmin) Serialize(ctx serde.Context) ([]byte, error) {
+	format := transactionFormats.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, removeTenantAdmin)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode remove tenant admin: %v", err)
+	}
+
+	return data, nil
+}
+
 // RemoveAdmin defines the transaction to Remove an Admin
 //
 // - implements serde.Message
@@ -485,3 +730,182 @@ func (removeOwner RemoveOwner) Serialize(ctx serde.Context) ([]byte, error) {
 
 	return data, nil
 }
+
+// AddRole defines the transaction to give a role to a user on a form
+//
+// - implements serde.Message
+type AddRole struct {
+	// FormID is hex-encoded
+	FormID           string
+	Role             FormRole
+	TargetUserID     string
+	PerformingUserID string
+}
+
+// Serialize implements serde.Message
+func (addRole AddRole) Serialize(ctx serde.Context) ([]byte, error) {
+	format := transactionFormats.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, addRole)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode Add Role: %v", err)
+	}
+
+	return data, nil
+}
+
+// RemoveRole defines the transaction to remove a role from a user on a form
+//
+// - implements serde.Message
+type RemoveRole struct {
+	// FormID is hex-encoded
+	FormID           string
+	Role             FormRole
+	TargetUserID     string
+	PerformingUserID string
+}
+
+// Serialize implements serde.Message
+func (removeRole RemoveRole) Serialize(ctx serde.Context) ([]byte, error) {
+	format := transactionFormats.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, removeRole)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode Remove Role: %v", err)
+	}
+
+	return data, nil
+}
+
+// VerifyForm defines the transaction of an auditor or an owner to verify the
+// tally of a form
+//
+// - implements serde.Message
+type VerifyForm struct {
+	// FormID is hex-encoded
+	FormID string
+	UserID string
+}
+
+// Serialize implements serde.Message
+func (verifyForm VerifyForm) Serialize(ctx serde.Context) ([]byte, error) {
+	format := transactionFormats.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, verifyForm)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode verify form: %v", err)
+	}
+
+	return data, nil
+}
+
+// RevokeAccess defines the transaction to revoke the credential of an evoting
+// command from identities
+//
+// - implements serde.Message
+type RevokeAccess struct {
+	// Command is the evoting command, or "all"
+	Command string
+	// Identities are the base64-encoded BLS public keys
+	Identities []string
+}
+
+// Serialize implements serde.Message
+func (revokeAccess RevokeAccess) Serialize(ctx serde.Context) ([]byte, error) {
+	format := transactionFormats.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, revokeAccess)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode revoke access: %v", err)
+	}
+
+	return data, nil
+}
+
+// SetLimits defines the transaction to set the resource limits of the forms
+//
+// - implements serde.Message
+type SetLimits struct {
+	Limits           Limits
+	PerformingUserID string
+}
+
+// Serialize implements serde.Message
+func (setLimits SetLimits) Serialize(ctx serde.Context) ([]byte, error) {
+	format := transactionFormats.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, setLimits)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode set limits: %v", err)
+	}
+
+	return data, nil
+}
+
+// ProposeAdminChange defines the transaction to propose a change of the admin
+// list or of the number of approvals needed by a change
+//
+// - implements serde.Message
+type ProposeAdminChange struct {
+	Action ProposalAction
+	// TargetUserID is the user to add or remove
+	TargetUserID string
+	// Threshold is the new number of approvals
+	Threshold        int
+	PerformingUserID string
+	// Lifetime is the number of blocks during which the proposal can be
+	// approved, or 0 for DefaultProposalLifetime
+	Lifetime uint64
+}
+
+// Serialize implements serde.Message
+func (propose ProposeAdminChange) Serialize(ctx serde.Context) ([]byte, error) {
+	format := transactionFormats.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, propose)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode propose admin change: %v", err)
+	}
+
+	return data, nil
+}
+
+// ApproveAdminProposal defines the transaction to approve a proposal
+//
+// - implements serde.Message
+type ApproveAdminProposal struct {
+	ProposalID       string
+	PerformingUserID string
+}
+
+// Serialize implements serde.Message
+func (approve ApproveAdminProposal) Serialize(ctx serde.Context) ([]byte, error) {
+	format := transactionFormats.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, approve)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode approve admin proposal: %v", err)
+	}
+
+	return data, nil
+}
+
+// RejectAdminProposal defines the transaction to reject a proposal
+//
+// - implements serde.Message
+type RejectAdminProposal struct {
+	ProposalID       string
+	PerformingUserID string
+}
+
+// Serialize implements serde.Message
+func (reject RejectAdminProposal) Serialize(ctx serde.Context) ([]byte, error) {
+	format := transactionFormats.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, reject)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode reject admin proposal: %v", err)
+	}
+
+	return data, nil
+}
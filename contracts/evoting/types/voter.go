@@ -0,0 +1,83 @@
+package types
+
+import (
+	"crypto/sha256"
+
+	"go.dedis.ch/dela/core/store"
+	"go.dedis.ch/dela/serde"
+	"go.dedis.ch/dela/serde/registry"
+	"golang.org/x/xerrors"
+)
+
+// voterRecordSuffix is appended to the form ID and the voter ID to compute the
+// key of the record of a voter
+const voterRecordSuffix = "voter"
+
+var voterRecordFormat = registry.NewSimpleRegistry()
+
+// RegisterVoterRecordFormat registers the engine for the provided format
+func RegisterVoterRecordFormat(format serde.Format, engine serde.FormatEngine) {
+	voterRecordFormat.Register(format, engine)
+}
+
+// VoterRecord keeps the ballots of a voter on a form, so that the re-vote
+// policy and the withdrawals are checked without reading the suffragia.
+//
+// - implements serde.Message
+type VoterRecord struct {
+	// Ballots is the number of ballots cast by the voter, including the ones
+	// that have been replaced or withdrawn.
+	Ballots uint32
+
+	// Live is true if the voter has a ballot that has not been withdrawn.
+	Live bool
+}
+
+// Serialize implements serde.Message
+func (r VoterRecord) Serialize(ctx serde.Context) ([]byte, error) {
+	format := voterRecordFormat.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, r)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode voter record: %v", err)
+	}
+
+	return data, nil
+}
+
+// VoterRecordKey returns the key at which the record of the voter is stored.
+func VoterRecordKey(formIDBuf []byte, voterID string) []byte {
+	h := sha256.New()
+	h.Write(formIDBuf)
+	h.Write([]byte(voterRecordSuffix))
+	h.Write([]byte(voterID))
+
+	return h.Sum(nil)
+}
+
+// voterRecordFromStore returns the record of the voter, which is empty if the
+// voter has not voted yet.
+func voterRecordFromStore(ctx serde.Context, rd store.Readable, key []byte) (VoterRecord, error) {
+	buf, err := rd.Get(key)
+	if err != nil {
+		return VoterRecord{}, xerrors.Errorf("failed to get voter record: %v", err)
+	}
+
+	if len(buf) == 0 {
+		return VoterRecord{}, nil
+	}
+
+	format := voterRecordFormat.Get(ctx.GetFormat())
+
+	msg, err := format.Decode(ctx, buf)
+	if err != nil {
+		return VoterRecord{}, xerrors.Errorf("failed to decode voter record: %v", err)
+	}
+
+	record, ok := msg.(VoterRecord)
+	if !ok {
+		return VoterRecord{}, xerrors.Errorf("wrong message type: %T", msg)
+	}
+
+	return record, nil
+}
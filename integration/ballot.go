@@ -441,7 +441,7 @@ func cast(idx int, castVoteRequest ptypes.CastVoteRequest, contentType, randompr
 
 	t.Logf("cast ballot to proxy %v", randomproxy)
 
-	signed, err := createSignedRequest(secret, castVoteRequest)
+	signed, err := createSignedRequest(secret, castVoteRequest, http.MethodPost, "/evoting/forms/"+formID+"/vote")
 	require.NoError(t, err)
 
 	resp, err := http.Post(randomproxy+controller.FormPathSlash+formID+"/vote", contentType, bytes.NewBuffer(signed))
@@ -509,7 +509,7 @@ func castVotesScenario(numVotes, BallotSize, chunksPerBallot int, formID, conten
 		randomproxy := proxyArray[rand.Intn(len(proxyArray))]
 		t.Logf("cast ballot to proxy %v", randomproxy)
 
-		signed, err := createSignedRequest(secret, castVoteRequest)
+		signed, err := createSignedRequest(secret, castVoteRequest, http.MethodPost, "/evoting/forms/"+formID+"/vote")
 		require.NoError(t, err)
 
 		resp, err := http.Post(randomproxy+"/evoting/forms/"+formID+"/vote", contentType, bytes.NewBuffer(signed))
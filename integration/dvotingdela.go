@@ -243,7 +243,8 @@ func newDVotingNode(t require.TestingT, path string, randSource rand.Source) dVo
 
 	dkg := pedersen.NewPedersen(onet, srvc, db, pool, formFac, signer)
 
-	evoting.RegisterContract(exec, evoting.NewContract(accessService, dkg, rosterFac))
+	evoting.RegisterContract(exec, evoting.NewContract(evoting.NewAccessService(accessService, json.NewContext()), dkg,
+		rosterFac, blocks))
 
 	neffShuffle := neff.NewNeffShuffle(onet, srvc, pool, blocks, formFac, signer)
 
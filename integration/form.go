@@ -101,7 +101,7 @@ func createFormScenario(contentType, proxy string, secret kyber.Scalar, t *testi
 		UserID:        "adminId",
 	}
 
-	signed, err := createSignedRequest(secret, createSimpleFormRequest)
+	signed, err := createSignedRequest(secret, createSimpleFormRequest, http.MethodPost, "/evoting/forms")
 	require.NoError(t, err)
 
 	resp, err := http.Post(proxy+"/evoting/forms", contentType, bytes.NewBuffer(signed))
@@ -216,7 +216,7 @@ func updateForm(secret kyber.Scalar, proxyAddr, formIDHex, action string, t *tes
 		Action: action,
 	}
 
-	signed, err := createSignedRequest(secret, msg)
+	signed, err := createSignedRequest(secret, msg, http.MethodPut, "/evoting/forms/"+formIDHex)
 	require.NoError(t, err)
 
 	req, err := http.NewRequest(http.MethodPut, proxyAddr+"/evoting/forms/"+formIDHex, bytes.NewBuffer(signed))
@@ -265,10 +265,11 @@ func getFormInfo(proxyAddr, formID string, t *testing.T) ptypes.GetFormResponse
 
 }
 
-func createSignedRequest(secret kyber.Scalar, msg interface{}) ([]byte, error) {
-	jsonMsg, err := json.Marshal(msg)
+// createSignedRequest signs msg for a request to the method and path
+func createSignedRequest(secret kyber.Scalar, msg interface{}, method, path string) ([]byte, error) {
+	jsonMsg, err := ptypes.NewPayload(msg, method, path)
 	if err != nil {
-		return nil, xerrors.Errorf("failed to marshal json: %v", err)
+		return nil, xerrors.Errorf("failed to create payload: %v", err)
 	}
 
 	payload := base64.URLEncoding.EncodeToString(jsonMsg)
@@ -0,0 +1,119 @@
+package proxy
+
+import (
+	"net/http"
+
+	"github.com/c4dt/d-voting/contracts/evoting"
+	"github.com/c4dt/d-voting/contracts/evoting/types"
+	"github.com/c4dt/d-voting/proxy/txnmanager"
+	ptypes "github.com/c4dt/d-voting/proxy/types"
+	"github.com/gorilla/mux"
+	"go.dedis.ch/dela/serde"
+	"golang.org/x/xerrors"
+)
+
+// NewFormDelegation implements proxy.Proxy
+func (form *form) NewFormDelegation(w http.ResponseWriter, r *http.Request) {
+	var req ptypes.DelegateVoteRequest
+
+	formID, ok := form.getDelegationRequest(w, r, &req)
+	if !ok {
+		return
+	}
+
+	delegateVote := types.DelegateVote{
+		FormID:     formID,
+		VoterID:    req.VoterID,
+		DelegateID: req.DelegateID,
+	}
+
+	form.submitDelegationTxn(w, r, evoting.CmdDelegateVote, delegateVote)
+}
+
+// RevokeFormDelegation implements proxy.Proxy
+func (form *form) RevokeFormDelegation(w http.ResponseWriter, r *http.Request) {
+	var req ptypes.RevokeDelegationRequest
+
+	formID, ok := form.getDelegationRequest(w, r, &req)
+	if !ok {
+		return
+	}
+
+	revokeDelegation := types.RevokeDelegation{
+		FormID:  formID,
+		VoterID: req.VoterID,
+	}
+
+	form.submitDelegationTxn(w, r, evoting.CmdRevokeDelegation, revokeDelegation)
+}
+
+// getDelegationRequest verifies the signed request, fills req, and returns
+// the ID of an existing form. It writes the error and returns false
+// otherwise.
+func (form *form) getDelegationRequest(w http.ResponseWriter, r *http.Request,
+	req interface{}) (string, bool) {
+
+	// get the signed request
+	signed, err := ptypes.SignedRequestOf(r)
+	if err != nil {
+		BadRequestError(w, r, newSignedErr(err), nil)
+		return "", false
+	}
+
+	// get the request and verify the signature
+	err = form.keys.GetAndVerify(signed, req)
+	if err != nil {
+		verifyErr(w, r, err)
+		return "", false
+	}
+
+	vars := mux.Vars(r)
+
+	// check if the formID is valid
+	if vars == nil || vars["formID"] == "" {
+		InternalError(w, r, xerrors.Errorf("formID not found: %v", vars), nil)
+		return "", false
+	}
+
+	formID := vars["formID"]
+
+	elecMD, err := form.getFormsMetadata()
+	if err != nil {
+		ErrorResponse(w, r, ptypes.ErrFormNotFound, xerrors.Errorf("failed to get form metadata: %v", err), nil)
+		return "", false
+	}
+
+	// check if the form exist
+	if elecMD.FormsIDs.Contains(formID) < 0 {
+		ErrorResponse(w, r, ptypes.ErrFormNotFound, xerrors.Errorf("the form does not exist"), nil)
+		return "", false
+	}
+
+	return formID, true
+}
+
+// submitDelegationTxn submits the transaction and sends its information
+func (form *form) submitDelegationTxn(w http.ResponseWriter, r *http.Request,
+	cmd evoting.Command, tx serde.Message) {
+
+	data, err := tx.Serialize(form.context)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to marshal delegation transaction: %v", err), nil)
+		return
+	}
+
+	// create the transaction and add it to the pool
+	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), cmd, evoting.FormArg, data)
+	if err != nil {
+		form.logger.Err(err).Msg("failed to submit txn")
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
+		return
+	}
+
+	// send the transaction's information
+	err = form.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("couldn't send transaction info: %v", err), nil)
+		return
+	}
+}
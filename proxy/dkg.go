@@ -3,7 +3,6 @@ package proxy
 import (
 	"encoding/hex"
 	"encoding/json"
-	"fmt"
 
 	"net/http"
 
@@ -12,16 +11,15 @@ import (
 	"github.com/gorilla/mux"
 	"go.dedis.ch/dela"
 	"go.dedis.ch/dela/core/txn"
-	"go.dedis.ch/kyber/v3"
 	"golang.org/x/xerrors"
 )
 
 // NewDKG returns a new initialized DKG proxy
-func NewDKG(mngr txn.Manager, d dkgSrv.DKG, pk kyber.Point) DKG {
+func NewDKG(mngr txn.Manager, d dkgSrv.DKG, keys *Keyring) DKG {
 	return dkg{
 		manager:    mngr,
 		dkgService: d,
-		pk:         pk,
+		keys:       keys,
 	}
 }
 
@@ -33,8 +31,8 @@ type dkg struct {
 	manager txn.Manager
 	// dkgService is the DKG service
 	dkgService dkgSrv.DKG
-	// pk is the public key of the proxy
-	pk kyber.Point
+	// keys are the keys trusted to sign the requests
+	keys *Keyring
 }
 
 // NewDKGActor implements proxy.DKG
@@ -44,28 +42,27 @@ func (d dkg) NewDKGActor(w http.ResponseWriter, r *http.Request) {
 	var req types.NewDKGRequest
 
 	// Read the request
-	signed, err := types.NewSignedRequest(r.Body)
+	signed, err := types.SignedRequestOf(r)
 	if err != nil {
-		InternalError(w, r, newSignedErr(err), nil)
+		BadRequestError(w, r, newSignedErr(err), nil)
 		return
 	}
 
 	// Verify the request
-	err = signed.GetAndVerify(d.pk, &req)
+	err = d.keys.GetAndVerify(signed, &req)
 	if err != nil {
-		InternalError(w, r, getSignedErr(err), nil)
+		verifyErr(w, r, err)
 		return
 	}
 
 	formIDBuf, err := hex.DecodeString(req.FormID)
 	if err != nil {
-		http.Error(w, "failed to decode formID: "+req.FormID,
-			http.StatusBadRequest)
+		BadRequestError(w, r, xerrors.Errorf("failed to decode formID: %s", req.FormID), nil)
 		return
 	}
 
 	if len(formIDBuf) == 0 {
-		http.Error(w, "formID is empty", http.StatusBadRequest)
+		BadRequestError(w, r, xerrors.Errorf("formID is empty"), nil)
 		return
 	}
 
@@ -77,8 +74,7 @@ func (d dkg) NewDKGActor(w http.ResponseWriter, r *http.Request) {
 	// subscribe to the DKG service
 	_, err = d.dkgService.Listen(formIDBuf, d.manager)
 	if err != nil {
-		http.Error(w, "failed to start actor: "+err.Error(),
-			http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to start actor: %v", err), nil)
 		return
 	}
 }
@@ -93,7 +89,7 @@ func (d dkg) Actor(w http.ResponseWriter, r *http.Request) {
 
 	// check if the formID is present
 	if vars == nil || vars["formID"] == "" {
-		http.Error(w, fmt.Sprintf("formID not found: %v", vars), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("formID not found: %v", vars), nil)
 		return
 	}
 
@@ -107,7 +103,7 @@ func (d dkg) Actor(w http.ResponseWriter, r *http.Request) {
 
 	actor, found := d.dkgService.GetActor(formIDBuf)
 	if !found {
-		NotFoundErr(w, r, xerrors.New("actor not found"), nil)
+		ErrorResponse(w, r, types.ErrActorNotFound, xerrors.New("actor not found"), nil)
 		return
 	}
 
@@ -147,22 +143,22 @@ func (d dkg) EditDKGActor(w http.ResponseWriter, r *http.Request) {
 	var req types.UpdateDKG
 
 	// Read the request
-	signed, err := types.NewSignedRequest(r.Body)
+	signed, err := types.SignedRequestOf(r)
 	if err != nil {
-		InternalError(w, r, newSignedErr(err), nil)
+		BadRequestError(w, r, newSignedErr(err), nil)
 		return
 	}
 
 	// Verify the signature
-	err = signed.GetAndVerify(d.pk, &req)
+	err = d.keys.GetAndVerify(signed, &req)
 	if err != nil {
-		InternalError(w, r, getSignedErr(err), nil)
+		verifyErr(w, r, err)
 		return
 	}
 
 	vars := mux.Vars(r)
 	if vars == nil || vars["formID"] == "" {
-		http.Error(w, fmt.Sprintf("formID not found: %v", vars), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("formID not found: %v", vars), nil)
 		return
 	}
 
@@ -170,14 +166,14 @@ func (d dkg) EditDKGActor(w http.ResponseWriter, r *http.Request) {
 
 	formIDBuf, err := hex.DecodeString(formID)
 	if err != nil {
-		http.Error(w, "failed to decode formID: "+formID, http.StatusBadRequest)
+		BadRequestError(w, r, xerrors.Errorf("failed to decode formID: %s", formID), nil)
 		return
 	}
 
 	// get the actor
 	a, exists := d.dkgService.GetActor(formIDBuf)
 	if !exists {
-		http.Error(w, "actor does not exist", http.StatusInternalServerError)
+		ErrorResponse(w, r, types.ErrActorNotFound, xerrors.Errorf("actor does not exist"), nil)
 		return
 	}
 
@@ -196,7 +192,7 @@ func (d dkg) EditDKGActor(w http.ResponseWriter, r *http.Request) {
 	case "computePubshares":
 		err = a.ComputePubshares()
 		if err != nil {
-			http.Error(w, "failed to compute pubshares: "+err.Error(), http.StatusInternalServerError)
+			InternalError(w, r, xerrors.Errorf("failed to compute pubshares: %v", err), nil)
 			return
 		}
 	default:
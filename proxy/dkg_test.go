@@ -33,18 +33,18 @@ func TestNewDkg(t *testing.T) {
 	ctx.Injector.Inject(&mngr)
 	var d dkgSrv.DKG
 	ctx.Injector.Inject(&d)
-	var pk kyber.Point
-	ctx.Injector.Inject(&pk)
+	var keys *Keyring
+	ctx.Injector.Inject(&keys)
 
-	dkgInterface := NewDKG(mngr, d, pk)
+	dkgInterface := NewDKG(mngr, d, keys)
 	//check that the dkg is not nil
 	require.NotNil(t, dkgInterface)
 	//the txn.Manager of the dkg should be the same as the one we injected$
 	require.Equal(t, mngr, dkgInterface.(dkg).manager)
 	//the dkg of the dkg should be the same as the one we injected
 	require.Equal(t, d, dkgInterface.(dkg).dkgService)
-	//the pk of the dkg should be the same as the one we injected
-	require.Equal(t, pk, dkgInterface.(dkg).pk)
+	//the keyring of the dkg should be the same as the one we injected
+	require.Equal(t, keys, dkgInterface.(dkg).keys)
 }
 
 // test that NewDKGActor is working properly
@@ -80,7 +80,7 @@ func TestNewDKGActor(t *testing.T) {
 		FormID: "abcd",
 	}
 
-	dkgInterface := NewDKG(mngr, mockDKGService{}, public)
+	dkgInterface := NewDKG(mngr, mockDKGService{}, newTestKeyring(t, public))
 
 	requestt, e := createSignedRequest(secret, request)
 	require.NoError(t, e)
@@ -121,7 +121,7 @@ func TestNewDKGActorInvalidRequest(t *testing.T) {
 	err = secret.UnmarshalBinary(secretkeyBuf)
 	require.NoError(t, err)
 
-	dkgInterface := NewDKG(mngr, mockDKGService{}, public)
+	dkgInterface := NewDKG(mngr, mockDKGService{}, newTestKeyring(t, public))
 
 	r, e := http.NewRequest("POST", "/dkg", strings.NewReader("abcd"))
 	if e != nil {
@@ -130,7 +130,7 @@ func TestNewDKGActorInvalidRequest(t *testing.T) {
 
 	dkgInterface.NewDKGActor(w, r)
 
-	require.Equal(t, 500, w.(*httptest.ResponseRecorder).Result().StatusCode)
+	require.Equal(t, 400, w.(*httptest.ResponseRecorder).Result().StatusCode)
 
 }
 
@@ -167,7 +167,7 @@ func TestNewDKGActorInvalidSignature(t *testing.T) {
 		FormID: "abcd",
 	}
 
-	dkgInterface := NewDKG(mngr, mockDKGService{}, public)
+	dkgInterface := NewDKG(mngr, mockDKGService{}, newTestKeyring(t, public))
 
 	requestt, err := createSignedRequest(secret, request)
 	require.NoError(t, err)
@@ -177,7 +177,12 @@ func TestNewDKGActorInvalidSignature(t *testing.T) {
 
 	dkgInterface.NewDKGActor(w, r)
 
-	require.Equal(t, 500, w.(*httptest.ResponseRecorder).Result().StatusCode)
+	require.Equal(t, 403, w.(*httptest.ResponseRecorder).Result().StatusCode)
+
+	var httpErr types.HTTPError
+	err = json.NewDecoder(w.(*httptest.ResponseRecorder).Body).Decode(&httpErr)
+	require.NoError(t, err)
+	require.Equal(t, types.ErrInvalidSignature, httpErr.ErrorCode)
 
 }
 
@@ -214,7 +219,7 @@ func TestNewDKGActorInvalidFormID(t *testing.T) {
 		FormID: "abcdefg",
 	}
 
-	dkgInterface := NewDKG(mngr, mockDKGService{}, public)
+	dkgInterface := NewDKG(mngr, mockDKGService{}, newTestKeyring(t, public))
 
 	requestt, err := createSignedRequest(secret, request)
 
@@ -263,7 +268,7 @@ func TestNewDKGActorFormDoesNotExist(t *testing.T) {
 		FormID: "abcd",
 	}
 
-	dkgInterface := NewDKG(mngr, mockDKGServiceError{}, public)
+	dkgInterface := NewDKG(mngr, mockDKGServiceError{}, newTestKeyring(t, public))
 
 	requestt, err := createSignedRequest(secret, request)
 
@@ -310,7 +315,7 @@ func TestActor(t *testing.T) {
 		FormID: "abcd",
 	}
 
-	dkgInterface := NewDKG(mngr, mockDKGService{}, public)
+	dkgInterface := NewDKG(mngr, mockDKGService{}, newTestKeyring(t, public))
 
 	requestt, err := createSignedRequest(secret, request)
 	require.NoError(t, err)
@@ -4,6 +4,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -17,9 +18,9 @@ import (
 	"github.com/rs/zerolog"
 	"go.dedis.ch/dela"
 	"go.dedis.ch/dela/core/ordering"
+	"go.dedis.ch/dela/core/ordering/cosipbft/blockstore"
 	"go.dedis.ch/dela/core/txn/pool"
 	"go.dedis.ch/dela/serde"
-	"go.dedis.ch/kyber/v3"
 	"golang.org/x/xerrors"
 )
 
@@ -31,9 +32,29 @@ func getSignedErr(err error) error {
 	return xerrors.Errorf("failed to get and verify signed request: %v", err)
 }
 
+// getFormErr sets the error of a form that can't be read from the store, with
+// the code of the reason.
+func getFormErr(w http.ResponseWriter, r *http.Request, err error) {
+	code := ptypes.ErrorCodeOf(err.Error(), ptypes.ErrInternal)
+
+	ErrorResponse(w, r, code, xerrors.Errorf("failed to get form: %v", err), nil)
+}
+
+// verifyErr sets the error of a signed request that can't be verified or
+// whose payload can't be decoded.
+func verifyErr(w http.ResponseWriter, r *http.Request, err error) {
+	code := ptypes.ErrBadRequest
+
+	if errors.As(err, &VerifyError{}) {
+		code = ptypes.ErrInvalidSignature
+	}
+
+	ErrorResponse(w, r, code, getSignedErr(err), nil)
+}
+
 // NewForm returns a new initialized form proxy
-func NewForm(srv ordering.Service, p pool.Pool,
-	ctx serde.Context, fac serde.Factory, pk kyber.Point, txnManaxer txnmanager.Manager) Form {
+func NewForm(srv ordering.Service, blocks blockstore.BlockStore, p pool.Pool,
+	ctx serde.Context, fac serde.Factory, keys *Keyring, txnManaxer txnmanager.Manager) Form {
 
 	logger := dela.Logger.With().Timestamp().Str("role", "evoting-proxy").Logger()
 
@@ -47,13 +68,16 @@ func NewForm(srv ordering.Service, p pool.Pool,
 	return &form{
 		logger:      logger,
 		orderingSvc: srv,
+		blocks:      blocks,
 		context:     ctx,
 		formFac:     fac,
 		adminFac:    types.AdminListFactory{},
+		txFac:       types.NewTransactionFactory(types.CiphervoteFactory{}),
 		mngr:        txnManaxer,
 		pool:        p,
-		pk:          pk,
+		keys:        keys,
 		adminListID: adminListID,
+		streams:     newStreamHub(DefaultMaxStreams),
 	}
 }
 
@@ -64,14 +88,17 @@ type form struct {
 	sync.Mutex
 
 	orderingSvc ordering.Service
+	blocks      blockstore.BlockStore
 	logger      zerolog.Logger
 	context     serde.Context
 	formFac     serde.Factory
 	adminFac    serde.Factory
+	txFac       serde.Factory
 	mngr        txnmanager.Manager
 	pool        pool.Pool
-	pk          kyber.Point
+	keys        *Keyring
 	adminListID string
+	streams     *streamHub
 }
 
 // NewForm implements proxy.Proxy
@@ -79,36 +106,38 @@ func (form *form) NewForm(w http.ResponseWriter, r *http.Request) {
 	var req ptypes.CreateFormRequest
 
 	// get the signed request
-	signed, err := ptypes.NewSignedRequest(r.Body)
+	signed, err := ptypes.SignedRequestOf(r)
 	if err != nil {
-		InternalError(w, r, newSignedErr(err), nil)
+		BadRequestError(w, r, newSignedErr(err), nil)
 		return
 	}
 
 	// get the request and verify the signature
-	err = signed.GetAndVerify(form.pk, &req)
+	err = form.keys.GetAndVerify(signed, &req)
 	if err != nil {
-		InternalError(w, r, getSignedErr(err), nil)
+		verifyErr(w, r, err)
 		return
 	}
 
 	createForm := types.CreateForm{
 		Configuration: req.Configuration,
 		UserID:        req.UserID,
+		TenantID:      req.TenantID,
+		CloseBlock:    req.CloseBlock,
+		Private:       req.Private,
 	}
 
 	// serialize the transaction
 	data, err := createForm.Serialize(form.context)
 	if err != nil {
-		http.Error(w, "failed to marshal CreateFormTransaction: "+err.Error(),
-			http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to marshal CreateFormTransaction: %v", err), nil)
 		return
 	}
 
 	// create the transaction and add it to the pool
 	txnID, blockIdx, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdCreateForm, evoting.FormArg, data)
 	if err != nil {
-		http.Error(w, "failed to submit txn: "+err.Error(), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
 		return
 	}
 
@@ -120,7 +149,7 @@ func (form *form) NewForm(w http.ResponseWriter, r *http.Request) {
 	// create it to get the  token
 	transactionClientInfo, err := form.mngr.CreateTransactionResult(txnID, blockIdx, txnmanager.UnknownTransactionStatus)
 	if err != nil {
-		http.Error(w, "failed to create transaction info: "+err.Error(), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to create transaction info: %v", err), nil)
 		return
 	}
 
@@ -136,21 +165,93 @@ func (form *form) NewForm(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// NewFormRunoff implements proxy.Proxy. It creates a runoff of a form whose
+// result is available.
+func (form *form) NewFormRunoff(w http.ResponseWriter, r *http.Request) {
+	var req ptypes.CreateRunoffRequest
+
+	// get the signed request
+	signed, err := ptypes.SignedRequestOf(r)
+	if err != nil {
+		BadRequestError(w, r, newSignedErr(err), nil)
+		return
+	}
+
+	// get the request and verify the signature
+	err = form.keys.GetAndVerify(signed, &req)
+	if err != nil {
+		verifyErr(w, r, err)
+		return
+	}
+
+	vars := mux.Vars(r)
+
+	// check if the formID is valid
+	if vars == nil || vars["formID"] == "" {
+		InternalError(w, r, xerrors.Errorf("formID not found: %v", vars), nil)
+		return
+	}
+
+	createRunoff := types.CreateRunoff{
+		FormID:     vars["formID"],
+		UserID:     req.UserID,
+		QuestionID: req.QuestionID,
+		Rule:       req.Rule,
+	}
+
+	// serialize the transaction
+	data, err := createRunoff.Serialize(form.context)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to marshal CreateRunoffTransaction: %v", err), nil)
+		return
+	}
+
+	// create the transaction and add it to the pool
+	txnID, blockIdx, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdCreateRunoff, evoting.FormArg, data)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
+		return
+	}
+
+	// the ID of the runoff is the hash of the transaction, as for a new form
+	hash := sha256.New()
+	hash.Write(txnID)
+	formID := hash.Sum(nil)
+
+	// create it to get the  token
+	transactionClientInfo, err := form.mngr.CreateTransactionResult(txnID, blockIdx, txnmanager.UnknownTransactionStatus)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to create transaction info: %v", err), nil)
+		return
+	}
+
+	response := ptypes.CreateFormResponse{
+		FormID: hex.EncodeToString(formID),
+		Token:  transactionClientInfo.Token,
+	}
+
+	// send the response json
+	err = txnmanager.SendResponse(w, response)
+	if err != nil {
+		form.logger.Err(err).Msg("failed to send the response")
+	}
+}
+
 // NewFormVote implements proxy.Proxy
 func (form *form) NewFormVote(w http.ResponseWriter, r *http.Request) {
 	var req ptypes.CastVoteRequest
 
 	// get the signed request
-	signed, err := ptypes.NewSignedRequest(r.Body)
+	signed, err := ptypes.SignedRequestOf(r)
 	if err != nil {
-		InternalError(w, r, newSignedErr(err), nil)
+		BadRequestError(w, r, newSignedErr(err), nil)
 		return
 	}
 
 	// get the request and verify the signature
-	err = signed.GetAndVerify(form.pk, &req)
+	err = form.keys.GetAndVerify(signed, &req)
 	if err != nil {
-		InternalError(w, r, getSignedErr(err), nil)
+		verifyErr(w, r, err)
 		return
 	}
 
@@ -158,7 +259,7 @@ func (form *form) NewFormVote(w http.ResponseWriter, r *http.Request) {
 
 	// check if the formID is valid
 	if vars == nil || vars["formID"] == "" {
-		http.Error(w, fmt.Sprintf("formID not found: %v", vars), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("formID not found: %v", vars), nil)
 		return
 	}
 
@@ -166,13 +267,27 @@ func (form *form) NewFormVote(w http.ResponseWriter, r *http.Request) {
 
 	elecMD, err := form.getFormsMetadata()
 	if err != nil {
-		http.Error(w, "failed to get form metadata", http.StatusNotFound)
+		ErrorResponse(w, r, ptypes.ErrFormNotFound, xerrors.Errorf("failed to get form metadata: %v", err), nil)
 		return
 	}
 
 	// check if the form exist
 	if elecMD.FormsIDs.Contains(formID) < 0 {
-		http.Error(w, "the form does not exist", http.StatusNotFound)
+		ErrorResponse(w, r, ptypes.ErrFormNotFound, xerrors.Errorf("the form does not exist"), nil)
+		return
+	}
+
+	formFromStore, err := types.FormFromStore(form.context, form.formFac, formID, form.orderingSvc.GetStore())
+	if err != nil {
+		getFormErr(w, r, err)
+		return
+	}
+
+	// reject early the ballots that the smart contract would reject
+	err = checkVote(formFromStore, req.VoterID, len(req.Ballot))
+	if err != nil {
+		code := ptypes.ErrorCodeOf(err.Error(), ptypes.ErrBadRequest)
+		ErrorResponse(w, r, code, xerrors.Errorf("invalid vote: %v", err), nil)
 		return
 	}
 
@@ -184,7 +299,7 @@ func (form *form) NewFormVote(w http.ResponseWriter, r *http.Request) {
 
 		err = k.UnmarshalBinary(egpair.K)
 		if err != nil {
-			http.Error(w, "failed to unmarshal K: "+err.Error(), http.StatusInternalServerError)
+			ErrorResponse(w, r, ptypes.ErrInvalidBallot, xerrors.Errorf("failed to unmarshal K: %v", err), nil)
 			return
 		}
 
@@ -192,7 +307,7 @@ func (form *form) NewFormVote(w http.ResponseWriter, r *http.Request) {
 
 		err = c.UnmarshalBinary(egpair.C)
 		if err != nil {
-			http.Error(w, "failed to unmarshal C: "+err.Error(), http.StatusInternalServerError)
+			ErrorResponse(w, r, ptypes.ErrInvalidBallot, xerrors.Errorf("failed to unmarshal C: %v", err), nil)
 			return
 		}
 
@@ -203,16 +318,16 @@ func (form *form) NewFormVote(w http.ResponseWriter, r *http.Request) {
 	}
 
 	castVote := types.CastVote{
-		FormID:  formID,
-		VoterID: req.VoterID,
-		Ballot:  ciphervote,
+		FormID:      formID,
+		VoterID:     req.VoterID,
+		Ballot:      ciphervote,
+		DelegatorID: req.DelegatorID,
 	}
 
 	// serialize the vote
 	data, err := castVote.Serialize(form.context)
 	if err != nil {
-		http.Error(w, "failed to marshal CastVoteTransaction: "+err.Error(),
-			http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to marshal CastVoteTransaction: %v", err), nil)
 		return
 	}
 
@@ -220,14 +335,111 @@ func (form *form) NewFormVote(w http.ResponseWriter, r *http.Request) {
 	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdCastVote, evoting.FormArg, data)
 	if err != nil {
 		form.logger.Err(err).Msg("failed to submit txn")
-		http.Error(w, "failed to submit txn: "+err.Error(), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
 		return
 	}
 
 	// send the transaction's information
 	err = form.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
 	if err != nil {
-		http.Error(w, "couldn't send transaction info: "+err.Error(), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("couldn't send transaction info: %v", err), nil)
+		return
+	}
+}
+
+// checkVote checks that the form accepts a ballot of the given length from the
+// voter. The errors are those of the smart contract.
+func checkVote(form types.Form, voterID string, ballotLen int) error {
+	if form.Status == types.Suspended {
+		return types.Reject(types.RejectFormSuspended, "the form is suspended")
+	}
+
+	if form.Status != types.Open {
+		return types.Reject(types.RejectFormNotOpen, "the form is not open, current status: %d", form.Status)
+	}
+
+	index, err := form.GetVoterIndex(voterID)
+	if err != nil {
+		return xerrors.Errorf("failed to get voter: %v", err)
+	}
+
+	if index < 0 {
+		return types.Reject(types.RejectNotVoter,
+			"The user %v doesn't have the Voter permission on the form.", voterID)
+	}
+
+	if ballotLen != form.ChunksPerBallot() {
+		return types.Reject(types.RejectBallotLength, "the ballot has unexpected length: %d != %d",
+			ballotLen, form.ChunksPerBallot())
+	}
+
+	return nil
+}
+
+// WithdrawFormVote implements proxy.Proxy
+func (form *form) WithdrawFormVote(w http.ResponseWriter, r *http.Request) {
+	var req ptypes.WithdrawVoteRequest
+
+	// get the signed request
+	signed, err := ptypes.SignedRequestOf(r)
+	if err != nil {
+		BadRequestError(w, r, newSignedErr(err), nil)
+		return
+	}
+
+	// get the request and verify the signature
+	err = form.keys.GetAndVerify(signed, &req)
+	if err != nil {
+		verifyErr(w, r, err)
+		return
+	}
+
+	vars := mux.Vars(r)
+
+	// check if the formID is valid
+	if vars == nil || vars["formID"] == "" {
+		InternalError(w, r, xerrors.Errorf("formID not found: %v", vars), nil)
+		return
+	}
+
+	formID := vars["formID"]
+
+	elecMD, err := form.getFormsMetadata()
+	if err != nil {
+		ErrorResponse(w, r, ptypes.ErrFormNotFound, xerrors.Errorf("failed to get form metadata: %v", err), nil)
+		return
+	}
+
+	// check if the form exist
+	if elecMD.FormsIDs.Contains(formID) < 0 {
+		ErrorResponse(w, r, ptypes.ErrFormNotFound, xerrors.Errorf("the form does not exist"), nil)
+		return
+	}
+
+	withdrawVote := types.WithdrawVote{
+		FormID:      formID,
+		VoterID:     req.VoterID,
+		DelegatorID: req.DelegatorID,
+	}
+
+	data, err := withdrawVote.Serialize(form.context)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to marshal WithdrawVoteTransaction: %v", err), nil)
+		return
+	}
+
+	// create the transaction and add it to the pool
+	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdWithdrawVote, evoting.FormArg, data)
+	if err != nil {
+		form.logger.Err(err).Msg("failed to submit txn")
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
+		return
+	}
+
+	// send the transaction's information
+	err = form.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("couldn't send transaction info: %v", err), nil)
 		return
 	}
 }
@@ -237,16 +449,16 @@ func (form *form) EditForm(w http.ResponseWriter, r *http.Request) {
 	var req ptypes.UpdateFormRequest
 
 	// get the signed request
-	signed, err := ptypes.NewSignedRequest(r.Body)
+	signed, err := ptypes.SignedRequestOf(r)
 	if err != nil {
-		InternalError(w, r, newSignedErr(err), nil)
+		BadRequestError(w, r, newSignedErr(err), nil)
 		return
 	}
 
 	// get the request and verify the signature
-	err = signed.GetAndVerify(form.pk, &req)
+	err = form.keys.GetAndVerify(signed, &req)
 	if err != nil {
-		InternalError(w, r, getSignedErr(err), nil)
+		verifyErr(w, r, err)
 		return
 	}
 
@@ -254,7 +466,7 @@ func (form *form) EditForm(w http.ResponseWriter, r *http.Request) {
 
 	// check if the formID is valid
 	if vars == nil || vars["formID"] == "" {
-		http.Error(w, fmt.Sprintf("formID not found: %v", vars), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("formID not found: %v", vars), nil)
 		return
 	}
 
@@ -262,13 +474,13 @@ func (form *form) EditForm(w http.ResponseWriter, r *http.Request) {
 
 	elecMD, err := form.getFormsMetadata()
 	if err != nil {
-		http.Error(w, "failed to get form metadata", http.StatusNotFound)
+		ErrorResponse(w, r, ptypes.ErrFormNotFound, xerrors.Errorf("failed to get form metadata: %v", err), nil)
 		return
 	}
 
 	// check if the form exists
 	if elecMD.FormsIDs.Contains(formID) < 0 {
-		http.Error(w, "the form does not exist", http.StatusNotFound)
+		ErrorResponse(w, r, ptypes.ErrFormNotFound, xerrors.Errorf("the form does not exist"), nil)
 		return
 	}
 
@@ -277,6 +489,12 @@ func (form *form) EditForm(w http.ResponseWriter, r *http.Request) {
 		form.openForm(formID, req.UserID, w, r)
 	case "close":
 		form.closeForm(formID, req.UserID, w, r)
+	case "reopen":
+		form.reopenForm(formID, req, w, r)
+	case "suspend":
+		form.suspendForm(formID, req.UserID, w, r)
+	case "resume":
+		form.resumeForm(formID, req.UserID, w, r)
 	case "combineShares":
 		form.combineShares(formID, req.UserID, w, r)
 	case "cancel":
@@ -298,15 +516,14 @@ func (form *form) openForm(formID string, userID string, w http.ResponseWriter,
 	// serialize the transaction
 	data, err := openForm.Serialize(form.context)
 	if err != nil {
-		http.Error(w, "failed to marshal OpenFormTransaction: "+err.Error(),
-			http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to marshal OpenFormTransaction: %v", err), nil)
 		return
 	}
 
 	// create the transaction and add it to the pool
 	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdOpenForm, evoting.FormArg, data)
 	if err != nil {
-		http.Error(w, "failed to submit txn: "+err.Error(), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
 		return
 	}
 
@@ -325,21 +542,100 @@ func (form *form) closeForm(formIDHex string, userID string, w http.ResponseWrit
 	// serialize the transaction
 	data, err := closeForm.Serialize(form.context)
 	if err != nil {
-		http.Error(w, "failed to marshal CloseFormTransaction: "+err.Error(),
-			http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to marshal CloseFormTransaction: %v", err), nil)
 		return
 	}
 
 	// create the transaction and add it to the pool
 	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdCloseForm, evoting.FormArg, data)
 	if err != nil {
-		http.Error(w, "failed to submit txn: "+err.Error(), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
+		return
+	}
+
+	// send the transaction's informations
+	form.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
+
+}
+
+// reopenForm reopens a closed form that has not been shuffled yet.
+func (form *form) reopenForm(formIDHex string, req ptypes.UpdateFormRequest, w http.ResponseWriter, r *http.Request) {
+
+	reopenForm := types.ReopenForm{
+		FormID:     formIDHex,
+		UserID:     req.UserID,
+		Reason:     req.Reason,
+		CloseBlock: req.CloseBlock,
+	}
+
+	// serialize the transaction
+	data, err := reopenForm.Serialize(form.context)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to marshal ReopenFormTransaction: %v", err), nil)
+		return
+	}
+
+	// create the transaction and add it to the pool
+	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdReopenForm, evoting.FormArg, data)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
+		return
+	}
+
+	// send the transaction's informations
+	form.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
+}
+
+// suspendForm temporarily suspends an open form.
+func (form *form) suspendForm(formIDHex string, userID string, w http.ResponseWriter, r *http.Request) {
+
+	suspendForm := types.SuspendForm{
+		FormID: formIDHex,
+		UserID: userID,
+	}
+
+	// serialize the transaction
+	data, err := suspendForm.Serialize(form.context)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to marshal SuspendFormTransaction: %v", err), nil)
+		return
+	}
+
+	// create the transaction and add it to the pool
+	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdSuspendForm, evoting.FormArg, data)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
 		return
 	}
 
 	// send the transaction's informations
 	form.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
+}
+
+// resumeForm resumes a suspended form.
+func (form *form) resumeForm(formIDHex string, userID string, w http.ResponseWriter, r *http.Request) {
+
+	resumeForm := types.ResumeForm{
+		FormID: formIDHex,
+		UserID: userID,
+	}
 
+	// serialize the transaction
+	data, err := resumeForm.Serialize(form.context)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to marshal ResumeFormTransaction: %v", err), nil)
+		return
+	}
+
+	// create the transaction and add it to the pool
+	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdResumeForm, evoting.FormArg, data)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
+		return
+	}
+
+	// send the transaction's informations
+	form.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
 }
 
 // combineShares decrypts the shuffled ballots in a form.
@@ -347,13 +643,11 @@ func (form *form) combineShares(formIDHex string, userID string, w http.Response
 
 	formFromStore, err := types.FormFromStore(form.context, form.formFac, formIDHex, form.orderingSvc.GetStore())
 	if err != nil {
-		http.Error(w, "failed to get form: "+err.Error(),
-			http.StatusInternalServerError)
+		getFormErr(w, r, err)
 		return
 	}
 	if formFromStore.Status != types.PubSharesSubmitted {
-		http.Error(w, "the submission of public shares must be over!",
-			http.StatusUnauthorized)
+		ErrorResponse(w, r, ptypes.ErrInvalidFormStatus, xerrors.Errorf("the submission of public shares must be over!"), nil)
 		return
 	}
 
@@ -365,15 +659,14 @@ func (form *form) combineShares(formIDHex string, userID string, w http.Response
 	// serialize the transaction
 	data, err := decryptBallots.Serialize(form.context)
 	if err != nil {
-		http.Error(w, "failed to marshal decryptBallots: "+err.Error(),
-			http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to marshal decryptBallots: %v", err), nil)
 		return
 	}
 
 	// create the transaction and add it to the pool
 	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdCombineShares, evoting.FormArg, data)
 	if err != nil {
-		http.Error(w, "failed to submit txn: "+err.Error(), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
 		return
 	}
 
@@ -392,15 +685,14 @@ func (form *form) cancelForm(formIDHex string, userID string, w http.ResponseWri
 	// serialize the transaction
 	data, err := cancelForm.Serialize(form.context)
 	if err != nil {
-		http.Error(w, "failed to marshal CancelForm: "+err.Error(),
-			http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to marshal CancelForm: %v", err), nil)
 		return
 	}
 
 	// create the transaction and add it to the pool
 	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdCancelForm, evoting.FormArg, data)
 	if err != nil {
-		http.Error(w, "failed to submit txn: "+err.Error(), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
 		return
 	}
 
@@ -409,7 +701,8 @@ func (form *form) cancelForm(formIDHex string, userID string, w http.ResponseWri
 }
 
 // Form implements proxy.Proxy. The request should not be signed because it
-// is fetching public data.
+// is fetching public data, except for the users who voted on a private form,
+// which are only given to its owners and observers.
 func (form *form) Form(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Headers", "*")
@@ -418,7 +711,7 @@ func (form *form) Form(w http.ResponseWriter, r *http.Request) {
 
 	// check if the form exists
 	if vars == nil || vars["formID"] == "" {
-		http.Error(w, fmt.Sprintf("formID not found: %v", vars), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("formID not found: %v", vars), nil)
 		return
 	}
 
@@ -427,7 +720,7 @@ func (form *form) Form(w http.ResponseWriter, r *http.Request) {
 	// get the form
 	formFromStore, err := types.FormFromStore(form.context, form.formFac, formID, form.orderingSvc.GetStore())
 	if err != nil {
-		http.Error(w, xerrors.Errorf("failed to get form: %v", err).Error(), http.StatusInternalServerError)
+		getFormErr(w, r, err)
 		return
 	}
 
@@ -437,8 +730,7 @@ func (form *form) Form(w http.ResponseWriter, r *http.Request) {
 	if formFromStore.Pubkey != nil {
 		pubkeyBuf, err = formFromStore.Pubkey.MarshalBinary()
 		if err != nil {
-			http.Error(w, "failed to marshal pubkey: "+err.Error(),
-				http.StatusInternalServerError)
+			InternalError(w, r, xerrors.Errorf("failed to marshal pubkey: %v", err), nil)
 			return
 		}
 	}
@@ -450,13 +742,25 @@ func (form *form) Form(w http.ResponseWriter, r *http.Request) {
 		roster = append(roster, iter.GetNext().String())
 	}
 
-	suff, err := formFromStore.Suffragia(form.context, form.orderingSvc.GetStore())
-	if err != nil {
-		http.Error(w, "couldn't get ballots: "+err.Error(),
-			http.StatusInternalServerError)
+	canRead, ok := form.canReadLiveStatus(w, r, formFromStore.Private, func() (types.Form, error) {
+		return formFromStore, nil
+	})
+	if !ok {
 		return
 	}
 
+	var voters []string
+
+	if canRead {
+		suff, err := formFromStore.Suffragia(form.context, form.orderingSvc.GetStore())
+		if err != nil {
+			InternalError(w, r, xerrors.Errorf("couldn't get ballots: %v", err), nil)
+			return
+		}
+
+		voters = suff.VoterIDs
+	}
+
 	response := ptypes.GetFormResponse{
 		FormID:          string(formFromStore.FormID),
 		Configuration:   formFromStore.Configuration,
@@ -466,58 +770,127 @@ func (form *form) Form(w http.ResponseWriter, r *http.Request) {
 		Roster:          roster,
 		ChunksPerBallot: formFromStore.ChunksPerBallot(),
 		BallotSize:      formFromStore.BallotSize,
-		Voters:          suff.VoterIDs,
+		Voters:          voters,
+		CloseBlock:      formFromStore.CloseBlock,
+		Reopenings:      formFromStore.Reopenings,
+		ParentFormID:    formFromStore.ParentFormID,
+		RunoffFormIDs:   formFromStore.RunoffFormIDs,
+		TenantID:        formFromStore.TenantID,
+		Delegations:     formFromStore.Delegations,
+		Private:         formFromStore.Private,
+	}
+
+	if formFromStore.Status == types.ResultAvailable {
+		tally := types.NewTally(formFromStore.Configuration, formFromStore.DecryptedBallots)
+		outcome := types.NewOutcome(&formFromStore, tally)
+		response.Tally = &tally
+		response.Outcome = &outcome
 	}
 
 	txnmanager.SendResponse(w, response)
 
 }
 
+// FormHistory implements proxy.Proxy. The request should not be signed
+// because it is fetching public data.
+func (form *form) FormHistory(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "*")
+
+	vars := mux.Vars(r)
+
+	// check if the form exists
+	if vars == nil || vars["formID"] == "" {
+		InternalError(w, r, xerrors.Errorf("formID not found: %v", vars), nil)
+		return
+	}
+
+	formID := vars["formID"]
+
+	history, err := types.HistoryFromStore(form.context, formID, form.orderingSvc.GetStore())
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to get history: %v", err), nil)
+		return
+	}
+
+	response := ptypes.GetFormHistoryResponse{
+		FormID: formID,
+		Events: history.Events,
+	}
+
+	txnmanager.SendResponse(w, response)
+}
+
+// FormSummary implements proxy.Proxy. The request should not be signed
+// because it is fetching public data, except for the ballot counts of a
+// private form, which are only given to its owners and observers.
+func (form *form) FormSummary(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "*")
+
+	formID, hasFailed := form.extractAndRetrieveFormID(w, r)
+	if hasFailed {
+		return
+	}
+
+	entry, err := form.formIndexEntry(formID)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to get summary: %v", err), nil)
+		return
+	}
+
+	canRead, ok := form.canReadLiveStatus(w, r, entry.Private, form.storedForm(formID))
+	if !ok {
+		return
+	}
+
+	if !canRead {
+		entry.BallotCount = 0
+		entry.LiveBallots = 0
+	}
+
+	txnmanager.SendResponse(w, entry)
+}
+
 // Forms implements proxy.Proxy. The request should not be signed because it
 // is fecthing public data.
 func (form *form) Forms(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Headers", "*")
 
-	elecMD, err := form.getFormsMetadata()
+	query, err := parseFormsQuery(r.URL.Query())
 	if err != nil {
-		InternalError(w, r, xerrors.Errorf("failed to get form metadata: %v", err), nil)
+		BadRequestError(w, r, xerrors.Errorf("invalid query: %v", err), nil)
 		return
 	}
 
-	allFormsInfo := make([]ptypes.LightForm, len(elecMD.FormsIDs))
-
-	// get the forms
-	for i, id := range elecMD.FormsIDs {
-		if id != form.adminListID {
-			form, err := types.FormFromStore(form.context, form.formFac, id, form.orderingSvc.GetStore())
-			if err != nil {
-				InternalError(w, r, xerrors.Errorf("failed to get form: %v", err), nil)
-				return
-			}
-
-			var pubkeyBuf []byte
+	entries, err := form.formIndexEntries()
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to get forms: %v", err), nil)
+		return
+	}
 
-			if form.Pubkey != nil {
-				pubkeyBuf, err = form.Pubkey.MarshalBinary()
-				if err != nil {
-					InternalError(w, r, xerrors.Errorf("failed to marshal pubkey: %v", err), nil)
-					return
-				}
-			}
+	page, next, err := listForms(entries, query, form.userForms)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to list forms: %v", err), nil)
+		return
+	}
 
-			info := ptypes.LightForm{
-				FormID: string(form.FormID),
-				Title:  form.Configuration.Title,
-				Status: uint16(form.Status),
-				Pubkey: hex.EncodeToString(pubkeyBuf),
-			}
+	allFormsInfo := make([]ptypes.LightForm, len(page))
 
-			allFormsInfo[i] = info
+	for i, entry := range page {
+		allFormsInfo[i] = ptypes.LightForm{
+			FormID:        entry.FormID,
+			Title:         entry.Title,
+			Status:        uint16(entry.Status),
+			Pubkey:        entry.Pubkey,
+			ParentFormID:  entry.ParentFormID,
+			RunoffFormIDs: entry.RunoffFormIDs,
+			TenantID:      entry.TenantID,
 		}
 	}
 
-	response := ptypes.GetFormsResponse{Forms: allFormsInfo}
+	response := ptypes.GetFormsResponse{Forms: allFormsInfo, NextCursor: next}
 
 	txnmanager.SendResponse(w, response)
 
@@ -525,13 +898,13 @@ func (form *form) Forms(w http.ResponseWriter, r *http.Request) {
 
 // DeleteForm implements proxy.Proxy
 func (form *form) DeleteForm(w http.ResponseWriter, r *http.Request) {
-	var req ptypes.UpdateFormRequest
+	var req ptypes.DeleteFormRequest
 
 	vars := mux.Vars(r)
 
 	// check if the formID is valid
 	if vars == nil || vars["formID"] == "" {
-		http.Error(w, fmt.Sprintf("formID not found: %v", vars), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("formID not found: %v", vars), nil)
 		return
 	}
 
@@ -539,26 +912,26 @@ func (form *form) DeleteForm(w http.ResponseWriter, r *http.Request) {
 
 	elecMD, err := form.getFormsMetadata()
 	if err != nil {
-		http.Error(w, "failed to get form metadata", http.StatusNotFound)
+		ErrorResponse(w, r, ptypes.ErrFormNotFound, xerrors.Errorf("failed to get form metadata: %v", err), nil)
 		return
 	}
 
 	// check if the form exists
 	if elecMD.FormsIDs.Contains(formID) < 0 {
-		http.Error(w, "the form does not exist", http.StatusNotFound)
+		ErrorResponse(w, r, ptypes.ErrFormNotFound, xerrors.Errorf("the form does not exist"), nil)
 		return
 	}
-	
+
 	// get the signed request
-	signed, err := ptypes.NewSignedRequest(r.Body)
+	signed, err := ptypes.SignedRequestOf(r)
 	if err != nil {
-		InternalError(w, r, newSignedErr(err), nil)
+		BadRequestError(w, r, newSignedErr(err), nil)
 		return
 	}
 
-	err = signed.GetAndVerify(form.pk, &req)
+	err = form.keys.GetAndVerify(signed, &req)
 	if err != nil {
-		InternalError(w, r, getSignedErr(err), nil)
+		verifyErr(w, r, err)
 		return
 	}
 
@@ -576,7 +949,7 @@ func (form *form) DeleteForm(w http.ResponseWriter, r *http.Request) {
 	// create the transaction and add it to the pool
 	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdDeleteForm, evoting.FormArg, data)
 	if err != nil {
-		http.Error(w, "failed to submit txn: "+err.Error(), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
 		return
 	}
 
@@ -591,26 +964,9 @@ func (form *form) AddAdmin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	addAdmin := types.AddAdmin{
-		TargetUserID:     req.TargetUserID,
-		PerformingUserID: req.PerformingUserID,
-	}
-
-	data, err := addAdmin.Serialize(form.context)
-	if err != nil {
-		InternalError(w, r, xerrors.Errorf("failed to marshal AddAdmin: %v", err), nil)
-		return
-	}
-
-	// create the transaction and add it to the pool
-	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdAddAdmin, evoting.FormArg, data)
-	if err != nil {
-		http.Error(w, "failed to submit txn: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	// send the transaction's information
-	form.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
+	// the change is a proposal, applied once enough admins approved it
+	form.submitAdminProposal(w, r, types.ProposalAddAdmin, req.TargetUserID, 0,
+		req.PerformingUserID, 0)
 }
 
 // POST /removetoadminlist
@@ -620,25 +976,9 @@ func (form *form) RemoveAdmin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	removeAdmin := types.RemoveAdmin{
-		TargetUserID:     req.TargetUserID,
-		PerformingUserID: req.PerformingUserID,
-	}
-
-	data, err := removeAdmin.Serialize(form.context)
-	if err != nil {
-		InternalError(w, r, xerrors.Errorf("failed to marshal RemoveAdmin: %v", err), nil)
-		return
-	}
-
-	// create the transaction and add it to the pool
-	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdRemoveAdmin, evoting.FormArg, data)
-	if err != nil {
-		http.Error(w, "failed to submit txn: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	form.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
+	// the change is a proposal, applied once enough admins approved it
+	form.submitAdminProposal(w, r, types.ProposalRemoveAdmin, req.TargetUserID, 0,
+		req.PerformingUserID, 0)
 }
 
 // GET /adminlist
@@ -697,7 +1037,7 @@ func (form *form) AddOwnerToForm(w http.ResponseWriter, r *http.Request) {
 	// create the transaction and add it to the pool
 	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdAddOwnerForm, evoting.FormArg, data)
 	if err != nil {
-		http.Error(w, "failed to submit txn: "+err.Error(), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
 		return
 	}
 
@@ -731,7 +1071,7 @@ func (form *form) RemoveOwnerToForm(w http.ResponseWriter, r *http.Request) {
 	// create the transaction and add it to the pool
 	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdRemoveOwnerForm, evoting.FormArg, data)
 	if err != nil {
-		http.Error(w, "failed to submit txn: "+err.Error(), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
 		return
 	}
 
@@ -765,7 +1105,7 @@ func (form *form) AddVoterToForm(w http.ResponseWriter, r *http.Request) {
 	// create the transaction and add it to the pool
 	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdAddVoterForm, evoting.FormArg, data)
 	if err != nil {
-		http.Error(w, "failed to submit txn: "+err.Error(), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
 		return
 	}
 
@@ -799,7 +1139,7 @@ func (form *form) RemoveVoterToForm(w http.ResponseWriter, r *http.Request) {
 	// create the transaction and add it to the pool
 	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdRemoveVoterForm, evoting.FormArg, data)
 	if err != nil {
-		http.Error(w, "failed to submit txn: "+err.Error(), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
 		return
 	}
 
@@ -808,6 +1148,67 @@ func (form *form) RemoveVoterToForm(w http.ResponseWriter, r *http.Request) {
 
 // ===== HELPER =====
 
+// formIndexEntries returns the index entries of the forms in the order they
+// were created. The forms created before the index are read entirely.
+func (form *form) formIndexEntries() ([]types.FormIndexEntry, error) {
+	elecMD, err := form.getFormsMetadata()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get form metadata: %v", err)
+	}
+
+	entries := make([]types.FormIndexEntry, 0, len(elecMD.FormsIDs))
+
+	for _, id := range elecMD.FormsIDs {
+		// the admin list is stored among the forms
+		if id == form.adminListID {
+			continue
+		}
+
+		entry, err := form.formIndexEntry(id)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// userForms returns the IDs of the forms of the user in the index of the
+// forms by user.
+func (form *form) userForms(index types.UserForms, sciper int) ([]string, error) {
+	return index.FromStore(sciper, form.orderingSvc.GetStore())
+}
+
+// formIndexEntry returns the index entry of the form. The entry of a form not
+// changed since the index was introduced is computed from the form, with a
+// Version of 0.
+func (form *form) formIndexEntry(formID string) (types.FormIndexEntry, error) {
+	store := form.orderingSvc.GetStore()
+
+	entry, found, err := types.FormIndexEntryFromStore(formID, store)
+	if err != nil {
+		return entry, xerrors.Errorf("failed to get index entry: %v", err)
+	}
+
+	if found {
+		return entry, nil
+	}
+
+	formFromStore, err := types.FormFromStore(form.context, form.formFac, formID, store)
+	if err != nil {
+		return entry, xerrors.Errorf("failed to get form: %v", err)
+	}
+
+	entry, err = types.NewFormIndexEntry(formFromStore)
+	if err != nil {
+		return entry, xerrors.Errorf("failed to create index entry: %v", err)
+	}
+
+	return entry, nil
+}
+
 func (form *form) getFormsMetadata() (types.FormsMetadata, error) {
 	var md types.FormsMetadata
 
@@ -833,16 +1234,16 @@ func (form *form) getPermissionOpRequest(w http.ResponseWriter, r *http.Request)
 	var req ptypes.PermissionOperationRequest
 
 	// get the signed request
-	signed, err := ptypes.NewSignedRequest(r.Body)
+	signed, err := ptypes.SignedRequestOf(r)
 	if err != nil {
-		InternalError(w, r, newSignedErr(err), nil)
+		BadRequestError(w, r, newSignedErr(err), nil)
 		return ptypes.PermissionOperationRequest{}, err
 	}
 
 	// get the request and verify the signature
-	err = signed.GetAndVerify(form.pk, &req)
+	err = form.keys.GetAndVerify(signed, &req)
 	if err != nil {
-		InternalError(w, r, getSignedErr(err), nil)
+		verifyErr(w, r, err)
 		return ptypes.PermissionOperationRequest{}, err
 	}
 	return req, err
@@ -853,7 +1254,7 @@ func (form *form) extractAndRetrieveFormID(w http.ResponseWriter, r *http.Reques
 
 	// check if the formID is valid
 	if vars == nil || vars["formID"] == "" {
-		http.Error(w, fmt.Sprintf("formID not found: %v", vars), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("formID not found: %v", vars), nil)
 		return "", true
 	}
 
@@ -861,13 +1262,13 @@ func (form *form) extractAndRetrieveFormID(w http.ResponseWriter, r *http.Reques
 
 	elecMD, err := form.getFormsMetadata()
 	if err != nil {
-		http.Error(w, "failed to get form metadata", http.StatusNotFound)
+		ErrorResponse(w, r, ptypes.ErrFormNotFound, xerrors.Errorf("failed to get form metadata: %v", err), nil)
 		return "", true
 	}
 
 	// check if the form exists
 	if elecMD.FormsIDs.Contains(formID) < 0 {
-		http.Error(w, "the form does not exist", http.StatusNotFound)
+		ErrorResponse(w, r, ptypes.ErrFormNotFound, xerrors.Errorf("the form does not exist"), nil)
 		return "", true
 	}
 	return formID, false
@@ -0,0 +1,33 @@
+package proxy
+
+import (
+	"testing"
+
+	"github.com/c4dt/d-voting/contracts/evoting/types"
+	ptypes "github.com/c4dt/d-voting/proxy/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckVote(t *testing.T) {
+	form := types.Form{
+		Status:     types.Open,
+		Voters:     []int{123456},
+		BallotSize: 30,
+	}
+
+	require.NoError(t, checkVote(form, "123456", 2))
+
+	codeOf := func(err error) ptypes.ErrorCode {
+		require.Error(t, err)
+		return ptypes.ErrorCodeOf(err.Error(), ptypes.ErrBadRequest)
+	}
+
+	require.Equal(t, ptypes.ErrBallotLength, codeOf(checkVote(form, "123456", 1)))
+	require.Equal(t, ptypes.ErrNotVoter, codeOf(checkVote(form, "234567", 2)))
+
+	form.Status = types.Closed
+	require.Equal(t, ptypes.ErrFormNotOpen, codeOf(checkVote(form, "123456", 2)))
+
+	form.Status = types.Suspended
+	require.Equal(t, ptypes.ErrFormSuspended, codeOf(checkVote(form, "123456", 2)))
+}
@@ -0,0 +1,378 @@
+package proxy
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	ptypes "github.com/c4dt/d-voting/proxy/types"
+	"go.dedis.ch/dela/cli/node"
+	"go.dedis.ch/dela/core/store/kv"
+	"go.dedis.ch/kyber/v3"
+	"golang.org/x/xerrors"
+)
+
+// DefaultKeyID is the ID of the key given with the proxykey flag. It verifies
+// the signed requests that have no key ID.
+const DefaultKeyID = "default"
+
+// KeyringBucket is the name of the bucket of the node database where the keys
+// added and revoked are stored.
+const KeyringBucket = "proxykeys"
+
+// TrustedKey is a key trusted to sign the requests of a frontend during its
+// validity period. NotBefore and NotAfter are in unix seconds, 0 meaning no
+// bound.
+type TrustedKey struct {
+	ID        string
+	Key       kyber.Point
+	NotBefore int64
+	NotAfter  int64
+}
+
+// IsValidAt returns true if the key is valid at the given time.
+func (k TrustedKey) IsValidAt(t time.Time) bool {
+	if k.NotBefore != 0 && t.Unix() < k.NotBefore {
+		return false
+	}
+
+	if k.NotAfter != 0 && t.Unix() > k.NotAfter {
+		return false
+	}
+
+	return true
+}
+
+// storedKey is a key of the keyring as stored in the node database. A revoked
+// key is kept so that it is never added again.
+type storedKey struct {
+	Key       string // hex-encoded
+	NotBefore int64
+	NotAfter  int64
+	Revoked   bool
+}
+
+// Keyring holds the keys trusted to sign requests, by key ID. Keys can be
+// added and revoked while the handlers are running. A revoked key can't be
+// added again, under its ID or another one.
+type Keyring struct {
+	sync.RWMutex
+
+	keys map[string]TrustedKey
+	// revokedIDs and revokedKeys hold the IDs and the hex-encoded keys that
+	// were revoked
+	revokedIDs  map[string]struct{}
+	revokedKeys map[string]struct{}
+	now         func() time.Time
+
+	// db stores the keys added and revoked, or is nil if they are only kept
+	// in memory
+	db kv.DB
+}
+
+// NewKeyring returns a new keyring with the given keys, kept in memory.
+func NewKeyring(keys ...TrustedKey) (*Keyring, error) {
+	keyring := &Keyring{
+		keys:        make(map[string]TrustedKey),
+		revokedIDs:  make(map[string]struct{}),
+		revokedKeys: make(map[string]struct{}),
+		now:         time.Now,
+	}
+
+	for _, key := range keys {
+		err := keyring.Add(key)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to add key: %v", err)
+		}
+	}
+
+	return keyring, nil
+}
+
+// LoadKeyring returns the keyring stored in the database, to which the keys
+// added and revoked are then stored. The default key is added unless it was
+// revoked.
+func LoadKeyring(db kv.DB, defaultKey kyber.Point) (*Keyring, error) {
+	keyring, err := NewKeyring()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create keyring: %v", err)
+	}
+
+	err = db.View(func(tx kv.ReadableTx) error {
+		bucket := tx.GetBucket([]byte(KeyringBucket))
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(id, value []byte) error {
+			var stored storedKey
+
+			err := json.Unmarshal(value, &stored)
+			if err != nil {
+				return xerrors.Errorf("failed to unmarshal key %q: %v", id, err)
+			}
+
+			if stored.Revoked {
+				keyring.revokedIDs[string(id)] = struct{}{}
+				keyring.revokedKeys[stored.Key] = struct{}{}
+
+				return nil
+			}
+
+			key, err := DecodeKey(stored.Key)
+			if err != nil {
+				return xerrors.Errorf("failed to decode key %q: %v", id, err)
+			}
+
+			keyring.keys[string(id)] = TrustedKey{
+				ID:        string(id),
+				Key:       key,
+				NotBefore: stored.NotBefore,
+				NotAfter:  stored.NotAfter,
+			}
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read keys: %v", err)
+	}
+
+	// the default key is given at each start, so it isn't stored unless it
+	// is revoked
+	_, found := keyring.keys[DefaultKeyID]
+	if !found && !keyring.isRevoked(DefaultKeyID, defaultKey) {
+		err = keyring.Add(TrustedKey{ID: DefaultKeyID, Key: defaultKey})
+		if err != nil {
+			return nil, xerrors.Errorf("failed to add default key: %v", err)
+		}
+	}
+
+	keyring.db = db
+
+	return keyring, nil
+}
+
+// ResolveKeyring returns the keyring injected in the node. If there is none,
+// it injects the keyring loaded from the node database, or a keyring kept in
+// memory if the node has no database, so that all the handlers of a node
+// share their keyring.
+func ResolveKeyring(inj node.Injector, defaultKey kyber.Point) (*Keyring, error) {
+	var keyring *Keyring
+
+	err := inj.Resolve(&keyring)
+	if err == nil {
+		return keyring, nil
+	}
+
+	var db kv.DB
+
+	err = inj.Resolve(&db)
+	if err == nil {
+		keyring, err = LoadKeyring(db, defaultKey)
+	} else {
+		keyring, err = NewKeyring(TrustedKey{ID: DefaultKeyID, Key: defaultKey})
+	}
+
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create keyring: %v", err)
+	}
+
+	inj.Inject(keyring)
+
+	return keyring, nil
+}
+
+// DecodeKey returns the point of a hex-encoded public key.
+func DecodeKey(keyHex string) (kyber.Point, error) {
+	keyBuf, err := hex.DecodeString(keyHex)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to decode hex: %v", err)
+	}
+
+	key := suite.Point()
+
+	err = key.UnmarshalBinary(keyBuf)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to unmarshal key: %v", err)
+	}
+
+	return key, nil
+}
+
+// Add adds a key. The ID must not be used by another key, and neither the ID
+// nor the key may have been revoked.
+func (k *Keyring) Add(key TrustedKey) error {
+	if key.ID == "" {
+		return xerrors.Errorf("the key has no ID")
+	}
+
+	if key.Key == nil {
+		return xerrors.Errorf("the key %q is missing", key.ID)
+	}
+
+	if key.NotAfter != 0 && key.NotAfter < key.NotBefore {
+		return xerrors.Errorf("the key %q expires before it is valid", key.ID)
+	}
+
+	k.Lock()
+	defer k.Unlock()
+
+	_, found := k.keys[key.ID]
+	if found {
+		return xerrors.Errorf("the key %q already exists", key.ID)
+	}
+
+	if k.isRevoked(key.ID, key.Key) {
+		return xerrors.Errorf("the key %q was revoked", key.ID)
+	}
+
+	err := k.store(key, false)
+	if err != nil {
+		return xerrors.Errorf("failed to store key %q: %v", key.ID, err)
+	}
+
+	k.keys[key.ID] = key
+
+	return nil
+}
+
+// Revoke removes a key, whose requests are then rejected.
+func (k *Keyring) Revoke(id string) error {
+	k.Lock()
+	defer k.Unlock()
+
+	key, found := k.keys[id]
+	if !found {
+		return xerrors.Errorf("the key %q doesn't exist", id)
+	}
+
+	err := k.store(key, true)
+	if err != nil {
+		return xerrors.Errorf("failed to store key %q: %v", id, err)
+	}
+
+	delete(k.keys, id)
+
+	k.revokedIDs[id] = struct{}{}
+	k.revokedKeys[encodeKey(key.Key)] = struct{}{}
+
+	return nil
+}
+
+// isRevoked returns true if the ID or the key was revoked. The keyring must
+// be locked.
+func (k *Keyring) isRevoked(id string, key kyber.Point) bool {
+	_, found := k.revokedIDs[id]
+	if found {
+		return true
+	}
+
+	_, found = k.revokedKeys[encodeKey(key)]
+
+	return found
+}
+
+// store writes the key to the database, if any. The keyring must be locked.
+func (k *Keyring) store(key TrustedKey, revoked bool) error {
+	if k.db == nil {
+		return nil
+	}
+
+	value, err := json.Marshal(storedKey{
+		Key:       encodeKey(key.Key),
+		NotBefore: key.NotBefore,
+		NotAfter:  key.NotAfter,
+		Revoked:   revoked,
+	})
+	if err != nil {
+		return xerrors.Errorf("failed to marshal key: %v", err)
+	}
+
+	return k.db.Update(func(tx kv.WritableTx) error {
+		bucket, err := tx.GetBucketOrCreate([]byte(KeyringBucket))
+		if err != nil {
+			return err
+		}
+
+		return bucket.Set([]byte(key.ID), value)
+	})
+}
+
+func encodeKey(key kyber.Point) string {
+	keyBuf, err := key.MarshalBinary()
+	if err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(keyBuf)
+}
+
+// Keys returns the keys sorted by ID.
+func (k *Keyring) Keys() []TrustedKey {
+	k.RLock()
+	defer k.RUnlock()
+
+	keys := make([]TrustedKey, 0, len(k.keys))
+	for _, key := range k.keys {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].ID < keys[j].ID
+	})
+
+	return keys
+}
+
+// Verify checks the signature of the request with the key of its key ID,
+// which must be valid now.
+func (k *Keyring) Verify(signed ptypes.SignedRequest) error {
+	id := signed.KeyID
+	if id == "" {
+		id = DefaultKeyID
+	}
+
+	k.RLock()
+	key, found := k.keys[id]
+	k.RUnlock()
+
+	if !found {
+		return xerrors.Errorf("unknown key %q", id)
+	}
+
+	if !key.IsValidAt(k.now()) {
+		return xerrors.Errorf("the key %q is not valid", id)
+	}
+
+	err := signed.Verify(key.Key)
+	if err != nil {
+		return xerrors.Errorf("failed to verify with key %q: %v", id, err)
+	}
+
+	return nil
+}
+
+// VerifyError is the error of a signed request that can't be verified with
+// the keyring.
+type VerifyError struct {
+	error
+}
+
+// GetAndVerify verifies the signed request and extracts its payload. el MUST
+// be a pointer. It returns a VerifyError if the request can't be verified.
+func (k *Keyring) GetAndVerify(signed ptypes.SignedRequest, el interface{}) error {
+	err := k.Verify(signed)
+	if err != nil {
+		return VerifyError{xerrors.Errorf("failed to verify: %v", err)}
+	}
+
+	err = signed.GetMessage(el)
+	if err != nil {
+		return xerrors.Errorf("failed to get message: %v", err)
+	}
+
+	return nil
+}
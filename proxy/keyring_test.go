@@ -0,0 +1,160 @@
+package proxy
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ptypes "github.com/c4dt/d-voting/proxy/types"
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/dela/cli/node"
+	"go.dedis.ch/dela/core/store/kv"
+	"go.dedis.ch/kyber/v3"
+)
+
+func TestKeyring_Verify(t *testing.T) {
+	secret1 := suite.Scalar().Pick(suite.RandomStream())
+	secret2 := suite.Scalar().Pick(suite.RandomStream())
+
+	keys := newTestKeyring(t, suite.Point().Mul(secret1, nil))
+	keys.now = func() time.Time { return time.Unix(1000, 0) }
+
+	payload := []byte(`{"UserID":"123456"}`)
+
+	var signed ptypes.SignedRequest
+	require.NoError(t, json.Unmarshal(signPayload(t, secret1, payload), &signed))
+	require.NoError(t, keys.Verify(signed))
+
+	signed.KeyID = "web2"
+	require.EqualError(t, keys.Verify(signed), `unknown key "web2"`)
+
+	// a second frontend signs with its own key
+	err := keys.Add(TrustedKey{ID: "web2", Key: suite.Point().Mul(secret2, nil), NotAfter: 1500})
+	require.NoError(t, err)
+
+	require.ErrorContains(t, keys.Verify(signed), `failed to verify with key "web2"`)
+
+	require.NoError(t, json.Unmarshal(signPayload(t, secret2, payload), &signed))
+	signed.KeyID = "web2"
+	require.NoError(t, keys.Verify(signed))
+
+	var req map[string]string
+	require.NoError(t, keys.GetAndVerify(signed, &req))
+	require.Equal(t, "123456", req["UserID"])
+
+	keys.now = func() time.Time { return time.Unix(1501, 0) }
+	require.EqualError(t, keys.Verify(signed), `the key "web2" is not valid`)
+
+	require.NoError(t, keys.Revoke(DefaultKeyID))
+	require.Len(t, keys.Keys(), 1)
+	require.Equal(t, "web2", keys.Keys()[0].ID)
+}
+
+func TestKeyring_Add(t *testing.T) {
+	key := suite.Point().Pick(suite.RandomStream())
+	keys := newTestKeyring(t, key)
+
+	err := keys.Add(TrustedKey{ID: DefaultKeyID, Key: key})
+	require.EqualError(t, err, `the key "default" already exists`)
+
+	err = keys.Add(TrustedKey{Key: key})
+	require.EqualError(t, err, "the key has no ID")
+
+	err = keys.Add(TrustedKey{ID: "web2"})
+	require.EqualError(t, err, `the key "web2" is missing`)
+
+	err = keys.Add(TrustedKey{ID: "web2", Key: key, NotBefore: 20, NotAfter: 10})
+	require.EqualError(t, err, `the key "web2" expires before it is valid`)
+
+	err = keys.Revoke("web2")
+	require.EqualError(t, err, `the key "web2" doesn't exist`)
+}
+
+func TestResolveKeyring(t *testing.T) {
+	inj := node.NewInjector()
+	key := suite.Point().Pick(suite.RandomStream())
+
+	keys, err := ResolveKeyring(inj, key)
+	require.NoError(t, err)
+
+	// the handlers registered next share the keyring
+	other, err := ResolveKeyring(inj, suite.Point().Pick(suite.RandomStream()))
+	require.NoError(t, err)
+	require.Same(t, keys, other)
+	require.Equal(t, key, other.Keys()[0].Key)
+}
+
+func TestLoadKeyring(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db")
+
+	db, err := kv.New(path)
+	require.NoError(t, err)
+
+	defaultKey := suite.Point().Pick(suite.RandomStream())
+	web2 := suite.Point().Pick(suite.RandomStream())
+	web3 := suite.Point().Pick(suite.RandomStream())
+
+	keys, err := LoadKeyring(db, defaultKey)
+	require.NoError(t, err)
+	require.Len(t, keys.Keys(), 1)
+
+	require.NoError(t, keys.Add(TrustedKey{ID: "web2", Key: web2, NotAfter: 1500}))
+	require.NoError(t, keys.Add(TrustedKey{ID: "web3", Key: web3}))
+	require.NoError(t, keys.Revoke(DefaultKeyID))
+	require.NoError(t, keys.Revoke("web3"))
+
+	// a revoked key can't be added again, under any ID
+	err = keys.Add(TrustedKey{ID: "web3", Key: suite.Point().Pick(suite.RandomStream())})
+	require.EqualError(t, err, `the key "web3" was revoked`)
+
+	err = keys.Add(TrustedKey{ID: "web4", Key: web3})
+	require.EqualError(t, err, `the key "web4" was revoked`)
+
+	// the node restarts
+	require.NoError(t, db.Close())
+
+	db, err = kv.New(path)
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	keys, err = LoadKeyring(db, defaultKey)
+	require.NoError(t, err)
+
+	require.Len(t, keys.Keys(), 1)
+	require.Equal(t, "web2", keys.Keys()[0].ID)
+	require.True(t, web2.Equal(keys.Keys()[0].Key))
+	require.Equal(t, int64(1500), keys.Keys()[0].NotAfter)
+
+	secret := suite.Scalar().Pick(suite.RandomStream())
+
+	var signed ptypes.SignedRequest
+	require.NoError(t, json.Unmarshal(signPayload(t, secret, []byte(`{}`)), &signed))
+	require.EqualError(t, keys.Verify(signed), `unknown key "default"`)
+
+	err = keys.Add(TrustedKey{ID: "web3", Key: web3})
+	require.EqualError(t, err, `the key "web3" was revoked`)
+}
+
+func TestDecodeKey(t *testing.T) {
+	key := suite.Point().Pick(suite.RandomStream())
+
+	keyBuf, err := key.MarshalBinary()
+	require.NoError(t, err)
+
+	decoded, err := DecodeKey(hex.EncodeToString(keyBuf))
+	require.NoError(t, err)
+	require.True(t, key.Equal(decoded))
+
+	_, err = DecodeKey("xx")
+	require.ErrorContains(t, err, "failed to decode hex")
+}
+
+func newTestKeyring(t *testing.T, key kyber.Point) *Keyring {
+	keys, err := NewKeyring(TrustedKey{ID: DefaultKeyID, Key: key})
+	require.NoError(t, err)
+
+	return keys
+}
@@ -0,0 +1,65 @@
+package proxy
+
+import (
+	"net/http"
+
+	"github.com/c4dt/d-voting/contracts/evoting"
+	"github.com/c4dt/d-voting/contracts/evoting/types"
+	"github.com/c4dt/d-voting/proxy/txnmanager"
+	ptypes "github.com/c4dt/d-voting/proxy/types"
+	"golang.org/x/xerrors"
+)
+
+// GET /limits
+func (form *form) Limits(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "*")
+
+	parameters, err := types.ParametersFromStore(form.orderingSvc.GetStore(),
+		[]byte(evoting.ParametersKey))
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to get parameters: %v", err), nil)
+		return
+	}
+
+	txnmanager.SendResponse(w, parameters.Limits)
+}
+
+// PUT /limits
+func (form *form) SetLimits(w http.ResponseWriter, r *http.Request) {
+	var req ptypes.SetLimitsRequest
+
+	// get the signed request
+	signed, err := ptypes.SignedRequestOf(r)
+	if err != nil {
+		BadRequestError(w, r, newSignedErr(err), nil)
+		return
+	}
+
+	// get the request and verify the signature
+	err = form.keys.GetAndVerify(signed, &req)
+	if err != nil {
+		verifyErr(w, r, err)
+		return
+	}
+
+	setLimits := types.SetLimits{
+		Limits:           req.Limits,
+		PerformingUserID: req.PerformingUserID,
+	}
+
+	data, err := setLimits.Serialize(form.context)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to marshal SetLimits: %v", err), nil)
+		return
+	}
+
+	// create the transaction and add it to the pool
+	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdSetLimits, evoting.FormArg, data)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
+		return
+	}
+
+	form.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
+}
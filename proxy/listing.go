@@ -0,0 +1,313 @@
+package proxy
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/c4dt/d-voting/contracts/evoting/types"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// sortCreated lists the forms in the order they were created
+	sortCreated = "created"
+	// sortTitle lists the forms by their English title
+	sortTitle = "title"
+	// sortStatus lists the forms by their status
+	sortStatus = "status"
+)
+
+// formsQuery defines the filters, the order and the page of a forms listing
+type formsQuery struct {
+	// status, owner and voter filter the forms if they are not nil
+	status *types.Status
+	owner  *int
+	voter  *int
+	tenant string
+
+	sort string
+	desc bool
+
+	// limit is the maximum number of forms in the page, or 0 for all forms
+	limit  int
+	cursor *formsCursor
+}
+
+// formsCursor points to the last form of the previous page. It is given to
+// clients base64 encoded. The next page starts after the form if it still
+// exists, or after its key and position in the creation order otherwise.
+type formsCursor struct {
+	FormID   string
+	Key      string
+	Position int
+}
+
+// userFormsReader returns the IDs of the forms of the user, read from the
+// index of the forms by user kept by the contract.
+type userFormsReader func(index types.UserForms, sciper int) ([]string, error)
+
+// listedForm is a form with its position in the creation order and its
+// sort key
+type listedForm struct {
+	entry    types.FormIndexEntry
+	position int
+	key      string
+}
+
+// parseFormsQuery returns the query from the URL parameters "status",
+// "owner", "voter", "tenant", "sort", "order", "limit" and "cursor".
+func parseFormsQuery(values url.Values) (formsQuery, error) {
+	query := formsQuery{
+		tenant: values.Get("tenant"),
+		sort:   sortCreated,
+	}
+
+	if values.Get("status") != "" {
+		status, err := strconv.ParseUint(values.Get("status"), 10, 16)
+		if err != nil {
+			return query, xerrors.Errorf("invalid status: %v", err)
+		}
+
+		s := types.Status(status)
+		query.status = &s
+	}
+
+	for _, param := range []string{"owner", "voter"} {
+		if values.Get(param) == "" {
+			continue
+		}
+
+		sciper, err := types.SciperToInt(values.Get(param))
+		if err != nil {
+			return query, xerrors.Errorf("invalid %s: %v", param, err)
+		}
+
+		if param == "owner" {
+			query.owner = &sciper
+		} else {
+			query.voter = &sciper
+		}
+	}
+
+	switch values.Get("sort") {
+	case "", sortCreated:
+	case sortTitle, sortStatus:
+		query.sort = values.Get("sort")
+	default:
+		return query, xerrors.Errorf("unknown sort: %q", values.Get("sort"))
+	}
+
+	switch values.Get("order") {
+	case "", "asc":
+	case "desc":
+		query.desc = true
+	default:
+		return query, xerrors.Errorf("unknown order: %q", values.Get("order"))
+	}
+
+	if values.Get("limit") != "" {
+		limit, err := strconv.Atoi(values.Get("limit"))
+		if err != nil || limit < 1 {
+			return query, xerrors.Errorf("invalid limit: %q", values.Get("limit"))
+		}
+
+		query.limit = limit
+	}
+
+	if values.Get("cursor") != "" {
+		cursor, err := decodeFormsCursor(values.Get("cursor"))
+		if err != nil {
+			return query, xerrors.Errorf("invalid cursor: %v", err)
+		}
+
+		query.cursor = &cursor
+	}
+
+	return query, nil
+}
+
+// userForms returns the set of forms of the user filtered by the query for
+// the index, or nil if the query doesn't filter by this role.
+func (query formsQuery) userForms(index types.UserForms,
+	read userFormsReader) (map[string]struct{}, error) {
+
+	sciper := query.owner
+	if index == types.VoterForms {
+		sciper = query.voter
+	}
+
+	if sciper == nil {
+		return nil, nil
+	}
+
+	formIDs, err := read(index, *sciper)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read %s forms: %v", index, err)
+	}
+
+	forms := make(map[string]struct{}, len(formIDs))
+	for _, formID := range formIDs {
+		forms[formID] = struct{}{}
+	}
+
+	return forms, nil
+}
+
+// match returns true if the form passes the filters of the query, given the
+// forms of the owner and the voter the query filters by, if any.
+func (query formsQuery) match(entry types.FormIndexEntry, owned, voted map[string]struct{}) bool {
+	if query.status != nil && entry.Status != *query.status {
+		return false
+	}
+
+	if owned != nil {
+		_, found := owned[entry.FormID]
+		if !found {
+			return false
+		}
+	}
+
+	if voted != nil {
+		_, found := voted[entry.FormID]
+		if !found {
+			return false
+		}
+	}
+
+	return query.tenant == "" || entry.TenantID == query.tenant
+}
+
+// sortKey returns the key of the form in the order of the query
+func (query formsQuery) sortKey(entry types.FormIndexEntry) string {
+	switch query.sort {
+	case sortTitle:
+		return strings.ToLower(entry.Title.En)
+	case sortStatus:
+		return fmt.Sprintf("%05d", entry.Status)
+	default:
+		return ""
+	}
+}
+
+// before returns true if a is listed before b. Forms with the same key are
+// listed in the order they were created.
+func (query formsQuery) before(a, b formsCursor) bool {
+	if query.desc {
+		a, b = b, a
+	}
+
+	if a.Key != b.Key {
+		return a.Key < b.Key
+	}
+
+	return a.Position < b.Position
+}
+
+// listForms returns the page of forms of the query, given all the forms in
+// the order they were created, and the cursor of the next page if there is
+// one. The forms are filtered and sorted from their index entries and the
+// forms of the users, without reading the forms.
+func listForms(entries []types.FormIndexEntry, query formsQuery,
+	read userFormsReader) ([]types.FormIndexEntry, string, error) {
+
+	owned, err := query.userForms(types.OwnerForms, read)
+	if err != nil {
+		return nil, "", err
+	}
+
+	voted, err := query.userForms(types.VoterForms, read)
+	if err != nil {
+		return nil, "", err
+	}
+
+	listed := make([]listedForm, 0, len(entries))
+
+	for i, entry := range entries {
+		if !query.match(entry, owned, voted) {
+			continue
+		}
+
+		listed = append(listed, listedForm{
+			entry:    entry,
+			position: i,
+			key:      query.sortKey(entry),
+		})
+	}
+
+	sort.Slice(listed, func(i, j int) bool {
+		return query.before(listed[i].cursor(), listed[j].cursor())
+	})
+
+	// skip the forms up to the cursor
+	if query.cursor != nil {
+		// the positions shift down when forms are deleted, so a missing form
+		// is replaced by the next one
+		start := sort.Search(len(listed), func(i int) bool {
+			return !query.before(listed[i].cursor(), *query.cursor)
+		})
+
+		for i, form := range listed {
+			if form.entry.FormID == query.cursor.FormID {
+				start = i + 1
+				break
+			}
+		}
+
+		listed = listed[start:]
+	}
+
+	next := ""
+
+	if query.limit > 0 && len(listed) > query.limit {
+		listed = listed[:query.limit]
+
+		next, err = encodeFormsCursor(listed[len(listed)-1].cursor())
+		if err != nil {
+			return nil, "", xerrors.Errorf("failed to encode cursor: %v", err)
+		}
+	}
+
+	page := make([]types.FormIndexEntry, len(listed))
+	for i, form := range listed {
+		page[i] = form.entry
+	}
+
+	return page, next, nil
+}
+
+// cursor returns the cursor pointing to the form
+func (form listedForm) cursor() formsCursor {
+	return formsCursor{FormID: form.entry.FormID, Key: form.key, Position: form.position}
+}
+
+// encodeFormsCursor returns the cursor given to clients
+func encodeFormsCursor(cursor formsCursor) (string, error) {
+	cursorBuf, err := json.Marshal(cursor)
+	if err != nil {
+		return "", xerrors.Errorf("failed to marshal cursor: %v", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(cursorBuf), nil
+}
+
+// decodeFormsCursor returns the cursor given by a client
+func decodeFormsCursor(encoded string) (formsCursor, error) {
+	var cursor formsCursor
+
+	cursorBuf, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return cursor, xerrors.Errorf("failed to decode cursor: %v", err)
+	}
+
+	err = json.Unmarshal(cursorBuf, &cursor)
+	if err != nil {
+		return cursor, xerrors.Errorf("failed to unmarshal cursor: %v", err)
+	}
+
+	return cursor, nil
+}
@@ -0,0 +1,160 @@
+package proxy
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/c4dt/d-voting/contracts/evoting/types"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+)
+
+func TestParseFormsQuery(t *testing.T) {
+	query, err := parseFormsQuery(url.Values{})
+	require.NoError(t, err)
+	require.Equal(t, formsQuery{sort: sortCreated}, query)
+
+	query, err = parseFormsQuery(url.Values{
+		"status": {"1"},
+		"owner":  {"123456"},
+		"sort":   {"title"},
+		"order":  {"desc"},
+		"limit":  {"10"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, types.Open, *query.status)
+	require.Equal(t, 123456, *query.owner)
+	require.Nil(t, query.voter)
+	require.Equal(t, sortTitle, query.sort)
+	require.True(t, query.desc)
+	require.Equal(t, 10, query.limit)
+
+	_, err = parseFormsQuery(url.Values{"status": {"open"}})
+	require.ErrorContains(t, err, "invalid status")
+
+	_, err = parseFormsQuery(url.Values{"voter": {"abc"}})
+	require.ErrorContains(t, err, "invalid voter")
+
+	_, err = parseFormsQuery(url.Values{"sort": {"owner"}})
+	require.EqualError(t, err, `unknown sort: "owner"`)
+
+	_, err = parseFormsQuery(url.Values{"limit": {"0"}})
+	require.EqualError(t, err, `invalid limit: "0"`)
+
+	_, err = parseFormsQuery(url.Values{"cursor": {"???"}})
+	require.ErrorContains(t, err, "invalid cursor")
+}
+
+func TestListForms(t *testing.T) {
+	entries := []types.FormIndexEntry{
+		{FormID: "a", Title: types.Title{En: "Charlie"}, Status: types.Open, OwnerCount: 1},
+		{FormID: "b", Title: types.Title{En: "alpha"}, Status: types.Closed},
+		{FormID: "c", Title: types.Title{En: "Bravo"}, Status: types.Open, TenantID: "t"},
+		{FormID: "d", Title: types.Title{En: "delta"}, Status: types.Initial},
+	}
+
+	userForms := map[types.UserForms]map[int][]string{
+		types.OwnerForms: {1: {"a"}},
+		types.VoterForms: {2: {"d", "b"}},
+	}
+
+	read := 0
+
+	load := func(index types.UserForms, sciper int) ([]string, error) {
+		read++
+		return userForms[index][sciper], nil
+	}
+
+	ids := func(page []types.FormIndexEntry) []string {
+		res := make([]string, len(page))
+		for i, entry := range page {
+			res[i] = entry.FormID
+		}
+		return res
+	}
+
+	page, next, err := listForms(entries, formsQuery{sort: sortCreated}, load)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "c", "d"}, ids(page))
+	require.Empty(t, next)
+
+	open := types.Open
+	page, _, err = listForms(entries, formsQuery{sort: sortCreated, status: &open}, load)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "c"}, ids(page))
+
+	// the forms of the users are only read to filter by role
+	require.Equal(t, 0, read)
+
+	voter := 2
+	page, _, err = listForms(entries, formsQuery{sort: sortCreated, voter: &voter}, load)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "d"}, ids(page))
+	require.Equal(t, 1, read)
+
+	owner := 1
+	page, _, err = listForms(entries, formsQuery{sort: sortCreated, owner: &owner, voter: &voter}, load)
+	require.NoError(t, err)
+	require.Empty(t, page)
+
+	owner = 3
+	page, _, err = listForms(entries, formsQuery{sort: sortCreated, owner: &owner}, load)
+	require.NoError(t, err)
+	require.Empty(t, page)
+
+	_, _, err = listForms(entries, formsQuery{sort: sortCreated, voter: &voter},
+		func(index types.UserForms, sciper int) ([]string, error) {
+			return nil, xerrors.Errorf("oops")
+		})
+	require.EqualError(t, err, "failed to read voter forms: oops")
+
+	page, _, err = listForms(entries, formsQuery{sort: sortCreated, tenant: "t"}, load)
+	require.NoError(t, err)
+	require.Equal(t, []string{"c"}, ids(page))
+
+	page, _, err = listForms(entries, formsQuery{sort: sortTitle}, load)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "c", "a", "d"}, ids(page))
+
+	// forms with the same status keep the order of creation
+	page, _, err = listForms(entries, formsQuery{sort: sortStatus, desc: true}, load)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "c", "a", "d"}, ids(page))
+
+	// paginate through the forms sorted by title
+	query := formsQuery{sort: sortTitle, limit: 3}
+
+	page, next, err = listForms(entries, query, load)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "c", "a"}, ids(page))
+	require.NotEmpty(t, next)
+
+	cursor, err := decodeFormsCursor(next)
+	require.NoError(t, err)
+	query.cursor = &cursor
+
+	page, next, err = listForms(entries, query, load)
+	require.NoError(t, err)
+	require.Equal(t, []string{"d"}, ids(page))
+	require.Empty(t, next)
+
+	// the cursor still works once older forms are deleted
+	query = formsQuery{sort: sortCreated, limit: 2}
+
+	page, next, err = listForms(entries, query, load)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, ids(page))
+
+	cursor, err = decodeFormsCursor(next)
+	require.NoError(t, err)
+	query.cursor = &cursor
+
+	page, _, err = listForms(entries[1:], query, load)
+	require.NoError(t, err)
+	require.Equal(t, []string{"c", "d"}, ids(page))
+
+	// or once the form it points to is deleted
+	page, _, err = listForms([]types.FormIndexEntry{entries[0], entries[2], entries[3]}, query, load)
+	require.NoError(t, err)
+	require.Equal(t, []string{"c", "d"}, ids(page))
+}
@@ -7,12 +7,11 @@
 package proxy
 
 import (
-	"encoding/json"
-	"fmt"
 	"net/http"
 
 	"github.com/c4dt/d-voting/proxy/types"
 	"go.dedis.ch/kyber/v3/suites"
+	"golang.org/x/xerrors"
 )
 
 var suite = suites.MustFind("ed25519")
@@ -21,14 +20,28 @@ var suite = suites.MustFind("ed25519")
 type Form interface {
 	// POST /forms
 	NewForm(http.ResponseWriter, *http.Request)
+	// POST /forms/{formID}/runoff
+	NewFormRunoff(http.ResponseWriter, *http.Request)
 	// POST /forms/{formID}/vote
 	NewFormVote(http.ResponseWriter, *http.Request)
+	// DELETE /forms/{formID}/vote
+	WithdrawFormVote(http.ResponseWriter, *http.Request)
+	// POST /forms/{formID}/delegation
+	NewFormDelegation(http.ResponseWriter, *http.Request)
+	// DELETE /forms/{formID}/delegation
+	RevokeFormDelegation(http.ResponseWriter, *http.Request)
 	// PUT /forms/{formID}
 	EditForm(http.ResponseWriter, *http.Request)
-	// GET /forms
+	// GET /forms?status=&owner=&voter=&tenant=&sort=&order=&limit=&cursor=
 	Forms(http.ResponseWriter, *http.Request)
 	// GET /forms/{formID}
 	Form(http.ResponseWriter, *http.Request)
+	// GET /forms/{formID}/summary
+	FormSummary(http.ResponseWriter, *http.Request)
+	// GET /forms/{formID}/history
+	FormHistory(http.ResponseWriter, *http.Request)
+	// GET /forms/{formID}/stream
+	FormStream(http.ResponseWriter, *http.Request)
 	// DELETE /forms/{formID}
 	DeleteForm(http.ResponseWriter, *http.Request)
 	// TODO CHECK CAUSE NEW -> modif according to blockchain
@@ -38,6 +51,28 @@ type Form interface {
 	RemoveAdmin(http.ResponseWriter, *http.Request)
 	// GET /adminlist
 	AdminList(http.ResponseWriter, *http.Request)
+	// GET /limits
+	Limits(http.ResponseWriter, *http.Request)
+	// PUT /limits
+	SetLimits(http.ResponseWriter, *http.Request)
+	// GET /adminproposals
+	AdminProposals(http.ResponseWriter, *http.Request)
+	// POST /adminproposals
+	NewAdminProposal(http.ResponseWriter, *http.Request)
+	// POST /adminproposals/{proposalID}/approve
+	ApproveAdminProposal(http.ResponseWriter, *http.Request)
+	// POST /adminproposals/{proposalID}/reject
+	RejectAdminProposal(http.ResponseWriter, *http.Request)
+	// POST /tenants
+	NewTenant(http.ResponseWriter, *http.Request)
+	// GET /tenants
+	Tenants(http.ResponseWriter, *http.Request)
+	// POST /tenants/{tenantID}/addadmin
+	AddTenantAdmin(http.ResponseWriter, *http.Request)
+	// POST /tenants/{tenantID}/removeadmin
+	RemoveTenantAdmin(http.ResponseWriter, *http.Request)
+	// GET /tenants/{tenantID}/adminlist
+	TenantAdminList(http.ResponseWriter, *http.Request)
 	// POST /forms/{formID}/addowner
 	AddOwnerToForm(http.ResponseWriter, *http.Request)
 	// POST /forms/{formID}/removeowner
@@ -46,6 +81,16 @@ type Form interface {
 	AddVoterToForm(http.ResponseWriter, *http.Request)
 	// POST /forms/{formID}/removevoter
 	RemoveVoterToForm(http.ResponseWriter, *http.Request)
+	// POST /forms/{formID}/addrole
+	AddRoleToForm(http.ResponseWriter, *http.Request)
+	// POST /forms/{formID}/removerole
+	RemoveRoleToForm(http.ResponseWriter, *http.Request)
+	// GET /forms/{formID}/verification
+	FormVerification(http.ResponseWriter, *http.Request)
+	// POST /forms/{formID}/verify
+	VerifyForm(http.ResponseWriter, *http.Request)
+	// GET /forms/{formID}/status
+	FormStatus(http.ResponseWriter, *http.Request)
 }
 
 // DKG defines the public HTTP API of the DKG service
@@ -66,86 +111,38 @@ type Shuffle interface {
 
 // NotFoundHandler defines a generic handler for 404
 func NotFoundHandler(w http.ResponseWriter, r *http.Request) {
-	err := types.HTTPError{
-		Title:   "Not found",
-		Code:    http.StatusNotFound,
-		Message: "The requested endpoint was not found",
-		Args: map[string]interface{}{
-			"url":    r.URL.String(),
-			"method": r.Method,
-		},
-	}
-
-	buf, _ := json.MarshalIndent(&err, "", "  ")
-
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.Header().Set("X-Content-Type-Options", "nosniff")
-	w.WriteHeader(http.StatusNotFound)
-	fmt.Fprintln(w, string(buf))
+	ErrorResponse(w, r, types.ErrNotFound, xerrors.Errorf("the requested endpoint was not found"), nil)
 }
 
 // NotAllowedHandler degines a generic handler for 405
 func NotAllowedHandler(w http.ResponseWriter, r *http.Request) {
-	err := types.HTTPError{
-		Title:   "Not allowed",
-		Code:    http.StatusMethodNotAllowed,
-		Message: "The requested endpoint was not allowed",
-		Args: map[string]interface{}{
-			"url":    r.URL.String(),
-			"method": r.Method,
-		},
-	}
-
-	buf, _ := json.MarshalIndent(&err, "", "  ")
-
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.Header().Set("X-Content-Type-Options", "nosniff")
-	w.WriteHeader(http.StatusMethodNotAllowed)
-	fmt.Fprintln(w, string(buf))
+	ErrorResponse(w, r, types.ErrMethodNotAllowed, xerrors.Errorf("the requested endpoint was not allowed"), nil)
 }
 
 // InternalError sets an internal server error
 func InternalError(w http.ResponseWriter, r *http.Request, err error, args map[string]interface{}) {
-	httpErr(w, r, err, http.StatusInternalServerError, "Internal server error", args)
+	ErrorResponse(w, r, types.ErrInternal, err, args)
 }
 
-// BadRequestError sets an bad request error
+// BadRequestError sets a bad request error
 func BadRequestError(w http.ResponseWriter, r *http.Request, err error, args map[string]interface{}) {
-	httpErr(w, r, err, http.StatusBadRequest, "bad request", args)
+	ErrorResponse(w, r, types.ErrBadRequest, err, args)
 }
 
 // ForbiddenError sets a forbidden error error
 func ForbiddenError(w http.ResponseWriter, r *http.Request, err error, args map[string]interface{}) {
-	httpErr(w, r, err, http.StatusForbidden, "not authorized / forbidden", args)
+	ErrorResponse(w, r, types.ErrForbidden, err, args)
 }
 
 // NotFoundErr sets a not found error
 func NotFoundErr(w http.ResponseWriter, r *http.Request, err error, args map[string]interface{}) {
-	httpErr(w, r, err, http.StatusNotFound, "not found", args)
+	ErrorResponse(w, r, types.ErrNotFound, err, args)
 }
 
-func httpErr(w http.ResponseWriter, r *http.Request, err error, code uint, title string, args map[string]interface{}) {
-	if args == nil {
-		args = make(map[string]interface{})
-	}
-
-	args["error"] = err.Error()
-	args["url"] = r.URL.String()
-	args["method"] = r.Method
-
-	errMsg := types.HTTPError{
-		Title:   title,
-		Code:    code,
-		Message: "A problem occurred on the proxy",
-		Args:    args,
-	}
-
-	buf, _ := json.MarshalIndent(&errMsg, "", "  ")
-
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.Header().Set("X-Content-Type-Options", "nosniff")
-	w.WriteHeader(int(code))
-	fmt.Fprintln(w, string(buf))
+// ErrorResponse sets an error with the given code, whose HTTP status is the
+// one of the code.
+func ErrorResponse(w http.ResponseWriter, r *http.Request, code types.ErrorCode, err error, args map[string]interface{}) {
+	types.NewHTTPError(r, code, err, args).Send(w)
 }
 
 // AllowCORS defines a basic handler that adds wide Access Control Allow origin
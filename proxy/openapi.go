@@ -0,0 +1,386 @@
+package proxy
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+
+	etypes "github.com/c4dt/d-voting/contracts/evoting/types"
+	"github.com/c4dt/d-voting/proxy/txnmanager"
+	ptypes "github.com/c4dt/d-voting/proxy/types"
+)
+
+const openAPIVersion = "3.0.3"
+
+// apiOperation describes an endpoint of the proxy. The request and response
+// are zero values of the types encoded in the body, or nil if there is no
+// body.
+type apiOperation struct {
+	method  string
+	path    string
+	summary string
+	// signed is true if the request is a SignedRequest whose payload is the
+	// request
+	signed   bool
+	request  interface{}
+	response interface{}
+	// query lists the query parameters
+	query []string
+	// header is the payload of the SignedRequest that can be given in the
+	// Signed-Request header of a request without a body
+	header interface{}
+	// stream is true if the response is a stream of server-sent events
+	stream bool
+}
+
+// apiOperations lists the endpoints served by the proxy. The OpenAPI document
+// is generated from it and the proxy types. It must be kept in line with the
+// routes registered by the controllers.
+var apiOperations = []apiOperation{
+	{method: "POST", path: "/evoting/addadmin", summary: "Propose to add an admin",
+		signed: true, request: ptypes.PermissionOperationRequest{}, response: txnmanager.TransactionClientInfo{}},
+	{method: "POST", path: "/evoting/removeadmin", summary: "Propose to remove an admin",
+		signed: true, request: ptypes.PermissionOperationRequest{}, response: txnmanager.TransactionClientInfo{}},
+	{method: "GET", path: "/evoting/adminlist", summary: "Get the admin list",
+		response: ""},
+	{method: "GET", path: "/evoting/limits", summary: "Get the resource limits",
+		response: etypes.Limits{}},
+	{method: "PUT", path: "/evoting/limits", summary: "Set the resource limits",
+		signed: true, request: ptypes.SetLimitsRequest{}, response: txnmanager.TransactionClientInfo{}},
+	{method: "GET", path: "/evoting/adminproposals", summary: "Get the admin proposals",
+		response: ptypes.GetAdminProposalsResponse{}},
+	{method: "POST", path: "/evoting/adminproposals", summary: "Propose an admin change",
+		signed: true, request: ptypes.ProposeAdminChangeRequest{}, response: txnmanager.TransactionClientInfo{}},
+	{method: "POST", path: "/evoting/adminproposals/{proposalID}/approve", summary: "Approve an admin proposal",
+		signed: true, request: ptypes.AdminProposalVoteRequest{}, response: txnmanager.TransactionClientInfo{}},
+	{method: "POST", path: "/evoting/adminproposals/{proposalID}/reject", summary: "Reject an admin proposal",
+		signed: true, request: ptypes.AdminProposalVoteRequest{}, response: txnmanager.TransactionClientInfo{}},
+	{method: "POST", path: "/evoting/tenants", summary: "Create a tenant",
+		signed: true, request: ptypes.CreateTenantRequest{}, response: txnmanager.TransactionClientInfo{}},
+	{method: "GET", path: "/evoting/tenants", summary: "Get the tenants",
+		response: ptypes.GetTenantsResponse{}},
+	{method: "POST", path: "/evoting/tenants/{tenantID}/addadmin", summary: "Add an admin to a tenant",
+		signed: true, request: ptypes.PermissionOperationRequest{}, response: txnmanager.TransactionClientInfo{}},
+	{method: "POST", path: "/evoting/tenants/{tenantID}/removeadmin", summary: "Remove an admin from a tenant",
+		signed: true, request: ptypes.PermissionOperationRequest{}, response: txnmanager.TransactionClientInfo{}},
+	{method: "GET", path: "/evoting/tenants/{tenantID}/adminlist", summary: "Get the admins of a tenant",
+		response: []int{}},
+	{method: "POST", path: "/evoting/forms", summary: "Create a form",
+		signed: true, request: ptypes.CreateFormRequest{}, response: ptypes.CreateFormResponse{}},
+	{method: "GET", path: "/evoting/forms", summary: "Get the forms",
+		query:    []string{"status", "owner", "voter", "tenant", "sort", "order", "limit", "cursor"},
+		response: ptypes.GetFormsResponse{}},
+	{method: "GET", path: "/evoting/forms/{formID}", summary: "Get a form",
+		response: ptypes.GetFormResponse{}, header: ptypes.ReadFormRequest{}},
+	{method: "PUT", path: "/evoting/forms/{formID}", summary: "Update the status of a form",
+		signed: true, request: ptypes.UpdateFormRequest{}, response: txnmanager.TransactionClientInfo{}},
+	{method: "DELETE", path: "/evoting/forms/{formID}", summary: "Delete a form",
+		signed: true, request: ptypes.DeleteFormRequest{}, response: txnmanager.TransactionClientInfo{}},
+	{method: "GET", path: "/evoting/forms/{formID}/history", summary: "Get the history of a form",
+		response: ptypes.GetFormHistoryResponse{}},
+	{method: "GET", path: "/evoting/forms/{formID}/summary", summary: "Get the summary of a form",
+		response: etypes.FormIndexEntry{}, header: ptypes.ReadFormRequest{}},
+	{method: "GET", path: "/evoting/forms/{formID}/stream", summary: "Stream the updates of a form",
+		header: ptypes.ReadFormRequest{}, response: ptypes.FormUpdate{}, stream: true},
+	{method: "POST", path: "/evoting/forms/{formID}/addowner", summary: "Add an owner to a form",
+		signed: true, request: ptypes.PermissionOperationRequest{}, response: txnmanager.TransactionClientInfo{}},
+	{method: "POST", path: "/evoting/forms/{formID}/removeowner", summary: "Remove an owner from a form",
+		signed: true, request: ptypes.PermissionOperationRequest{}, response: txnmanager.TransactionClientInfo{}},
+	{method: "POST", path: "/evoting/forms/{formID}/addvoter", summary: "Add a voter to a form",
+		signed: true, request: ptypes.PermissionOperationRequest{}, response: txnmanager.TransactionClientInfo{}},
+	{method: "POST", path: "/evoting/forms/{formID}/removevoter", summary: "Remove a voter from a form",
+		signed: true, request: ptypes.PermissionOperationRequest{}, response: txnmanager.TransactionClientInfo{}},
+	{method: "POST", path: "/evoting/forms/{formID}/addrole", summary: "Give a role on a form",
+		signed: true, request: ptypes.RoleOperationRequest{}, response: txnmanager.TransactionClientInfo{}},
+	{method: "POST", path: "/evoting/forms/{formID}/removerole", summary: "Remove a role on a form",
+		signed: true, request: ptypes.RoleOperationRequest{}, response: txnmanager.TransactionClientInfo{}},
+	{method: "GET", path: "/evoting/forms/{formID}/verification", summary: "Get the verification data of a form",
+		response: ptypes.FormVerificationResponse{}},
+	{method: "POST", path: "/evoting/forms/{formID}/verify", summary: "Verify the tally of a form",
+		signed: true, request: ptypes.VerifyFormRequest{}, response: txnmanager.TransactionClientInfo{}},
+	{method: "GET", path: "/evoting/forms/{formID}/status", summary: "Get the live status of a form",
+		response: ptypes.FormStatusResponse{}, header: ptypes.ReadFormRequest{}},
+	{method: "POST", path: "/evoting/forms/{formID}/runoff", summary: "Create a runoff of a form",
+		signed: true, request: ptypes.CreateRunoffRequest{}, response: ptypes.CreateFormResponse{}},
+	{method: "POST", path: "/evoting/forms/{formID}/vote", summary: "Cast a vote",
+		signed: true, request: ptypes.CastVoteRequest{}, response: txnmanager.TransactionClientInfo{}},
+	{method: "DELETE", path: "/evoting/forms/{formID}/vote", summary: "Withdraw a vote",
+		signed: true, request: ptypes.WithdrawVoteRequest{}, response: txnmanager.TransactionClientInfo{}},
+	{method: "POST", path: "/evoting/forms/{formID}/delegation", summary: "Delegate a vote",
+		signed: true, request: ptypes.DelegateVoteRequest{}, response: txnmanager.TransactionClientInfo{}},
+	{method: "DELETE", path: "/evoting/forms/{formID}/delegation", summary: "Revoke the delegation of a vote",
+		signed: true, request: ptypes.RevokeDelegationRequest{}, response: txnmanager.TransactionClientInfo{}},
+	{method: "GET", path: "/evoting/transactions/{token}", summary: "Check the inclusion of a transaction",
+		response: txnmanager.TransactionClientInfo{}},
+	{method: "POST", path: "/evoting/services/dkg/actors", summary: "Create a DKG actor",
+		signed: true, request: ptypes.NewDKGRequest{}},
+	{method: "GET", path: "/evoting/services/dkg/actors/{formID}", summary: "Get a DKG actor",
+		response: ptypes.GetActorInfo{}},
+	{method: "PUT", path: "/evoting/services/dkg/actors/{formID}", summary: "Setup the DKG or compute the public shares",
+		signed: true, request: ptypes.UpdateDKG{}},
+	{method: "PUT", path: "/evoting/services/shuffle/{formID}", summary: "Shuffle the ballots",
+		signed: true, request: ptypes.UpdateShuffle{}},
+}
+
+var pathParam = regexp.MustCompile(`{([^}]+)}`)
+
+// Operations returns the documented endpoints as "METHOD path". The
+// controllers check it against the routes they register.
+func Operations() []string {
+	operations := make([]string, len(apiOperations))
+
+	for i, op := range apiOperations {
+		operations[i] = op.method + " " + op.path
+	}
+
+	return operations
+}
+
+// OpenAPI serves the OpenAPI document of the proxy
+//
+// GET /openapi.json
+func OpenAPI(w http.ResponseWriter, r *http.Request) {
+	txnmanager.SendResponse(w, newOpenAPIDocument(apiOperations))
+}
+
+// newOpenAPIDocument returns the OpenAPI document of the operations, with the
+// schemas of their types.
+func newOpenAPIDocument(operations []apiOperation) map[string]interface{} {
+	schemas := newOpenAPISchemas()
+	errorSchema := schemas.schemaOf(reflect.TypeOf(ptypes.HTTPError{}))
+
+	paths := make(map[string]interface{})
+
+	for _, op := range operations {
+		item, ok := paths[op.path].(map[string]interface{})
+		if !ok {
+			item = make(map[string]interface{})
+			paths[op.path] = item
+		}
+
+		operation := map[string]interface{}{
+			"summary": op.summary,
+		}
+
+		var parameters []interface{}
+
+		for _, match := range pathParam.FindAllStringSubmatch(op.path, -1) {
+			parameters = append(parameters, map[string]interface{}{
+				"name":     match[1],
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]interface{}{"type": "string"},
+			})
+		}
+
+		for _, name := range op.query {
+			parameters = append(parameters, map[string]interface{}{
+				"name":   name,
+				"in":     "query",
+				"schema": map[string]interface{}{"type": "string"},
+			})
+		}
+
+		if op.header != nil {
+			parameters = append(parameters, map[string]interface{}{
+				"name": ptypes.SignedRequestHeader,
+				"in":   "header",
+				"schema": map[string]interface{}{
+					"allOf":     []interface{}{schemas.schemaOf(reflect.TypeOf(ptypes.SignedRequest{}))},
+					"x-payload": schemas.schemaOf(reflect.TypeOf(op.header)),
+				},
+			})
+		}
+
+		if len(parameters) > 0 {
+			operation["parameters"] = parameters
+		}
+
+		if op.request != nil {
+			requestSchema := schemas.schemaOf(reflect.TypeOf(op.request))
+
+			if op.signed {
+				// the payload is the base64 encoded JSON of the request
+				requestSchema = map[string]interface{}{
+					"allOf":     []interface{}{schemas.schemaOf(reflect.TypeOf(ptypes.SignedRequest{}))},
+					"x-payload": requestSchema,
+				}
+			}
+
+			operation["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content":  jsonContent(requestSchema),
+			}
+		}
+
+		ok200 := map[string]interface{}{"description": "OK"}
+
+		if op.response != nil {
+			responseSchema := schemas.schemaOf(reflect.TypeOf(op.response))
+
+			if op.stream {
+				ok200["content"] = map[string]interface{}{
+					"text/event-stream": map[string]interface{}{"schema": responseSchema},
+				}
+			} else {
+				ok200["content"] = jsonContent(responseSchema)
+			}
+		}
+
+		operation["responses"] = map[string]interface{}{
+			"200": ok200,
+			"default": map[string]interface{}{
+				"description": "Error",
+				"content":     jsonContent(errorSchema),
+			},
+		}
+
+		item[strings.ToLower(op.method)] = operation
+	}
+
+	return map[string]interface{}{
+		"openapi": openAPIVersion,
+		"info": map[string]interface{}{
+			"title":   "D-Voting proxy",
+			"version": "1.0.0",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas.schemas,
+		},
+	}
+}
+
+func jsonContent(schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"application/json": map[string]interface{}{"schema": schema},
+	}
+}
+
+// openAPISchemas builds the schemas of Go types as they are encoded by
+// encoding/json. Named structs are added to the components and referenced.
+type openAPISchemas struct {
+	schemas map[string]interface{}
+	names   map[reflect.Type]string
+}
+
+func newOpenAPISchemas() openAPISchemas {
+	return openAPISchemas{
+		schemas: make(map[string]interface{}),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+func (s openAPISchemas) schemaOf(t reflect.Type) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return map[string]interface{}{
+			"allOf":    []interface{}{s.schemaOf(t.Elem())},
+			"nullable": true,
+		}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.structSchema(t)
+		}
+
+		return map[string]interface{}{"$ref": "#/components/schemas/" + s.componentName(t)}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+
+		return map[string]interface{}{"type": "array", "items": s.schemaOf(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": s.schemaOf(t.Elem())}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	default:
+		// any value
+		return map[string]interface{}{}
+	}
+}
+
+// componentName returns the name of the struct in the components, adding its
+// schema if needed. The name is qualified by the package if it is already
+// used by another type.
+func (s openAPISchemas) componentName(t reflect.Type) string {
+	name, found := s.names[t]
+	if found {
+		return name
+	}
+
+	name = t.Name()
+	if _, taken := s.schemas[name]; taken {
+		pkg := t.PkgPath()
+		name = pkg[strings.LastIndex(pkg, "/")+1:] + "." + name
+	}
+
+	// registered before the fields to support recursive types
+	s.names[t] = name
+	s.schemas[name] = nil
+	s.schemas[name] = s.structSchema(t)
+
+	return name
+}
+
+// structSchema returns the schema of a struct, following the json tags.
+// Fields without omitempty are always encoded and are therefore required.
+func (s openAPISchemas) structSchema(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	required := []string{}
+
+	s.addFields(t, properties, &required)
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	return schema
+}
+
+func (s openAPISchemas) addFields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			// the fields of embedded structs are promoted
+			s.addFields(field.Type, properties, required)
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = s.schemaOf(field.Type)
+
+		if !strings.Contains(options, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
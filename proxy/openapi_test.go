@@ -0,0 +1,73 @@
+package proxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPI(t *testing.T) {
+	rec := httptest.NewRecorder()
+	OpenAPI(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var doc struct {
+		OpenAPI string
+		Paths   map[string]map[string]struct {
+			Parameters []struct {
+				Name string
+				In   string
+			}
+			RequestBody struct {
+				Content map[string]struct {
+					Schema map[string]interface{}
+				}
+			}
+		}
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]interface{}
+				Required   []string
+			}
+		}
+	}
+
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	require.Equal(t, openAPIVersion, doc.OpenAPI)
+
+	for _, op := range apiOperations {
+		require.Contains(t, doc.Paths[op.path], strings.ToLower(op.method), op.path)
+	}
+
+	vote := doc.Paths["/evoting/forms/{formID}/vote"]["post"]
+	require.Equal(t, "formID", vote.Parameters[0].Name)
+	require.Equal(t, "path", vote.Parameters[0].In)
+
+	schema := vote.RequestBody.Content["application/json"].Schema
+	require.Equal(t, map[string]interface{}{"$ref": "#/components/schemas/CastVoteRequest"},
+		schema["x-payload"])
+
+	// the owners and observers of a private form sign their reads in a header
+	status := doc.Paths["/evoting/forms/{formID}/status"]["get"]
+	require.Equal(t, "Signed-Request", status.Parameters[1].Name)
+	require.Equal(t, "header", status.Parameters[1].In)
+
+	// the schemas follow the json tags of the proxy types
+	createForm := doc.Components.Schemas["CreateFormRequest"]
+	require.Contains(t, createForm.Properties, "UserID")
+	require.Contains(t, createForm.Properties, "Configuration")
+	require.Contains(t, createForm.Properties, "TenantID")
+	require.Equal(t, []string{"UserID", "Configuration"}, createForm.Required)
+
+	// embedded structs are flattened
+	proposal := doc.Components.Schemas["AdminProposalInfo"]
+	require.Contains(t, proposal.Properties, "Expired")
+	require.Contains(t, proposal.Properties, "ID")
+
+	require.Contains(t, doc.Components.Schemas, "Configuration")
+	require.Contains(t, doc.Components.Schemas, "HTTPError")
+}
@@ -0,0 +1,185 @@
+package proxy
+
+import (
+	"net/http"
+
+	"github.com/c4dt/d-voting/contracts/evoting"
+	"github.com/c4dt/d-voting/contracts/evoting/types"
+	"github.com/c4dt/d-voting/proxy/txnmanager"
+	ptypes "github.com/c4dt/d-voting/proxy/types"
+	"github.com/gorilla/mux"
+	"golang.org/x/xerrors"
+)
+
+// GET /adminproposals
+func (form *form) AdminProposals(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "*")
+
+	proposals, err := types.AdminProposalsFromStore(form.orderingSvc.GetStore(),
+		[]byte(evoting.AdminProposalsKey))
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to get proposals: %v", err), nil)
+		return
+	}
+
+	// the next block is the first one in which expired proposals can't be
+	// approved
+	height := form.blocks.Len()
+
+	infos := make([]ptypes.AdminProposalInfo, len(proposals.Proposals))
+	for i, proposal := range proposals.Proposals {
+		infos[i] = ptypes.AdminProposalInfo{
+			AdminProposal: proposal,
+			Expired:       proposal.IsExpired(height),
+		}
+	}
+
+	response := ptypes.GetAdminProposalsResponse{
+		Threshold: proposals.Threshold,
+		Proposals: infos,
+	}
+
+	txnmanager.SendResponse(w, response)
+}
+
+// POST /adminproposals
+func (form *form) NewAdminProposal(w http.ResponseWriter, r *http.Request) {
+	var req ptypes.ProposeAdminChangeRequest
+
+	// get the signed request
+	signed, err := ptypes.SignedRequestOf(r)
+	if err != nil {
+		BadRequestError(w, r, newSignedErr(err), nil)
+		return
+	}
+
+	// get the request and verify the signature
+	err = form.keys.GetAndVerify(signed, &req)
+	if err != nil {
+		verifyErr(w, r, err)
+		return
+	}
+
+	form.submitAdminProposal(w, r, types.ProposalAction(req.Action), req.TargetUserID,
+		req.Threshold, req.PerformingUserID, req.Lifetime)
+}
+
+// POST /adminproposals/{proposalID}/approve
+func (form *form) ApproveAdminProposal(w http.ResponseWriter, r *http.Request) {
+	req, proposalID, ok := form.getAdminProposalVote(w, r)
+	if !ok {
+		return
+	}
+
+	approve := types.ApproveAdminProposal{
+		ProposalID:       proposalID,
+		PerformingUserID: req.PerformingUserID,
+	}
+
+	data, err := approve.Serialize(form.context)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to marshal ApproveAdminProposal: %v", err), nil)
+		return
+	}
+
+	// create the transaction and add it to the pool
+	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdApproveAdminProposal, evoting.FormArg, data)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
+		return
+	}
+
+	form.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
+}
+
+// POST /adminproposals/{proposalID}/reject
+func (form *form) RejectAdminProposal(w http.ResponseWriter, r *http.Request) {
+	req, proposalID, ok := form.getAdminProposalVote(w, r)
+	if !ok {
+		return
+	}
+
+	reject := types.RejectAdminProposal{
+		ProposalID:       proposalID,
+		PerformingUserID: req.PerformingUserID,
+	}
+
+	data, err := reject.Serialize(form.context)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to marshal RejectAdminProposal: %v", err), nil)
+		return
+	}
+
+	// create the transaction and add it to the pool
+	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdRejectAdminProposal, evoting.FormArg, data)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
+		return
+	}
+
+	form.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
+}
+
+// submitAdminProposal submits a proposal to change the admin list, which
+// expires after the given number of blocks, or the default if it is 0.
+func (form *form) submitAdminProposal(w http.ResponseWriter, r *http.Request,
+	action types.ProposalAction, targetUserID string, threshold int,
+	performingUserID string, lifetime uint64) {
+
+	propose := types.ProposeAdminChange{
+		Action:           action,
+		TargetUserID:     targetUserID,
+		Threshold:        threshold,
+		PerformingUserID: performingUserID,
+		Lifetime:         lifetime,
+	}
+
+	data, err := propose.Serialize(form.context)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to marshal ProposeAdminChange: %v", err), nil)
+		return
+	}
+
+	// create the transaction and add it to the pool
+	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdProposeAdminChange, evoting.FormArg, data)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
+		return
+	}
+
+	form.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
+}
+
+// getAdminProposalVote returns the signed request approving or rejecting a
+// proposal, and the ID of the proposal. It writes the error and returns false
+// otherwise.
+func (form *form) getAdminProposalVote(w http.ResponseWriter,
+	r *http.Request) (ptypes.AdminProposalVoteRequest, string, bool) {
+
+	var req ptypes.AdminProposalVoteRequest
+
+	vars := mux.Vars(r)
+
+	// check if the proposalID is valid
+	if vars == nil || vars["proposalID"] == "" {
+		InternalError(w, r, xerrors.Errorf("proposalID not found: %v", vars), nil)
+		return req, "", false
+	}
+
+	// get the signed request
+	signed, err := ptypes.SignedRequestOf(r)
+	if err != nil {
+		BadRequestError(w, r, newSignedErr(err), nil)
+		return req, "", false
+	}
+
+	// get the request and verify the signature
+	err = form.keys.GetAndVerify(signed, &req)
+	if err != nil {
+		verifyErr(w, r, err)
+		return req, "", false
+	}
+
+	return req, vars["proposalID"], true
+}
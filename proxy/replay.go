@@ -0,0 +1,204 @@
+package proxy
+
+import (
+	"container/heap"
+	"net/http"
+	"sync"
+	"time"
+
+	ptypes "github.com/c4dt/d-voting/proxy/types"
+	"github.com/gorilla/mux"
+	"go.dedis.ch/dela/cli/node"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// DefaultReplayWindow is how far the timestamp of a signed request can be
+	// from the time of the proxy
+	DefaultReplayWindow = 5 * time.Minute
+	// DefaultNonceCapacity is the number of nonces remembered by a guard
+	DefaultNonceCapacity = 100000
+)
+
+// ReplayGuard rejects the signed requests that are stale or whose nonce was
+// already received. A nonce is remembered until its request is stale. When
+// capacity nonces are remembered, the oldest one is forgotten and the requests
+// that are not newer are rejected, so that it still can't be replayed.
+type ReplayGuard struct {
+	sync.Mutex
+
+	window   time.Duration
+	capacity int
+	now      func() time.Time
+
+	nonces map[string]struct{}
+	// queue holds the nonces ordered by timestamp, which is the order in
+	// which their requests become stale
+	queue nonceQueue
+	// floor is the timestamp of the latest nonce forgotten before its request
+	// was stale
+	floor int64
+}
+
+type rememberedNonce struct {
+	nonce     string
+	timestamp int64
+}
+
+// nonceQueue is a min-heap of nonces by timestamp.
+//
+// - implements heap.Interface
+type nonceQueue []rememberedNonce
+
+func (q nonceQueue) Len() int           { return len(q) }
+func (q nonceQueue) Less(i, j int) bool { return q[i].timestamp < q[j].timestamp }
+func (q nonceQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *nonceQueue) Push(x interface{}) {
+	*q = append(*q, x.(rememberedNonce))
+}
+
+func (q *nonceQueue) Pop() interface{} {
+	old := *q
+	last := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return last
+}
+
+// NewReplayGuard returns a new guard that accepts the requests whose
+// timestamp is within window of the current time and remembers at most
+// capacity nonces.
+func NewReplayGuard(window time.Duration, capacity int) *ReplayGuard {
+	return &ReplayGuard{
+		window:   window,
+		capacity: capacity,
+		now:      time.Now,
+		nonces:   make(map[string]struct{}),
+	}
+}
+
+// ResolveReplayGuard returns the guard injected in the node. If there is none,
+// it injects a new guard, so that all the handlers of a node share the nonces
+// they received.
+func ResolveReplayGuard(inj node.Injector) *ReplayGuard {
+	var guard *ReplayGuard
+
+	err := inj.Resolve(&guard)
+	if err == nil {
+		return guard
+	}
+
+	guard = NewReplayGuard(DefaultReplayWindow, DefaultNonceCapacity)
+	inj.Inject(guard)
+
+	return guard
+}
+
+// Check returns an error if the freshness is stale or its nonce was already
+// received. Otherwise the nonce is remembered.
+func (g *ReplayGuard) Check(freshness ptypes.Freshness) error {
+	if freshness.Nonce == "" {
+		return xerrors.Errorf("the request has no nonce")
+	}
+
+	now := g.now()
+	timestamp := time.Unix(freshness.Timestamp, 0)
+
+	if timestamp.Before(now.Add(-g.window)) || timestamp.After(now.Add(g.window)) {
+		return xerrors.Errorf("the request is stale: its timestamp %d is not within %s of %d",
+			freshness.Timestamp, g.window, now.Unix())
+	}
+
+	g.Lock()
+	defer g.Unlock()
+
+	// the nonces whose requests are stale can't be replayed anymore
+	oldest := now.Add(-g.window).Unix()
+
+	for len(g.queue) > 0 && g.queue[0].timestamp < oldest {
+		delete(g.nonces, heap.Pop(&g.queue).(rememberedNonce).nonce)
+	}
+
+	if freshness.Timestamp <= g.floor {
+		return xerrors.Errorf("the request is stale: its timestamp %d is not after %d, "+
+			"whose nonces were forgotten", freshness.Timestamp, g.floor)
+	}
+
+	_, found := g.nonces[freshness.Nonce]
+	if found {
+		return xerrors.Errorf("the nonce %q was already used", freshness.Nonce)
+	}
+
+	if len(g.nonces) >= g.capacity {
+		forgotten := heap.Pop(&g.queue).(rememberedNonce)
+		delete(g.nonces, forgotten.nonce)
+
+		g.floor = forgotten.timestamp
+	}
+
+	g.nonces[freshness.Nonce] = struct{}{}
+	heap.Push(&g.queue, rememberedNonce{
+		nonce:     freshness.Nonce,
+		timestamp: freshness.Timestamp,
+	})
+
+	return nil
+}
+
+// RejectReplays returns a middleware that checks the freshness of the signed
+// requests with the guard, and that they were signed for their method and
+// path. Only the requests validly signed by a key of the keyring are checked,
+// the others and the requests without a body nor Signed-Request header are
+// left to the handlers.
+func RejectReplays(keys *Keyring, guard *ReplayGuard) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !hasSignedRequest(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// the request is parsed and verified by ParseRequests, and only
+			// parsed here when it is used without it
+			parsed, err := ptypes.ParsedRequestOf(r)
+			if err != nil {
+				BadRequestError(w, r, xerrors.Errorf("failed to parse request: %v", err), nil)
+				return
+			}
+
+			verifyRequest(parsed, keys)
+
+			r = ptypes.WithParsedRequest(r, parsed)
+
+			if parsed.Signer == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var freshness ptypes.Freshness
+
+			err = parsed.Signed.GetMessage(&freshness)
+			if err != nil {
+				BadRequestError(w, r, xerrors.Errorf("failed to get freshness: %v", err), nil)
+				return
+			}
+
+			// the signature binds the request to its route, and so to its
+			// form
+			if freshness.Method != r.Method || freshness.Path != r.URL.Path {
+				ErrorResponse(w, r, ptypes.ErrReplayedRequest, xerrors.Errorf(
+					"rejected request: it was signed for %s %s", freshness.Method, freshness.Path), nil)
+				return
+			}
+
+			err = guard.Check(freshness)
+			if err != nil {
+				ErrorResponse(w, r, ptypes.ErrReplayedRequest, xerrors.Errorf("rejected request: %v", err), nil)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package proxy
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ptypes "github.com/c4dt/d-voting/proxy/types"
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/dela/cli/node"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/sign/schnorr"
+)
+
+func TestReplayGuard_Check(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	guard := NewReplayGuard(time.Minute, 2)
+	guard.now = func() time.Time { return now }
+
+	err := guard.Check(ptypes.Freshness{Timestamp: 1000})
+	require.EqualError(t, err, "the request has no nonce")
+
+	err = guard.Check(ptypes.Freshness{Timestamp: 939, Nonce: "a"})
+	require.EqualError(t, err, "the request is stale: its timestamp 939 is not within 1m0s of 1000")
+
+	err = guard.Check(ptypes.Freshness{Timestamp: 1061, Nonce: "a"})
+	require.EqualError(t, err, "the request is stale: its timestamp 1061 is not within 1m0s of 1000")
+
+	require.NoError(t, guard.Check(ptypes.Freshness{Timestamp: 940, Nonce: "a"}))
+
+	err = guard.Check(ptypes.Freshness{Timestamp: 1000, Nonce: "a"})
+	require.EqualError(t, err, `the nonce "a" was already used`)
+
+	require.NoError(t, guard.Check(ptypes.Freshness{Timestamp: 1060, Nonce: "b"}))
+
+	// without room, the oldest nonce is forgotten and the requests that are
+	// not newer are rejected
+	require.NoError(t, guard.Check(ptypes.Freshness{Timestamp: 1000, Nonce: "c"}))
+	require.Len(t, guard.nonces, 2)
+	require.Equal(t, int64(940), guard.floor)
+
+	err = guard.Check(ptypes.Freshness{Timestamp: 940, Nonce: "a"})
+	require.EqualError(t, err, "the request is stale: its timestamp 940 is not after 940, "+
+		"whose nonces were forgotten")
+
+	// the nonces are forgotten once their requests are stale
+	now = now.Add(time.Minute + time.Second)
+
+	require.NoError(t, guard.Check(ptypes.Freshness{Timestamp: now.Unix(), Nonce: "d"}))
+	require.Len(t, guard.nonces, 2)
+	require.Contains(t, guard.nonces, "b")
+}
+
+func TestResolveReplayGuard(t *testing.T) {
+	inj := node.NewInjector()
+
+	guard := ResolveReplayGuard(inj)
+	require.Same(t, guard, ResolveReplayGuard(inj))
+}
+
+func TestRejectReplays(t *testing.T) {
+	secret := suite.Scalar().Pick(suite.RandomStream())
+	pk := suite.Point().Mul(secret, nil)
+
+	called := 0
+	handler := RejectReplays(newTestKeyring(t, pk), NewReplayGuard(time.Minute, 10))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called++
+		}))
+
+	serve := func(method string, body []byte) int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, "/evoting/forms", bytes.NewReader(body)))
+		return rec.Code
+	}
+
+	payload, err := ptypes.NewPayload(ptypes.VerifyFormRequest{UserID: "123456"}, http.MethodPost, "/evoting/forms")
+	require.NoError(t, err)
+
+	signed := signPayload(t, secret, payload)
+
+	require.Equal(t, http.StatusOK, serve(http.MethodPost, signed))
+	require.Equal(t, 1, called)
+
+	// the same request is rejected
+	require.Equal(t, http.StatusBadRequest, serve(http.MethodPost, signed))
+	require.Equal(t, 1, called)
+
+	// as is a request signed for another route
+	payload, err = ptypes.NewPayload(ptypes.VerifyFormRequest{UserID: "123456"}, http.MethodPost, "/evoting/forms/abcd/verify")
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusBadRequest, serve(http.MethodPost, signPayload(t, secret, payload)))
+	require.Equal(t, 1, called)
+
+	// or a request without freshness
+	payload, err = json.Marshal(ptypes.VerifyFormRequest{UserID: "123456"})
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusBadRequest, serve(http.MethodPut, signPayload(t, secret, payload)))
+	require.Equal(t, 1, called)
+
+	// the requests that are not signed are left to the handler
+	require.Equal(t, http.StatusOK, serve(http.MethodPost, []byte("{}")))
+	require.Equal(t, 2, called)
+
+	require.Equal(t, http.StatusOK, serve(http.MethodGet, nil))
+	require.Equal(t, 3, called)
+
+	// the request parsed and checked by ParseRequests is not verified again
+	payload, err = ptypes.NewPayload(ptypes.VerifyFormRequest{UserID: "123456"}, http.MethodPost, "/evoting/forms")
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodPost, "/evoting/forms", nil)
+	r = ptypes.WithParsedRequest(r, &ptypes.ParsedRequest{
+		Signed:  ptypes.SignedRequest{Payload: base64.URLEncoding.EncodeToString(payload)},
+		Checked: true,
+		Signer:  DefaultKeyID,
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, 4, called)
+}
+
+func signPayload(t *testing.T, secret kyber.Scalar, payload []byte) []byte {
+	encoded := base64.URLEncoding.EncodeToString(payload)
+
+	md := sha256.Sum256([]byte(encoded))
+
+	signature, err := schnorr.Sign(suite, secret, md[:])
+	require.NoError(t, err)
+
+	signed, err := json.Marshal(ptypes.SignedRequest{
+		Payload:   encoded,
+		Signature: hex.EncodeToString(signature),
+	})
+	require.NoError(t, err)
+
+	return signed
+}
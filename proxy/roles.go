@@ -0,0 +1,322 @@
+package proxy
+
+import (
+	"encoding/hex"
+	"net/http"
+
+	"github.com/c4dt/d-voting/contracts/evoting"
+	"github.com/c4dt/d-voting/contracts/evoting/types"
+	"github.com/c4dt/d-voting/proxy/txnmanager"
+	ptypes "github.com/c4dt/d-voting/proxy/types"
+	"golang.org/x/xerrors"
+)
+
+// formLoader returns the form of a request. It is only called when the form
+// is needed, the other data being read from the index entry of the form.
+type formLoader func() (types.Form, error)
+
+// errPrivateStatus is the error of a request for the live status of a private
+// form by a user who is neither an owner nor an observer of the form
+var errPrivateStatus = xerrors.New("the live status of a private form is only " +
+	"served to its owners and observers")
+
+// POST /forms/{formID}/addrole
+func (form *form) AddRoleToForm(w http.ResponseWriter, r *http.Request) {
+	req, err := form.getRoleOpRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	formID, hasFailed := form.extractAndRetrieveFormID(w, r)
+	if hasFailed {
+		return
+	}
+
+	addRole := types.AddRole{
+		FormID:           formID,
+		Role:             types.FormRole(req.Role),
+		TargetUserID:     req.TargetUserID,
+		PerformingUserID: req.PerformingUserID,
+	}
+
+	data, err := addRole.Serialize(form.context)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to marshal AddRole: %v", err), nil)
+		return
+	}
+
+	// create the transaction and add it to the pool
+	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdAddRoleForm, evoting.FormArg, data)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
+		return
+	}
+
+	form.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
+}
+
+// POST /forms/{formID}/removerole
+func (form *form) RemoveRoleToForm(w http.ResponseWriter, r *http.Request) {
+	req, err := form.getRoleOpRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	formID, hasFailed := form.extractAndRetrieveFormID(w, r)
+	if hasFailed {
+		return
+	}
+
+	removeRole := types.RemoveRole{
+		FormID:           formID,
+		Role:             types.FormRole(req.Role),
+		TargetUserID:     req.TargetUserID,
+		PerformingUserID: req.PerformingUserID,
+	}
+
+	data, err := removeRole.Serialize(form.context)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to marshal RemoveRole: %v", err), nil)
+		return
+	}
+
+	// create the transaction and add it to the pool
+	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdRemoveRoleForm, evoting.FormArg, data)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
+		return
+	}
+
+	form.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
+}
+
+// GET /forms/{formID}/verification
+//
+// FormVerification returns the data needed to verify the shuffle and the
+// decryption of the form. The data is public, like the rest of the ledger, so
+// anyone can get it. Auditors verify the tally with POST
+// /forms/{formID}/verify.
+func (form *form) FormVerification(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "*")
+
+	formFromStore, ok := form.getPublicForm(w, r)
+	if !ok {
+		return
+	}
+
+	var pubkeyBuf []byte
+	var err error
+
+	if formFromStore.Pubkey != nil {
+		pubkeyBuf, err = formFromStore.Pubkey.MarshalBinary()
+		if err != nil {
+			InternalError(w, r, xerrors.Errorf("failed to marshal pubkey: %v", err), nil)
+			return
+		}
+	}
+
+	shuffles := make([]ptypes.ShuffleVerification, len(formFromStore.ShuffleInstances))
+	for i, instance := range formFromStore.ShuffleInstances {
+		shuffles[i] = ptypes.ShuffleVerification{
+			ShufflerPublicKey: hex.EncodeToString(instance.ShufflerPublicKey),
+			ShuffleProofs:     hex.EncodeToString(instance.ShuffleProofs),
+			BallotCount:       len(instance.ShuffledBallots),
+		}
+	}
+
+	units := formFromStore.PubsharesUnits
+	pubshares := make([]ptypes.PubsharesVerification, len(units.PubKeys))
+	for i, pubKey := range units.PubKeys {
+		pubshares[i] = ptypes.PubsharesVerification{
+			Index:  units.Indexes[i],
+			PubKey: hex.EncodeToString(pubKey),
+		}
+	}
+
+	response := ptypes.FormVerificationResponse{
+		FormID:           string(formFromStore.FormID),
+		Status:           uint16(formFromStore.Status),
+		Pubkey:           hex.EncodeToString(pubkeyBuf),
+		ShuffleThreshold: formFromStore.ShuffleThreshold,
+		ShuffleInstances: shuffles,
+		PubsharesUnits:   pubshares,
+	}
+
+	txnmanager.SendResponse(w, response)
+}
+
+// GET /forms/{formID}/status
+//
+// FormStatus returns the live status of the form, read from its index entry.
+// Anyone can get the status of a public form, only the owners and the
+// observers the one of a private form.
+func (form *form) FormStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "*")
+
+	formID, hasFailed := form.extractAndRetrieveFormID(w, r)
+	if hasFailed {
+		return
+	}
+
+	entry, err := form.formIndexEntry(formID)
+	if err != nil {
+		getFormErr(w, r, err)
+		return
+	}
+
+	canRead, ok := form.canReadLiveStatus(w, r, entry.Private, form.storedForm(formID))
+	if !ok {
+		return
+	}
+
+	if !canRead {
+		ErrorResponse(w, r, ptypes.ErrForbidden, errPrivateStatus, nil)
+		return
+	}
+
+	response := ptypes.FormStatusResponse{
+		Status:           uint16(entry.Status),
+		BallotCount:      entry.BallotCount,
+		LiveBallots:      entry.LiveBallots,
+		ShuffleRounds:    entry.ShuffleRounds,
+		ShuffleThreshold: entry.ShuffleThreshold,
+		PubsharesUnits:   entry.PubsharesUnits,
+	}
+
+	txnmanager.SendResponse(w, response)
+}
+
+// POST /forms/{formID}/verify
+//
+// VerifyForm submits the verification of the tally of the form by one of its
+// auditors or owners. The outcome is recorded in the history of the form.
+func (form *form) VerifyForm(w http.ResponseWriter, r *http.Request) {
+	var req ptypes.VerifyFormRequest
+
+	// get the signed request
+	signed, err := ptypes.SignedRequestOf(r)
+	if err != nil {
+		BadRequestError(w, r, newSignedErr(err), nil)
+		return
+	}
+
+	// get the request and verify the signature
+	err = form.keys.GetAndVerify(signed, &req)
+	if err != nil {
+		verifyErr(w, r, err)
+		return
+	}
+
+	formID, hasFailed := form.extractAndRetrieveFormID(w, r)
+	if hasFailed {
+		return
+	}
+
+	verifyForm := types.VerifyForm{
+		FormID: formID,
+		UserID: req.UserID,
+	}
+
+	data, err := verifyForm.Serialize(form.context)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to marshal VerifyForm: %v", err), nil)
+		return
+	}
+
+	// create the transaction and add it to the pool
+	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdVerifyForm, evoting.FormArg, data)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
+		return
+	}
+
+	form.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
+}
+
+// getPublicForm returns the form of the request. It writes the error and
+// returns false otherwise.
+func (form *form) getPublicForm(w http.ResponseWriter, r *http.Request) (types.Form, bool) {
+	formID, hasFailed := form.extractAndRetrieveFormID(w, r)
+	if hasFailed {
+		return types.Form{}, false
+	}
+
+	formFromStore, err := types.FormFromStore(form.context, form.formFac, formID, form.orderingSvc.GetStore())
+	if err != nil {
+		getFormErr(w, r, err)
+		return types.Form{}, false
+	}
+
+	return formFromStore, true
+}
+
+// storedForm returns the loader of the form from the store.
+func (form *form) storedForm(formID string) formLoader {
+	return func() (types.Form, error) {
+		return types.FormFromStore(form.context, form.formFac, formID, form.orderingSvc.GetStore())
+	}
+}
+
+// canReadLiveStatus tells if the request can read the live status of the
+// form, that is its ballot counts and the users who voted. The owners and the
+// observers of a private form sign a ReadFormRequest in the Signed-Request
+// header of their request, and the form is only loaded to check their role.
+// It writes the error and returns false as second value if the signed request
+// is invalid.
+func (form *form) canReadLiveStatus(w http.ResponseWriter, r *http.Request,
+	private bool, load formLoader) (bool, bool) {
+
+	if !private || r.Header.Get(ptypes.SignedRequestHeader) == "" {
+		return !private, true
+	}
+
+	var req ptypes.ReadFormRequest
+
+	signed, err := ptypes.SignedRequestOf(r)
+	if err != nil {
+		BadRequestError(w, r, newSignedErr(err), nil)
+		return false, false
+	}
+
+	err = form.keys.GetAndVerify(signed, &req)
+	if err != nil {
+		verifyErr(w, r, err)
+		return false, false
+	}
+
+	formFromStore, err := load()
+	if err != nil {
+		getFormErr(w, r, err)
+		return false, false
+	}
+
+	canRead, err := formFromStore.CanReadLiveStatus(req.UserID)
+	if err != nil {
+		ErrorResponse(w, r, ptypes.ErrInvalidRequest, xerrors.Errorf("invalid request: %v", err), nil)
+		return false, false
+	}
+
+	return canRead, true
+}
+
+func (form *form) getRoleOpRequest(w http.ResponseWriter, r *http.Request) (ptypes.RoleOperationRequest, error) {
+	var req ptypes.RoleOperationRequest
+
+	// get the signed request
+	signed, err := ptypes.SignedRequestOf(r)
+	if err != nil {
+		BadRequestError(w, r, newSignedErr(err), nil)
+		return ptypes.RoleOperationRequest{}, err
+	}
+
+	// get the request and verify the signature
+	err = form.keys.GetAndVerify(signed, &req)
+	if err != nil {
+		verifyErr(w, r, err)
+		return ptypes.RoleOperationRequest{}, err
+	}
+
+	return req, nil
+}
@@ -2,21 +2,19 @@ package proxy
 
 import (
 	"encoding/hex"
-	"fmt"
 	"net/http"
 
 	"github.com/c4dt/d-voting/proxy/types"
 	shuffleSrv "github.com/c4dt/d-voting/services/shuffle"
 	"github.com/gorilla/mux"
-	"go.dedis.ch/kyber/v3"
 	"golang.org/x/xerrors"
 )
 
 // NewShuffle returns a new initialized shuffle
-func NewShuffle(actor shuffleSrv.Actor, pk kyber.Point) Shuffle {
+func NewShuffle(actor shuffleSrv.Actor, keys *Keyring) Shuffle {
 	return shuffle{
 		actor: actor,
-		pk:    pk,
+		keys:  keys,
 	}
 }
 
@@ -26,8 +24,8 @@ func NewShuffle(actor shuffleSrv.Actor, pk kyber.Point) Shuffle {
 type shuffle struct {
 	// actor is the shuffle actor
 	actor shuffleSrv.Actor
-	// pk is the public key of the proxy
-	pk kyber.Point
+	// keys are the keys trusted to sign the requests
+	keys *Keyring
 }
 
 // EditShuffle implements proxy.Shuffle
@@ -35,16 +33,16 @@ func (s shuffle) EditShuffle(w http.ResponseWriter, r *http.Request) {
 	var req types.UpdateShuffle
 
 	// Read the request
-	signed, err := types.NewSignedRequest(r.Body)
+	signed, err := types.SignedRequestOf(r)
 	if err != nil {
-		InternalError(w, r, newSignedErr(err), nil)
+		BadRequestError(w, r, newSignedErr(err), nil)
 		return
 	}
 
 	// Verify the signature and get the request
-	err = signed.GetAndVerify(s.pk, &req)
+	err = s.keys.GetAndVerify(signed, &req)
 	if err != nil {
-		InternalError(w, r, getSignedErr(err), nil)
+		verifyErr(w, r, err)
 		return
 	}
 
@@ -52,7 +50,7 @@ func (s shuffle) EditShuffle(w http.ResponseWriter, r *http.Request) {
 
 	// check if the formID is present
 	if vars == nil || vars["formID"] == "" {
-		http.Error(w, fmt.Sprintf("formID not found: %v", vars), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("formID not found: %v", vars), nil)
 		return
 	}
 
@@ -61,7 +59,7 @@ func (s shuffle) EditShuffle(w http.ResponseWriter, r *http.Request) {
 
 	formIDBuf, err := hex.DecodeString(formID)
 	if err != nil {
-		http.Error(w, "failed to decode formID: "+formID, http.StatusInternalServerError)
+		BadRequestError(w, r, xerrors.Errorf("failed to decode formID: %s", formID), nil)
 		return
 	}
 
@@ -70,7 +68,7 @@ func (s shuffle) EditShuffle(w http.ResponseWriter, r *http.Request) {
 	case "shuffle":
 		err = s.actor.Shuffle(formIDBuf, userID)
 		if err != nil {
-			http.Error(w, "failed to shuffle: "+err.Error(), http.StatusInternalServerError)
+			InternalError(w, r, xerrors.Errorf("failed to shuffle: %v", err), nil)
 			return
 		}
 	default:
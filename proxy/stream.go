@@ -0,0 +1,347 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/c4dt/d-voting/contracts/evoting"
+	"github.com/c4dt/d-voting/contracts/evoting/types"
+	ptypes "github.com/c4dt/d-voting/proxy/types"
+	"github.com/gorilla/mux"
+	"go.dedis.ch/dela/core/execution/native"
+	"go.dedis.ch/dela/core/ordering"
+	"go.dedis.ch/dela/core/txn"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// keepAliveInterval is the interval at which a comment is sent on an idle
+	// stream, so that intermediaries don't close the connection.
+	keepAliveInterval = 15 * time.Second
+
+	// DefaultMaxStreams is the number of streams served at the same time
+	DefaultMaxStreams = 1000
+
+	// streamBuffer is the number of updates kept for a stream that is slow to
+	// send them
+	streamBuffer = 16
+)
+
+// streamHub forwards the updates of the forms to the streams that follow
+// them. A single watcher of the committed blocks runs as long as there are
+// streams, so that a slow client doesn't delay the others nor the node.
+type streamHub struct {
+	sync.Mutex
+
+	max   int
+	count int
+	forms map[string]map[chan ptypes.FormUpdate]struct{}
+
+	// cancel stops the watcher, it is nil when no watcher runs
+	cancel context.CancelFunc
+}
+
+func newStreamHub(max int) *streamHub {
+	return &streamHub{
+		max:   max,
+		forms: make(map[string]map[chan ptypes.FormUpdate]struct{}),
+	}
+}
+
+// subscribe returns the channel of the updates of the form. The watcher is
+// started with the first stream.
+func (h *streamHub) subscribe(formID string, watch func(context.Context)) (chan ptypes.FormUpdate, error) {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.count >= h.max {
+		return nil, xerrors.Errorf("the maximum number of streams is reached: %d", h.max)
+	}
+
+	updates := make(chan ptypes.FormUpdate, streamBuffer)
+
+	streams, found := h.forms[formID]
+	if !found {
+		streams = make(map[chan ptypes.FormUpdate]struct{})
+		h.forms[formID] = streams
+	}
+
+	streams[updates] = struct{}{}
+	h.count++
+
+	if h.cancel == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		h.cancel = cancel
+
+		go watch(ctx)
+	}
+
+	return updates, nil
+}
+
+// unsubscribe removes the stream. The watcher is stopped with the last
+// stream.
+func (h *streamHub) unsubscribe(formID string, updates chan ptypes.FormUpdate) {
+	h.Lock()
+	defer h.Unlock()
+
+	streams := h.forms[formID]
+
+	_, found := streams[updates]
+	if !found {
+		return
+	}
+
+	delete(streams, updates)
+	h.count--
+
+	if len(streams) == 0 {
+		delete(h.forms, formID)
+	}
+
+	if h.count == 0 && h.cancel != nil {
+		h.cancel()
+		h.cancel = nil
+	}
+}
+
+// follows returns true if a stream follows the form.
+func (h *streamHub) follows(formID string) bool {
+	h.Lock()
+	defer h.Unlock()
+
+	return len(h.forms[formID]) > 0
+}
+
+// publish sends the update to the streams of the form. A stream whose buffer
+// is full misses the update, the next one holding the state of the form.
+func (h *streamHub) publish(formID string, update ptypes.FormUpdate) {
+	h.Lock()
+	defer h.Unlock()
+
+	for updates := range h.forms[formID] {
+		select {
+		case updates <- update:
+		default:
+		}
+	}
+}
+
+// FormStream implements proxy.Proxy. It streams the updates of the form as
+// server-sent events, one event per committed block that changes the form.
+// The request of an owner or an observer of a private form is signed in the
+// Signed-Request header, the others should not be signed.
+func (form *form) FormStream(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "*")
+
+	vars := mux.Vars(r)
+
+	// check if the form exists
+	if vars == nil || vars["formID"] == "" {
+		InternalError(w, r, xerrors.Errorf("formID not found: %v", vars), nil)
+		return
+	}
+
+	formID := vars["formID"]
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		InternalError(w, r, xerrors.New("streaming is not supported"), nil)
+		return
+	}
+
+	// check that the form exists and that its updates can be read before
+	// streaming
+	entry, err := form.formIndexEntry(formID)
+	if err != nil {
+		ErrorResponse(w, r, ptypes.ErrorCodeOf(err.Error(), ptypes.ErrFormNotFound), xerrors.Errorf("failed to get form: %v", err), nil)
+		return
+	}
+
+	canRead, ok := form.canReadLiveStatus(w, r, entry.Private, form.storedForm(formID))
+	if !ok {
+		return
+	}
+
+	if !canRead {
+		ErrorResponse(w, r, ptypes.ErrForbidden, errPrivateStatus, nil)
+		return
+	}
+
+	updates, err := form.streams.subscribe(formID, form.watchBlocks)
+	if err != nil {
+		ErrorResponse(w, r, ptypes.ErrTooManyStreams, err, nil)
+		return
+	}
+
+	defer form.streams.unsubscribe(formID, updates)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		case update := <-updates:
+			err = writeFormUpdate(w, update)
+			if err != nil {
+				form.logger.Warn().Err(err).Msg("failed to write form update")
+				return
+			}
+
+			flusher.Flush()
+		}
+	}
+}
+
+// watchBlocks publishes the updates of the committed blocks until the context
+// is done.
+func (form *form) watchBlocks(ctx context.Context) {
+	for event := range form.orderingSvc.Watch(ctx) {
+		form.publishBlock(event)
+	}
+}
+
+// publishBlock publishes one update for each followed form that the block
+// changes, with the last command of the block on the form.
+func (form *form) publishBlock(event ordering.Event) {
+	commands := make(map[string]string)
+	formIDs := make([]string, 0)
+
+	for _, res := range event.Transactions {
+		accepted, _ := res.GetStatus()
+		if !accepted {
+			continue
+		}
+
+		formID, command := form.txCommand(res.GetTransaction())
+		if formID == "" || !form.streams.follows(formID) {
+			continue
+		}
+
+		_, found := commands[formID]
+		if !found {
+			formIDs = append(formIDs, formID)
+		}
+
+		commands[formID] = command
+	}
+
+	for _, formID := range formIDs {
+		update, err := form.formUpdate(formID, commands[formID], event.Index)
+		if err != nil {
+			form.logger.Warn().Err(err).Msg("failed to get form update")
+			continue
+		}
+
+		form.streams.publish(formID, update)
+	}
+}
+
+// txCommand decodes a committed transaction and returns the form and the
+// command of the transaction if it is an evoting command on a form.
+func (form *form) txCommand(tx txn.Transaction) (string, string) {
+	if string(tx.GetArg(native.ContractArg)) != evoting.ContractName {
+		return "", ""
+	}
+
+	msg, err := form.txFac.Deserialize(form.context, tx.GetArg(evoting.FormArg))
+	if err != nil {
+		return "", ""
+	}
+
+	return txFormID(msg), string(tx.GetArg(evoting.CmdArg))
+}
+
+// formUpdate returns the update of the form after the block. The event is
+// received once the block is committed, so the store holds the state of the
+// form after the block.
+func (form *form) formUpdate(formID string, command string,
+	blockIndex uint64) (ptypes.FormUpdate, error) {
+
+	entry, err := form.formIndexEntry(formID)
+	if err != nil {
+		return ptypes.FormUpdate{}, xerrors.Errorf("failed to get index entry: %v", err)
+	}
+
+	update := ptypes.FormUpdate{
+		Command:          command,
+		BlockIndex:       blockIndex,
+		Status:           uint16(entry.Status),
+		BallotCount:      entry.BallotCount,
+		LiveBallots:      entry.LiveBallots,
+		ShuffleRounds:    entry.ShuffleRounds,
+		PubsharesUnits:   entry.PubsharesUnits,
+		ShuffleThreshold: entry.ShuffleThreshold,
+	}
+
+	return update, nil
+}
+
+// txFormID returns the ID of the form targeted by the transaction, or an
+// empty string if the transaction doesn't change the state of a form that
+// clients follow.
+func txFormID(msg interface{}) string {
+	switch tx := msg.(type) {
+	case types.OpenForm:
+		return tx.FormID
+	case types.CastVote:
+		return tx.FormID
+	case types.WithdrawVote:
+		return tx.FormID
+	case types.DelegateVote:
+		return tx.FormID
+	case types.RevokeDelegation:
+		return tx.FormID
+	case types.CloseForm:
+		return tx.FormID
+	case types.ReopenForm:
+		return tx.FormID
+	case types.CreateRunoff:
+		return tx.FormID
+	case types.SuspendForm:
+		return tx.FormID
+	case types.ResumeForm:
+		return tx.FormID
+	case types.ShuffleBallots:
+		return tx.FormID
+	case types.RegisterPubShares:
+		return tx.FormID
+	case types.CombineShares:
+		return tx.FormID
+	case types.CancelForm:
+		return tx.FormID
+	default:
+		return ""
+	}
+}
+
+// writeFormUpdate writes the update as a server-sent event
+func writeFormUpdate(w http.ResponseWriter, update ptypes.FormUpdate) error {
+	data, err := json.Marshal(update)
+	if err != nil {
+		return xerrors.Errorf("failed to marshal update: %v", err)
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", update.Command, data)
+	if err != nil {
+		return xerrors.Errorf("failed to write event: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package proxy
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/c4dt/d-voting/contracts/evoting/types"
+	ptypes "github.com/c4dt/d-voting/proxy/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxFormID(t *testing.T) {
+	require.Equal(t, "aa", txFormID(types.CastVote{FormID: "aa"}))
+	require.Equal(t, "bb", txFormID(types.ShuffleBallots{FormID: "bb"}))
+	require.Equal(t, "cc", txFormID(types.RegisterPubShares{FormID: "cc"}))
+	require.Equal(t, "", txFormID(types.AddAdmin{}))
+}
+
+func TestWriteFormUpdate(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	err := writeFormUpdate(w, ptypes.FormUpdate{
+		Command:     "CAST_VOTE",
+		BlockIndex:  3,
+		Status:      uint16(types.Open),
+		BallotCount: 2,
+		LiveBallots: 2,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "event: CAST_VOTE\n"+
+		`data: {"Command":"CAST_VOTE","BlockIndex":3,"Status":1,"BallotCount":2,`+
+		`"LiveBallots":2,"ShuffleRounds":0,"ShuffleThreshold":0,"PubsharesUnits":0}`+"\n\n", w.Body.String())
+}
+
+func TestStreamHub(t *testing.T) {
+	hub := newStreamHub(2)
+
+	watching := make(chan context.Context, 1)
+	watch := func(ctx context.Context) {
+		watching <- ctx
+	}
+
+	first, err := hub.subscribe("aa", watch)
+	require.NoError(t, err)
+
+	second, err := hub.subscribe("bb", watch)
+	require.NoError(t, err)
+
+	// a single watcher runs for all the streams
+	ctx := <-watching
+	require.Len(t, watching, 0)
+
+	_, err = hub.subscribe("aa", watch)
+	require.EqualError(t, err, "the maximum number of streams is reached: 2")
+
+	require.True(t, hub.follows("aa"))
+	require.False(t, hub.follows("cc"))
+
+	// a stream that doesn't read its updates doesn't block the others
+	for i := 0; i < streamBuffer+1; i++ {
+		hub.publish("aa", ptypes.FormUpdate{BlockIndex: uint64(i)})
+	}
+
+	hub.publish("bb", ptypes.FormUpdate{BlockIndex: 1})
+
+	require.Len(t, first, streamBuffer)
+	require.Equal(t, ptypes.FormUpdate{BlockIndex: 1}, <-second)
+
+	hub.unsubscribe("aa", first)
+	require.False(t, hub.follows("aa"))
+	require.NoError(t, ctx.Err())
+
+	// the watcher stops with the last stream
+	hub.unsubscribe("bb", second)
+	require.Error(t, ctx.Err())
+}
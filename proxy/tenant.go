@@ -0,0 +1,164 @@
+package proxy
+
+import (
+	"net/http"
+
+	"github.com/c4dt/d-voting/contracts/evoting"
+	"github.com/c4dt/d-voting/contracts/evoting/types"
+	"github.com/c4dt/d-voting/proxy/txnmanager"
+	ptypes "github.com/c4dt/d-voting/proxy/types"
+	"github.com/gorilla/mux"
+	"golang.org/x/xerrors"
+)
+
+// POST /tenants
+func (form *form) NewTenant(w http.ResponseWriter, r *http.Request) {
+	var req ptypes.CreateTenantRequest
+
+	// get the signed request
+	signed, err := ptypes.SignedRequestOf(r)
+	if err != nil {
+		BadRequestError(w, r, newSignedErr(err), nil)
+		return
+	}
+
+	// get the request and verify the signature
+	err = form.keys.GetAndVerify(signed, &req)
+	if err != nil {
+		verifyErr(w, r, err)
+		return
+	}
+
+	createTenant := types.CreateTenant{
+		TenantID:         req.TenantID,
+		Name:             req.Name,
+		AdminID:          req.AdminID,
+		PerformingUserID: req.PerformingUserID,
+	}
+
+	data, err := createTenant.Serialize(form.context)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to marshal CreateTenant: %v", err), nil)
+		return
+	}
+
+	// create the transaction and add it to the pool
+	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdCreateTenant, evoting.FormArg, data)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
+		return
+	}
+
+	form.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
+}
+
+// GET /tenants
+func (form *form) Tenants(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "*")
+
+	tenants, err := types.TenantsFromStore(form.context, form.orderingSvc.GetStore(), []byte(evoting.TenantsKey))
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to get tenants: %v", err), nil)
+		return
+	}
+
+	txnmanager.SendResponse(w, ptypes.GetTenantsResponse{Tenants: tenants.Tenants})
+}
+
+// POST /tenants/{tenantID}/addadmin
+func (form *form) AddTenantAdmin(w http.ResponseWriter, r *http.Request) {
+	req, err := form.getPermissionOpRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	tenantID, ok := extractTenantID(w, r)
+	if !ok {
+		return
+	}
+
+	addTenantAdmin := types.AddTenantAdmin{
+		TenantID:         tenantID,
+		TargetUserID:     req.TargetUserID,
+		PerformingUserID: req.PerformingUserID,
+	}
+
+	data, err := addTenantAdmin.Serialize(form.context)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to marshal AddTenantAdmin: %v", err), nil)
+		return
+	}
+
+	// create the transaction and add it to the pool
+	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdAddTenantAdmin, evoting.FormArg, data)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
+		return
+	}
+
+	form.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
+}
+
+// POST /tenants/{tenantID}/removeadmin
+func (form *form) RemoveTenantAdmin(w http.ResponseWriter, r *http.Request) {
+	req, err := form.getPermissionOpRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	tenantID, ok := extractTenantID(w, r)
+	if !ok {
+		return
+	}
+
+	removeTenantAdmin := types.RemoveTenantAdmin{
+		TenantID:         tenantID,
+		TargetUserID:     req.TargetUserID,
+		PerformingUserID: req.PerformingUserID,
+	}
+
+	data, err := removeTenantAdmin.Serialize(form.context)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to marshal RemoveTenantAdmin: %v", err), nil)
+		return
+	}
+
+	// create the transaction and add it to the pool
+	txnID, lastBlock, err := form.mngr.SubmitTxn(r.Context(), evoting.CmdRemoveTenantAdmin, evoting.FormArg, data)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err), nil)
+		return
+	}
+
+	form.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
+}
+
+// GET /tenants/{tenantID}/adminlist
+func (form *form) TenantAdminList(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := extractTenantID(w, r)
+	if !ok {
+		return
+	}
+
+	adminList, err := types.AdminListFromStore(form.context, form.adminFac,
+		form.orderingSvc.GetStore(), evoting.TenantAdminListId(tenantID))
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to get the admin list of the tenant: %v", err), nil)
+		return
+	}
+
+	txnmanager.SendResponse(w, adminList.AdminList)
+}
+
+func extractTenantID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	vars := mux.Vars(r)
+
+	// check if the tenantID is valid
+	if vars == nil || vars["tenantID"] == "" {
+		InternalError(w, r, xerrors.Errorf("tenantID not found: %v", vars), nil)
+		return "", false
+	}
+
+	return vars["tenantID"], true
+}
@@ -0,0 +1,285 @@
+package txnmanager
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	ptypes "github.com/c4dt/d-voting/proxy/types"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// IdempotencyKeyHeader is the header of the key chosen by the client to
+	// identify a request and its retries
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on the responses served from the cache
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// DefaultIdempotencyWindow is how long the response of a request is kept
+	// for its retries
+	DefaultIdempotencyWindow = 10 * time.Minute
+	// DefaultIdempotencyCapacity is the number of responses kept
+	DefaultIdempotencyCapacity = 10000
+)
+
+// idempotencyCache keeps the responses of the requests with an idempotency
+// key, so that their retries get the same response without submitting
+// another transaction.
+type idempotencyCache struct {
+	sync.Mutex
+
+	window   time.Duration
+	capacity int
+	now      func() time.Time
+
+	entries map[string]*idempotentResponse
+	// queue holds the keys in the order they were received, which is the
+	// order in which they expire
+	queue []rememberedKey
+}
+
+type rememberedKey struct {
+	id     string
+	expiry time.Time
+}
+
+// idempotentResponse is the response of a request. It is done once the
+// handler of the first request returned.
+type idempotentResponse struct {
+	digest []byte
+	expiry time.Time
+	done   bool
+
+	status int
+	header http.Header
+	body   []byte
+}
+
+func newIdempotencyCache(window time.Duration, capacity int) *idempotencyCache {
+	return &idempotencyCache{
+		window:   window,
+		capacity: capacity,
+		now:      time.Now,
+		entries:  make(map[string]*idempotentResponse),
+	}
+}
+
+// errIdempotencyFull is returned when the cache is full of requests in
+// progress
+var errIdempotencyFull = xerrors.New("too many requests with an idempotency key in progress")
+
+// begin returns the response of the request with the same ID, if any. If
+// there is none, the request is tracked and its response must be given to
+// end. When the cache is full, the oldest completed response is forgotten,
+// and the request is refused if there is none.
+func (c *idempotencyCache) begin(id string, digest []byte) (*idempotentResponse, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	now := c.now()
+
+	for len(c.queue) > 0 && c.queue[0].expiry.Before(now) {
+		entry, found := c.entries[c.queue[0].id]
+		if found && entry.expiry.Equal(c.queue[0].expiry) {
+			delete(c.entries, c.queue[0].id)
+		}
+
+		c.queue = c.queue[1:]
+	}
+
+	entry, found := c.entries[id]
+	if found {
+		if !bytes.Equal(entry.digest, digest) {
+			return nil, xerrors.Errorf("the idempotency key was used for another request")
+		}
+
+		if !entry.done {
+			return nil, xerrors.Errorf("a request with the idempotency key is in progress")
+		}
+
+		return entry, nil
+	}
+
+	if len(c.entries) >= c.capacity && !c.evictCompleted() {
+		return nil, errIdempotencyFull
+	}
+
+	expiry := now.Add(c.window)
+
+	c.entries[id] = &idempotentResponse{
+		digest: digest,
+		expiry: expiry,
+	}
+
+	c.queue = append(c.queue, rememberedKey{id: id, expiry: expiry})
+
+	return nil, nil
+}
+
+// evictCompleted forgets the oldest completed response. It returns false if
+// all the requests are in progress.
+func (c *idempotencyCache) evictCompleted() bool {
+	for i, key := range c.queue {
+		entry, found := c.entries[key.id]
+		if !found || !entry.expiry.Equal(key.expiry) || !entry.done {
+			continue
+		}
+
+		delete(c.entries, key.id)
+		c.queue = append(c.queue[:i], c.queue[i+1:]...)
+
+		return true
+	}
+
+	return false
+}
+
+// end keeps the response of a tracked request. A failed request is forgotten
+// so that it can be retried.
+func (c *idempotencyCache) end(id string, status int, header http.Header, body []byte) {
+	c.Lock()
+	defer c.Unlock()
+
+	entry, found := c.entries[id]
+	if !found {
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		delete(c.entries, id)
+		return
+	}
+
+	entry.done = true
+	entry.status = status
+	entry.header = header
+	entry.body = body
+}
+
+// write sends the kept response.
+func (r *idempotentResponse) write(w http.ResponseWriter) {
+	for key, values := range r.header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(r.status)
+	w.Write(r.body)
+}
+
+// recordingWriter writes the response and records it.
+type recordingWriter struct {
+	http.ResponseWriter
+
+	status int
+	body   bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recordingWriter) Write(buf []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	w.body.Write(buf)
+
+	return w.ResponseWriter.Write(buf)
+}
+
+// Idempotent implements Manager. The requests are identified by their method,
+// path, signer and idempotency key, and a retry must carry the same payload.
+// Only the requests whose signature was verified are kept, so that a request
+// can't get the response of a request of another signer. The timestamp and
+// nonce of a signed payload are ignored, since a retry is signed again.
+func (h *manager) Idempotent(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+
+		if key == "" || r.Method == http.MethodGet || r.Method == http.MethodHead ||
+			r.Method == http.MethodOptions {
+
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		parsed, err := ptypes.ParsedRequestOf(r)
+		if err != nil {
+			ptypes.NewHTTPError(r, ptypes.ErrBadRequest, xerrors.Errorf("failed to parse request: %v", err), nil).Send(w)
+			return
+		}
+
+		r = ptypes.WithParsedRequest(r, parsed)
+
+		// the handler rejects the requests that are not validly signed
+		if parsed.Signer == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		id := r.Method + " " + r.URL.Path + " " + parsed.Signer + " " + key
+
+		response, err := h.idempotency.begin(id, payloadDigest(parsed))
+		if err != nil {
+			code := ptypes.ErrIdempotencyConflict
+			if errors.Is(err, errIdempotencyFull) {
+				code = ptypes.ErrTooManyRequests
+			}
+
+			ptypes.NewHTTPError(r, code, err, map[string]interface{}{
+				"key": key,
+			}).Send(w)
+			return
+		}
+
+		if response != nil {
+			response.write(w)
+			return
+		}
+
+		recorder := &recordingWriter{ResponseWriter: w}
+
+		// a request whose handler panics before answering is forgotten
+		defer func() {
+			h.idempotency.end(id, recorder.status, w.Header().Clone(), recorder.body.Bytes())
+		}()
+
+		next.ServeHTTP(recorder, r)
+	})
+}
+
+// payloadDigest returns the hash of the payload of a signed request, without
+// its freshness, or the hash of the body if it is not a signed request.
+func payloadDigest(parsed *ptypes.ParsedRequest) []byte {
+	content := parsed.Body
+
+	var fields map[string]interface{}
+
+	if parsed.SignedErr == nil && parsed.Signed.GetMessage(&fields) == nil && fields != nil {
+		delete(fields, "Timestamp")
+		delete(fields, "Nonce")
+
+		// the keys of a map are sorted
+		buf, err := json.Marshal(fields)
+		if err == nil {
+			content = buf
+		}
+	}
+
+	digest := sha256.Sum256(content)
+
+	return digest[:]
+}
@@ -0,0 +1,161 @@
+package txnmanager
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ptypes "github.com/c4dt/d-voting/proxy/types"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+)
+
+func TestManager_Idempotent(t *testing.T) {
+	calls := 0
+	status := http.StatusOK
+
+	h := &manager{idempotency: newIdempotencyCache(time.Minute, 10)}
+
+	handler := h.Idempotent(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(`{"Token":"abc"}`))
+	}))
+
+	serveFrom := func(signer, key, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/evoting/forms", nil)
+		if key != "" {
+			r.Header.Set(IdempotencyKeyHeader, key)
+		}
+
+		r = ptypes.WithParsedRequest(r, &ptypes.ParsedRequest{
+			Body:   []byte(body),
+			Signer: signer,
+		})
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		return w
+	}
+
+	serve := func(key, body string) *httptest.ResponseRecorder {
+		return serveFrom("default", key, body)
+	}
+
+	w := serve("key1", "body")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, 1, calls)
+
+	// the retry gets the same response without calling the handler
+	w = serve("key1", "body")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `{"Token":"abc"}`, w.Body.String())
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	require.Equal(t, "true", w.Header().Get(IdempotentReplayedHeader))
+	require.Equal(t, 1, calls)
+
+	// the key can't be used for another request
+	w = serve("key1", "other body")
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.Equal(t, 1, calls)
+
+	var httpErr ptypes.HTTPError
+	err := json.NewDecoder(w.Body).Decode(&httpErr)
+	require.NoError(t, err)
+	require.Equal(t, ptypes.ErrIdempotencyConflict, httpErr.ErrorCode)
+
+	// the requests without key are always served
+	serve("", "body")
+	serve("", "body")
+	require.Equal(t, 3, calls)
+
+	// a failed request can be retried
+	status = http.StatusInternalServerError
+	serve("key2", "body")
+	status = http.StatusOK
+	w = serve("key2", "body")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, w.Header().Get(IdempotentReplayedHeader))
+	require.Equal(t, 5, calls)
+
+	// the key of a signer is not shared with another signer
+	w = serveFrom("other", "key1", "body")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, w.Header().Get(IdempotentReplayedHeader))
+	require.Equal(t, 6, calls)
+
+	// and the requests that are not verified are not kept
+	serveFrom("", "key3", "body")
+	w = serveFrom("", "key3", "body")
+	require.Empty(t, w.Header().Get(IdempotentReplayedHeader))
+	require.Equal(t, 8, calls)
+}
+
+func TestIdempotencyCache_Expiry(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	cache := newIdempotencyCache(time.Minute, 1)
+	cache.now = func() time.Time { return now }
+
+	response, err := cache.begin("a", []byte{1})
+	require.NoError(t, err)
+	require.Nil(t, response)
+
+	_, err = cache.begin("a", []byte{1})
+	require.EqualError(t, err, "a request with the idempotency key is in progress")
+
+	// the cache is full of requests in progress
+	_, err = cache.begin("b", []byte{1})
+	require.ErrorIs(t, err, errIdempotencyFull)
+
+	cache.end("a", http.StatusOK, http.Header{}, []byte("done"))
+
+	response, err = cache.begin("a", []byte{1})
+	require.NoError(t, err)
+	require.Equal(t, []byte("done"), response.body)
+
+	// the oldest completed response makes room for a new request
+	response, err = cache.begin("b", []byte{1})
+	require.NoError(t, err)
+	require.Nil(t, response)
+
+	_, found := cache.entries["a"]
+	require.False(t, found)
+	require.Len(t, cache.queue, 1)
+
+	now = now.Add(2 * time.Minute)
+
+	response, err = cache.begin("a", []byte{1})
+	require.NoError(t, err)
+	require.Nil(t, response)
+}
+
+func TestPayloadDigest(t *testing.T) {
+	signed := func(payload string) *ptypes.ParsedRequest {
+		return &ptypes.ParsedRequest{
+			Signed: ptypes.SignedRequest{
+				Payload:   base64.URLEncoding.EncodeToString([]byte(payload)),
+				Signature: "abcd",
+			},
+		}
+	}
+
+	body := func(body string) *ptypes.ParsedRequest {
+		return &ptypes.ParsedRequest{Body: []byte(body), SignedErr: xerrors.New("not signed")}
+	}
+
+	first := payloadDigest(signed(`{"VoterID":"123456","Timestamp":1,"Nonce":"a"}`))
+	retry := payloadDigest(signed(`{"Nonce":"b","VoterID":"123456","Timestamp":2}`))
+	other := payloadDigest(signed(`{"VoterID":"234567","Timestamp":1,"Nonce":"a"}`))
+
+	require.Equal(t, first, retry)
+	require.NotEqual(t, first, other)
+
+	require.Equal(t, payloadDigest(body("body")), payloadDigest(body("body")))
+	require.NotEqual(t, payloadDigest(body("body")), payloadDigest(body("other")))
+}
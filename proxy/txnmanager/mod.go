@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/c4dt/d-voting/contracts/evoting"
+	ptypes "github.com/c4dt/d-voting/proxy/types"
 )
 
 // Manager defines the public HTTP API of the transaction manager
@@ -20,6 +21,12 @@ type Manager interface {
 	// CreateTransactionResult create the json to send to the client
 	CreateTransactionResult(txnID []byte, lastBlockIdx uint64, status TransactionStatus) (TransactionClientInfo, error)
 	SendTransactionInfo(w http.ResponseWriter, txnID []byte, lastBlockIdx uint64, status TransactionStatus) error
+
+	// Idempotent returns a handler that serves the retries of a request with
+	// the same Idempotency-Key header with the response of the first request,
+	// instead of submitting another transaction. It expects the requests
+	// parsed and verified by a previous middleware.
+	Idempotent(next http.Handler) http.Handler
 }
 
 // TransactionStatus is the status of a transaction
@@ -52,4 +59,8 @@ type transactionInternalInfo struct {
 type TransactionClientInfo struct {
 	Status TransactionStatus // 0 if not yet included, 1 if included, 2 if rejected
 	Token  string
+	// Reason is why the smart contract rejected the transaction, and ErrorCode
+	// the code of the reason
+	Reason    string           `json:",omitempty"`
+	ErrorCode ptypes.ErrorCode `json:",omitempty"`
 }
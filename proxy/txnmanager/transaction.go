@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/c4dt/d-voting/contracts/evoting"
+	ptypes "github.com/c4dt/d-voting/proxy/types"
 	"github.com/gorilla/mux"
 	"github.com/rs/zerolog"
 	"go.dedis.ch/dela"
@@ -46,6 +47,8 @@ func NewTransactionManager(mngr txn.Manager, p pool.Pool,
 		blocks:  blocks,
 		signer:  signer,
 		val:     val,
+
+		idempotency: newIdempotencyCache(DefaultIdempotencyWindow, DefaultIdempotencyCapacity),
 	}
 }
 
@@ -63,6 +66,8 @@ type manager struct {
 	blocks  blockstore.BlockStore
 	signer  crypto.Signer
 	val     validation.Service
+
+	idempotency *idempotencyCache
 }
 
 // StatusHandlerGet checks if the transaction is included in the blockchain
@@ -72,7 +77,7 @@ func (h *manager) StatusHandlerGet(w http.ResponseWriter, r *http.Request) {
 
 	// check if the token is valid
 	if vars == nil || vars["token"] == "" {
-		http.Error(w, fmt.Sprintf("token not found: %v", vars), http.StatusInternalServerError)
+		ptypes.NewHTTPError(r, ptypes.ErrInternal, xerrors.Errorf("token not found: %v", vars), nil).Send(w)
 		return
 	}
 
@@ -81,7 +86,7 @@ func (h *manager) StatusHandlerGet(w http.ResponseWriter, r *http.Request) {
 	// decode the token
 	marshall, err := b64.URLEncoding.DecodeString(token)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("failed to decode token: %v", err), http.StatusInternalServerError)
+		ptypes.NewHTTPError(r, ptypes.ErrInvalidToken, xerrors.Errorf("failed to decode token: %v", err), nil).Send(w)
 		return
 	}
 
@@ -89,13 +94,13 @@ func (h *manager) StatusHandlerGet(w http.ResponseWriter, r *http.Request) {
 	var content transactionInternalInfo
 	err = json.Unmarshal(marshall, &content)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("failed to unmarshall token: %v", err), http.StatusInternalServerError)
+		ptypes.NewHTTPError(r, ptypes.ErrInvalidToken, xerrors.Errorf("failed to unmarshall token: %v", err), nil).Send(w)
 		return
 	}
 
 	err = content.validate(h)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid content: %v", err), http.StatusBadRequest)
+		ptypes.NewHTTPError(r, ptypes.ErrInvalidToken, xerrors.Errorf("Invalid content: %v", err), nil).Send(w)
 		return
 	}
 
@@ -104,7 +109,7 @@ func (h *manager) StatusHandlerGet(w http.ResponseWriter, r *http.Request) {
 		// if it was submited to long ago, we reject the transaction
 		err = h.SendTransactionInfo(w, content.TransactionID, 0, RejectedTransaction)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("failed to send transaction info: %v", err), http.StatusInternalServerError)
+			ptypes.NewHTTPError(r, ptypes.ErrInternal, xerrors.Errorf("failed to send transaction info: %v", err), nil).Send(w)
 			return
 		}
 		return
@@ -112,17 +117,29 @@ func (h *manager) StatusHandlerGet(w http.ResponseWriter, r *http.Request) {
 
 	// check if the transaction time stamp is possible
 	if time.Now().Unix()-content.Time < 0 {
-		http.Error(w, "the transaction is from the future", http.StatusInternalServerError)
+		ptypes.NewHTTPError(r, ptypes.ErrInvalidToken, xerrors.Errorf("the transaction is from the future"), nil).Send(w)
 		return
 	}
 
 	// check if the transaction is included in the blockchain
-	newStatus, idx := h.checkTxnIncluded(content.TransactionID, content.LastBlockIdx)
+	newStatus, idx, reason := h.checkTxnIncluded(content.TransactionID, content.LastBlockIdx)
+
+	response, err := h.CreateTransactionResult(content.TransactionID, idx, newStatus)
+	if err != nil {
+		ptypes.NewHTTPError(r, ptypes.ErrInternal, xerrors.Errorf("failed to create transaction info: %v", err), nil).Send(w)
+		return
+	}
+
+	// tell the client why the smart contract refused the transaction
+	if newStatus == RejectedTransaction {
+		response.Reason = reason
+		response.ErrorCode = ptypes.ErrorCodeOf(reason, ptypes.ErrTransactionRejected)
+	}
 
 	// send the transaction info
-	err = h.SendTransactionInfo(w, content.TransactionID, idx, newStatus)
+	err = SendResponse(w, response)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("failed to send transaction info: %v", err), http.StatusInternalServerError)
+		ptypes.NewHTTPError(r, ptypes.ErrInternal, xerrors.Errorf("failed to send transaction info: %v", err), nil).Send(w)
 		return
 	}
 
@@ -169,8 +186,9 @@ func (h *manager) checkSignature(Hash []byte, Signature crypto.Signature) bool {
 	return h.signer.GetPublicKey().Verify(Hash, Signature) == nil
 }
 
-// checkTxnIncluded checks if the transaction is included in the blockchain
-func (h *manager) checkTxnIncluded(transactionID []byte, lastBlockIdx uint64) (TransactionStatus, uint64) {
+// checkTxnIncluded checks if the transaction is included in the blockchain. It
+// returns the reason of the smart contract if the transaction was rejected.
+func (h *manager) checkTxnIncluded(transactionID []byte, lastBlockIdx uint64) (TransactionStatus, uint64, string) {
 	// we start at the last block index
 	// which is the index of the last block that was checked
 	// or the last block before the transaction was submited
@@ -182,7 +200,7 @@ func (h *manager) checkTxnIncluded(transactionID []byte, lastBlockIdx uint64) (T
 
 		// if we reached the end of the blockchain
 		if err != nil {
-			return UnknownTransactionStatus, idx - 1
+			return UnknownTransactionStatus, idx - 1, ""
 		}
 
 		// check if the transaction is in the block
@@ -193,12 +211,14 @@ func (h *manager) checkTxnIncluded(transactionID []byte, lastBlockIdx uint64) (T
 			if bytes.Equal(txn.GetTransaction().GetID(), transactionID) {
 				accepted, reason := txn.GetStatus()
 				if accepted {
-					return IncludedTransaction, blockLink.GetBlock().GetIndex()
-				} else {
-					println(reason)
-					return RejectedTransaction, blockLink.GetBlock().GetIndex()
+					return IncludedTransaction, blockLink.GetBlock().GetIndex(), ""
 				}
 
+				h.logger.Info().Hex("txnID", transactionID).Str("reason", reason).
+					Msg("transaction rejected")
+
+				return RejectedTransaction, blockLink.GetBlock().GetIndex(), reason
+
 			}
 
 		}
@@ -288,13 +308,19 @@ func (h *manager) CreateTransactionResult(txnID []byte, lastBlockIdx uint64, sta
 // SendResponse sends a response to the client.
 func SendResponse(w http.ResponseWriter, response any) error {
 
+	// the response is encoded before anything is written, so that an error
+	// can still be sent
+	buf, err := json.Marshal(response)
+	if err != nil {
+		ptypes.NewResponseError(ptypes.ErrInternal, xerrors.Errorf("failed to marshal response: %v", err), nil).Send(w)
+		return nil
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 
-	err := json.NewEncoder(w).Encode(response)
+	_, err = w.Write(append(buf, '\n'))
 	if err != nil {
-		http.Error(w, "failed to write in ResponseWriter: "+err.Error(),
-			http.StatusInternalServerError)
-		return nil
+		return xerrors.Errorf("failed to write response: %v", err)
 	}
 
 	return nil
@@ -0,0 +1,57 @@
+package txnmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/dela"
+	"go.dedis.ch/dela/core/ordering/cosipbft/blockstore"
+	"go.dedis.ch/dela/core/ordering/cosipbft/types"
+	"go.dedis.ch/dela/core/txn/signed"
+	"go.dedis.ch/dela/core/validation/simple"
+	"go.dedis.ch/dela/testing/fake"
+)
+
+func TestCheckTxnIncluded(t *testing.T) {
+	accepted, err := signed.NewTransaction(0, fake.PublicKey{})
+	require.NoError(t, err)
+
+	rejected, err := signed.NewTransaction(1, fake.PublicKey{})
+	require.NoError(t, err)
+
+	missing, err := signed.NewTransaction(2, fake.PublicKey{})
+	require.NoError(t, err)
+
+	reason := "failed to execute: [FORM_NOT_OPEN] the form is not open, current status: 0"
+
+	block, err := types.NewBlock(simple.NewResult([]simple.TransactionResult{
+		simple.NewTransactionResult(accepted, true, ""),
+		simple.NewTransactionResult(rejected, false, reason),
+	}))
+	require.NoError(t, err)
+
+	link, err := types.NewBlockLink(types.Digest{}, block)
+	require.NoError(t, err)
+
+	blocks := blockstore.NewInMemory()
+	require.NoError(t, blocks.Store(link))
+
+	h := &manager{
+		logger: dela.Logger,
+		blocks: blocks,
+	}
+
+	status, idx, rejection := h.checkTxnIncluded(accepted.GetID(), 0)
+	require.Equal(t, IncludedTransaction, status)
+	require.Equal(t, uint64(0), idx)
+	require.Empty(t, rejection)
+
+	status, idx, rejection = h.checkTxnIncluded(rejected.GetID(), 0)
+	require.Equal(t, RejectedTransaction, status)
+	require.Equal(t, uint64(0), idx)
+	require.Equal(t, reason, rejection)
+
+	status, _, rejection = h.checkTxnIncluded(missing.GetID(), 0)
+	require.Equal(t, UnknownTransactionStatus, status)
+	require.Empty(t, rejection)
+}
@@ -8,6 +8,68 @@ import (
 type CreateFormRequest struct {
 	UserID        string
 	Configuration etypes.Configuration
+	// TenantID is the tenant of the form, or empty for a form of the whole
+	// deployment
+	TenantID string `json:",omitempty"`
+	// CloseBlock is the index of the last block in which ballots are
+	// accepted, or 0 for no deadline
+	CloseBlock uint64 `json:",omitempty"`
+	// Private tells if the live status of the form is only served to its
+	// owners and observers
+	Private bool `json:",omitempty"`
+}
+
+// CreateTenantRequest defines the HTTP request for creating a tenant
+type CreateTenantRequest struct {
+	TenantID         string
+	Name             string
+	AdminID          string
+	PerformingUserID string
+}
+
+// ProposeAdminChangeRequest defines the HTTP request for proposing a change
+// of the admin list
+type ProposeAdminChangeRequest struct {
+	// Action is "add", "remove" or "threshold"
+	Action           string
+	TargetUserID     string `json:",omitempty"`
+	Threshold        int    `json:",omitempty"`
+	PerformingUserID string
+	// Lifetime is the number of blocks during which the proposal can be
+	// approved. A default is used if it is 0.
+	Lifetime uint64 `json:",omitempty"`
+}
+
+// SetLimitsRequest defines the HTTP request for setting the resource limits
+// of the forms
+type SetLimitsRequest struct {
+	Limits           etypes.Limits
+	PerformingUserID string
+}
+
+// AdminProposalVoteRequest defines the HTTP request for approving or
+// rejecting a proposal
+type AdminProposalVoteRequest struct {
+	PerformingUserID string
+}
+
+// GetAdminProposalsResponse defines the HTTP response when getting the
+// proposals to change the admin list
+type GetAdminProposalsResponse struct {
+	Threshold int
+	Proposals []AdminProposalInfo
+}
+
+// AdminProposalInfo is a proposal, which is expired if it is still pending
+// after its expiry
+type AdminProposalInfo struct {
+	etypes.AdminProposal
+	Expired bool
+}
+
+// GetTenantsResponse defines the HTTP response when getting the tenants
+type GetTenantsResponse struct {
+	Tenants []etypes.Tenant
 }
 
 // PermissionOperationRequest defines the HTTP request for performing
@@ -17,17 +79,73 @@ type PermissionOperationRequest struct {
 	PerformingUserID string
 }
 
+// RoleOperationRequest defines the HTTP request for giving or removing a role
+// on a form
+type RoleOperationRequest struct {
+	Role             string
+	TargetUserID     string
+	PerformingUserID string
+}
+
+// VerifyFormRequest defines the HTTP request of an auditor or an owner to
+// verify the tally of a form
+type VerifyFormRequest struct {
+	UserID string
+}
+
+// ReadFormRequest defines the request of an owner or an observer to read the
+// live status of a private form. It is signed in the Signed-Request header.
+type ReadFormRequest struct {
+	UserID string
+}
+
 // CreateFormResponse defines the HTTP response when creating a form
 type CreateFormResponse struct {
 	FormID string // hex-encoded
 	Token  string
 }
 
+// DeleteFormRequest defines the HTTP request for deleting a form
+type DeleteFormRequest struct {
+	UserID string
+}
+
+// CreateRunoffRequest defines the HTTP request for creating a runoff of a
+// form
+type CreateRunoffRequest struct {
+	UserID     string
+	QuestionID etypes.ID
+	Rule       etypes.RunoffRule
+}
+
 // CastVoteRequest defines the HTTP request for casting a vote
 type CastVoteRequest struct {
 	VoterID string
 	// Marshalled representation of Ciphervote. It contains []{K:,C:}
 	Ballot CiphervoteJSON
+	// DelegatorID is set to cast the ballot on behalf of a voter who
+	// delegated their vote to VoterID
+	DelegatorID string `json:",omitempty"`
+}
+
+// DelegateVoteRequest defines the HTTP request for delegating a vote
+type DelegateVoteRequest struct {
+	VoterID    string
+	DelegateID string
+}
+
+// RevokeDelegationRequest defines the HTTP request for revoking the
+// delegation of a vote
+type RevokeDelegationRequest struct {
+	VoterID string
+}
+
+// WithdrawVoteRequest defines the HTTP request for withdrawing a vote
+type WithdrawVoteRequest struct {
+	VoterID string
+	// DelegatorID is set to withdraw the ballot cast on behalf of a voter who
+	// delegated their vote to VoterID
+	DelegatorID string `json:",omitempty"`
 }
 
 // CiphervoteJSON is the JSON representation of a ciphervote
@@ -43,6 +161,9 @@ type EGPairJSON struct {
 type UpdateFormRequest struct {
 	Action string
 	UserID string
+	// Reason and CloseBlock are only used to reopen a form
+	Reason     string `json:",omitempty"`
+	CloseBlock uint64 `json:",omitempty"`
 }
 
 // GetFormResponse defines the HTTP response when getting the form info
@@ -53,30 +174,104 @@ type GetFormResponse struct {
 	Status          uint16
 	Pubkey          string
 	Result          []etypes.Ballot
+	Tally           *etypes.Tally   // only set once the result is available
+	Outcome         *etypes.Outcome // only set once the result is available
 	Roster          []string
 	ChunksPerBallot int
 	BallotSize      int
 	Voters          []string
+	CloseBlock      uint64
+	Reopenings      []etypes.Reopening
+	ParentFormID    string
+	RunoffFormIDs   []string
+	TenantID        string
+	Delegations     []etypes.Delegation
+	Private         bool
 }
 
 // LightForm represents a light version of the form
 type LightForm struct {
-	FormID string
-	Title  etypes.Title
-	Status uint16
-	Pubkey string
+	FormID        string
+	Title         etypes.Title
+	Status        uint16
+	Pubkey        string
+	ParentFormID  string
+	RunoffFormIDs []string
+	TenantID      string
 }
 
 // GetFormsResponse defines the HTTP response when getting all forms
 // infos.
 type GetFormsResponse struct {
 	Forms []LightForm
+	// NextCursor is the cursor of the next page, if there is one
+	NextCursor string `json:",omitempty"`
+}
+
+// GetFormHistoryResponse defines the HTTP response when getting the history
+// of a form
+type GetFormHistoryResponse struct {
+	// FormID is hex-encoded
+	FormID string
+	Events []etypes.FormEvent
+}
+
+// FormUpdate defines the server-sent event of a committed transaction on a
+// form, with the state of the form after the transaction
+type FormUpdate struct {
+	Command          string
+	BlockIndex       uint64
+	Status           uint16
+	BallotCount      uint32
+	LiveBallots      uint32
+	ShuffleRounds    int
+	ShuffleThreshold int
+	PubsharesUnits   int
+}
+
+// FormStatusResponse defines the HTTP response when getting the live status
+// of a form
+type FormStatusResponse struct {
+	Status           uint16
+	BallotCount      uint32
+	LiveBallots      uint32
+	ShuffleRounds    int
+	ShuffleThreshold int
+	PubsharesUnits   int
+}
+
+// FormVerificationResponse defines the HTTP response when getting the data
+// to verify a form
+type FormVerificationResponse struct {
+	FormID           string
+	Status           uint16
+	Pubkey           string
+	ShuffleThreshold int
+	ShuffleInstances []ShuffleVerification
+	PubsharesUnits   []PubsharesVerification
+}
+
+// ShuffleVerification is a shuffle round of a form, with the hex-encoded key
+// of the shuffler and its proof
+type ShuffleVerification struct {
+	ShufflerPublicKey string
+	ShuffleProofs     string
+	BallotCount       int
+}
+
+// PubsharesVerification is a submission of public shares, with the
+// hex-encoded key of the node
+type PubsharesVerification struct {
+	Index  int
+	PubKey string
 }
 
-// HTTPError defines the standard error format
+// HTTPError defines the standard error format. Code is the HTTP status and
+// ErrorCode the stable code of the error.
 type HTTPError struct {
-	Title   string
-	Code    uint
-	Message string
-	Args    map[string]interface{}
+	Title     string
+	Code      uint
+	ErrorCode ErrorCode `json:",omitempty"`
+	Message   string
+	Args      map[string]interface{}
 }
@@ -0,0 +1,208 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	etypes "github.com/c4dt/d-voting/contracts/evoting/types"
+)
+
+// ErrorCode is the stable, machine-readable code of an error returned by the
+// proxy.
+type ErrorCode string
+
+const (
+	// ErrInternal is a failure of the proxy or of the node
+	ErrInternal ErrorCode = "INTERNAL"
+	// ErrBadRequest is a request that can't be decoded
+	ErrBadRequest ErrorCode = "BAD_REQUEST"
+	// ErrInvalidRequest is a request whose payload is invalid
+	ErrInvalidRequest ErrorCode = "INVALID_REQUEST"
+	// ErrInvalidSignature is a signed request that can't be verified
+	ErrInvalidSignature ErrorCode = "INVALID_SIGNATURE"
+	// ErrReplayedRequest is a signed request that is stale or already used
+	ErrReplayedRequest ErrorCode = "REPLAYED_REQUEST"
+	// ErrForbidden is a user without the permission to perform the request
+	ErrForbidden ErrorCode = "FORBIDDEN"
+	// ErrNotFound is an unknown endpoint or resource
+	ErrNotFound ErrorCode = "NOT_FOUND"
+	// ErrMethodNotAllowed is an endpoint that doesn't accept the method
+	ErrMethodNotAllowed ErrorCode = "METHOD_NOT_ALLOWED"
+	// ErrInvalidToken is a transaction token that can't be decoded or
+	// verified
+	ErrInvalidToken ErrorCode = "INVALID_TOKEN"
+	// ErrIdempotencyConflict is a request whose idempotency key is used by
+	// another request or by a request in progress
+	ErrIdempotencyConflict ErrorCode = "IDEMPOTENCY_CONFLICT"
+	// ErrTooManyRequests is a request with an idempotency key refused because
+	// the proxy tracks the maximum number of requests in progress
+	ErrTooManyRequests ErrorCode = "TOO_MANY_REQUESTS"
+	// ErrTooManyStreams is a stream refused because the proxy serves the
+	// maximum number of streams
+	ErrTooManyStreams ErrorCode = "TOO_MANY_STREAMS"
+	// ErrTransactionRejected is a transaction rejected by the smart contract
+	// for another reason than the ones below
+	ErrTransactionRejected ErrorCode = "TRANSACTION_REJECTED"
+
+	// ErrFormNotFound is an unknown form
+	ErrFormNotFound ErrorCode = "FORM_NOT_FOUND"
+	// ErrFormNotOpen is a form that isn't open
+	ErrFormNotOpen ErrorCode = "FORM_NOT_OPEN"
+	// ErrFormSuspended is a form that is suspended
+	ErrFormSuspended ErrorCode = "FORM_SUSPENDED"
+	// ErrInvalidFormStatus is a form whose status doesn't allow the request
+	ErrInvalidFormStatus ErrorCode = "INVALID_FORM_STATUS"
+	// ErrNotVoter is a user who isn't a voter of the form
+	ErrNotVoter ErrorCode = "NOT_VOTER"
+	// ErrNotOwner is a user who isn't an owner of the form
+	ErrNotOwner ErrorCode = "NOT_OWNER"
+	// ErrNotAdmin is a user who isn't an admin
+	ErrNotAdmin ErrorCode = "NOT_ADMIN"
+	// ErrInvalidBallot is a ballot whose points can't be decoded
+	ErrInvalidBallot ErrorCode = "INVALID_BALLOT"
+	// ErrBallotLength is a ballot with a wrong number of chunks
+	ErrBallotLength ErrorCode = "BALLOT_LENGTH"
+	// ErrRevoteNotAllowed is a voter who can't vote again
+	ErrRevoteNotAllowed ErrorCode = "REVOTE_NOT_ALLOWED"
+	// ErrInvalidDelegation is a delegation that isn't allowed
+	ErrInvalidDelegation ErrorCode = "INVALID_DELEGATION"
+	// ErrLimitExceeded is a form or a ballot that exceeds the resource limits
+	ErrLimitExceeded ErrorCode = "LIMIT_EXCEEDED"
+	// ErrActorNotFound is a form without DKG actor on the node
+	ErrActorNotFound ErrorCode = "ACTOR_NOT_FOUND"
+)
+
+// errorStatus is the HTTP status of each code. The codes not listed are
+// internal errors.
+var errorStatus = map[ErrorCode]uint{
+	ErrBadRequest:          http.StatusBadRequest,
+	ErrInvalidRequest:      http.StatusBadRequest,
+	ErrInvalidSignature:    http.StatusForbidden,
+	ErrReplayedRequest:     http.StatusBadRequest,
+	ErrForbidden:           http.StatusForbidden,
+	ErrNotFound:            http.StatusNotFound,
+	ErrMethodNotAllowed:    http.StatusMethodNotAllowed,
+	ErrInvalidToken:        http.StatusBadRequest,
+	ErrIdempotencyConflict: http.StatusConflict,
+	ErrTooManyRequests:     http.StatusTooManyRequests,
+	ErrTooManyStreams:      http.StatusServiceUnavailable,
+	ErrTransactionRejected: http.StatusBadRequest,
+	ErrFormNotFound:        http.StatusNotFound,
+	ErrFormNotOpen:         http.StatusConflict,
+	ErrFormSuspended:       http.StatusConflict,
+	ErrInvalidFormStatus:   http.StatusConflict,
+	ErrNotVoter:            http.StatusForbidden,
+	ErrNotOwner:            http.StatusForbidden,
+	ErrNotAdmin:            http.StatusForbidden,
+	ErrInvalidBallot:       http.StatusBadRequest,
+	ErrBallotLength:        http.StatusBadRequest,
+	ErrRevoteNotAllowed:    http.StatusConflict,
+	ErrInvalidDelegation:   http.StatusBadRequest,
+	ErrLimitExceeded:       http.StatusBadRequest,
+	ErrActorNotFound:       http.StatusNotFound,
+}
+
+// errorTitles is the title of the errors of each HTTP status
+var errorTitles = map[uint]string{
+	http.StatusBadRequest:         "bad request",
+	http.StatusForbidden:          "not authorized / forbidden",
+	http.StatusNotFound:           "not found",
+	http.StatusMethodNotAllowed:   "Not allowed",
+	http.StatusConflict:           "conflict",
+	http.StatusTooManyRequests:    "too many requests",
+	http.StatusServiceUnavailable: "service unavailable",
+}
+
+// Status returns the HTTP status of the code.
+func (c ErrorCode) Status() uint {
+	status, found := errorStatus[c]
+	if !found {
+		return http.StatusInternalServerError
+	}
+
+	return status
+}
+
+// rejectionCodes maps the codes of the errors of the smart contract to the
+// codes of the proxy.
+var rejectionCodes = map[etypes.Rejection]ErrorCode{
+	etypes.RejectFormNotFound:      ErrFormNotFound,
+	etypes.RejectFormNotOpen:       ErrFormNotOpen,
+	etypes.RejectFormSuspended:     ErrFormSuspended,
+	etypes.RejectNotVoter:          ErrNotVoter,
+	etypes.RejectNotOwner:          ErrNotOwner,
+	etypes.RejectNotAdmin:          ErrNotAdmin,
+	etypes.RejectForbidden:         ErrForbidden,
+	etypes.RejectBallotLength:      ErrBallotLength,
+	etypes.RejectRevoteNotAllowed:  ErrRevoteNotAllowed,
+	etypes.RejectInvalidDelegation: ErrInvalidDelegation,
+	etypes.RejectLimitExceeded:     ErrLimitExceeded,
+	etypes.RejectInvalidFormStatus: ErrInvalidFormStatus,
+	etypes.RejectInvalidRequest:    ErrInvalidRequest,
+}
+
+// ErrorCodeOf returns the code of an error or of the reason of a rejected
+// transaction, from the code of the first error of the smart contract it
+// contains. It returns fallback if there is none.
+func ErrorCodeOf(reason string, fallback ErrorCode) ErrorCode {
+	rejection, found := etypes.RejectionOf(reason)
+	if !found {
+		return fallback
+	}
+
+	code, found := rejectionCodes[rejection]
+	if !found {
+		return fallback
+	}
+
+	return code
+}
+
+// NewHTTPError returns the error sent for the request, with the HTTP status of
+// the code.
+func NewHTTPError(r *http.Request, code ErrorCode, err error, args map[string]interface{}) HTTPError {
+	if args == nil {
+		args = make(map[string]interface{})
+	}
+
+	args["url"] = r.URL.String()
+	args["method"] = r.Method
+
+	return NewResponseError(code, err, args)
+}
+
+// NewResponseError returns the error sent when the request isn't known, with
+// the HTTP status of the code.
+func NewResponseError(code ErrorCode, err error, args map[string]interface{}) HTTPError {
+	if args == nil {
+		args = make(map[string]interface{})
+	}
+
+	args["error"] = err.Error()
+
+	status := code.Status()
+
+	title, found := errorTitles[status]
+	if !found {
+		title = "Internal server error"
+	}
+
+	return HTTPError{
+		Title:     title,
+		Code:      status,
+		ErrorCode: code,
+		Message:   "A problem occurred on the proxy",
+		Args:      args,
+	}
+}
+
+// Send writes the error as JSON with its HTTP status.
+func (e HTTPError) Send(w http.ResponseWriter) {
+	buf, _ := json.MarshalIndent(&e, "", "  ")
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(int(e.Code))
+	fmt.Fprintln(w, string(buf))
+}
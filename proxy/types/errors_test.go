@@ -0,0 +1,68 @@
+package types
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+)
+
+func TestErrorCode_Status(t *testing.T) {
+	require.Equal(t, uint(http.StatusNotFound), ErrFormNotFound.Status())
+	require.Equal(t, uint(http.StatusForbidden), ErrNotVoter.Status())
+	require.Equal(t, uint(http.StatusConflict), ErrFormNotOpen.Status())
+	require.Equal(t, uint(http.StatusBadRequest), ErrBallotLength.Status())
+	require.Equal(t, uint(http.StatusTooManyRequests), ErrTooManyRequests.Status())
+	require.Equal(t, uint(http.StatusInternalServerError), ErrInternal.Status())
+	require.Equal(t, uint(http.StatusInternalServerError), ErrorCode("UNKNOWN").Status())
+}
+
+func TestErrorCodeOf(t *testing.T) {
+	reasons := map[string]ErrorCode{
+		"failed to get form: [FORM_NOT_FOUND] no form found":                                      ErrFormNotFound,
+		"[NOT_VOTER] The user 123456 doesn't have the Voter permission on the form.":              ErrNotVoter,
+		"[FORM_NOT_OPEN] the form is not open, current status: 0":                                 ErrFormNotOpen,
+		"[FORM_SUSPENDED] the form is suspended":                                                  ErrFormSuspended,
+		"[BALLOT_LENGTH] the ballot has unexpected length: 1 != 2":                                ErrBallotLength,
+		"[REVOTE_NOT_ALLOWED] voter 123456 already voted and re-voting is not allowed":            ErrRevoteNotAllowed,
+		"[INVALID_DELEGATION] invalid delegation: the voter 123456 delegated their vote":          ErrInvalidDelegation,
+		"[NOT_ADMIN] The performing user 123456 is not an admin.":                                 ErrNotAdmin,
+		"[NOT_OWNER] The user 123456 doesn't have the Owner permission on the form.":              ErrNotOwner,
+		"[FORBIDDEN] The user 123456 doesn't have the Owner or Registrar permission on the form.": ErrForbidden,
+		"[FORBIDDEN] The user 123456 doesn't have the Owner or Auditor permission on the form.":   ErrForbidden,
+		"[FORBIDDEN] not allowed: [NOT_OWNER] The user 123456 doesn't have the Owner permission.": ErrForbidden,
+		"[LIMIT_EXCEEDED] the form has 11 questions, the limit is 10":                             ErrLimitExceeded,
+	}
+
+	for reason, code := range reasons {
+		require.Equal(t, code, ErrorCodeOf(reason, ErrInternal), reason)
+	}
+
+	require.Equal(t, ErrTransactionRejected, ErrorCodeOf("something else", ErrTransactionRejected))
+	require.Equal(t, ErrTransactionRejected, ErrorCodeOf("the limit is 10", ErrTransactionRejected))
+	require.Equal(t, ErrTransactionRejected, ErrorCodeOf("[UNKNOWN] oops", ErrTransactionRejected))
+}
+
+func TestHTTPError_Send(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/evoting/forms/abcd/vote", nil)
+	w := httptest.NewRecorder()
+
+	NewHTTPError(r, ErrBallotLength, xerrors.Errorf("oops"), map[string]interface{}{"key": "value"}).Send(w)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+
+	var httpErr HTTPError
+	err := json.NewDecoder(w.Body).Decode(&httpErr)
+	require.NoError(t, err)
+
+	require.Equal(t, "bad request", httpErr.Title)
+	require.Equal(t, uint(http.StatusBadRequest), httpErr.Code)
+	require.Equal(t, ErrBallotLength, httpErr.ErrorCode)
+	require.Equal(t, "oops", httpErr.Args["error"])
+	require.Equal(t, "value", httpErr.Args["key"])
+	require.Equal(t, http.MethodPost, httpErr.Args["method"])
+}
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+
+	"golang.org/x/xerrors"
+)
+
+// SignedRequestHeader is the header of the requests without a body, such as
+// GET requests, that carries their signed request.
+const SignedRequestHeader = "Signed-Request"
+
+// requestKey is the key of the parsed request in the context of a request
+type requestKey struct{}
+
+// ParsedRequest is the body of a request, read and parsed once by the first
+// middleware and passed to the next ones and to the handler in the context of
+// the request.
+type ParsedRequest struct {
+	Body []byte
+
+	// Signed is the signed request of the body. It is only set if SignedErr
+	// is nil.
+	Signed    SignedRequest
+	SignedErr error
+
+	// Checked tells if the signature was checked with the keyring.
+	Checked bool
+	// Signer is the ID of the trusted key that verified the signature, or
+	// empty if the signature wasn't verified.
+	Signer string
+}
+
+// ParseRequest reads the body of the request and parses it as a signed
+// request. A request without a body is parsed from its Signed-Request header
+// instead. The signature isn't verified.
+func ParseRequest(r *http.Request) (*ParsedRequest, error) {
+	var body []byte
+
+	if r.Body != nil {
+		var err error
+
+		body, err = io.ReadAll(r.Body)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to read body: %v", err)
+		}
+	}
+
+	if len(body) == 0 {
+		body = []byte(r.Header.Get(SignedRequestHeader))
+	}
+
+	parsed := &ParsedRequest{Body: body}
+	parsed.Signed, parsed.SignedErr = NewSignedRequest(bytes.NewReader(body))
+
+	return parsed, nil
+}
+
+// WithParsedRequest returns the request with the parsed request in its
+// context.
+func WithParsedRequest(r *http.Request, parsed *ParsedRequest) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), requestKey{}, parsed))
+}
+
+// ParsedRequestOf returns the parsed request kept in the context of the
+// request, or parses its body if there is none.
+func ParsedRequestOf(r *http.Request) (*ParsedRequest, error) {
+	parsed, found := r.Context().Value(requestKey{}).(*ParsedRequest)
+	if found {
+		return parsed, nil
+	}
+
+	return ParseRequest(r)
+}
+
+// SignedRequestOf returns the signed request of the body of the request.
+func SignedRequestOf(r *http.Request) (SignedRequest, error) {
+	parsed, err := ParsedRequestOf(r)
+	if err != nil {
+		return SignedRequest{}, err
+	}
+
+	return parsed.Signed, parsed.SignedErr
+}
@@ -0,0 +1,50 @@
+package types
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsedRequestOf(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/evoting/forms", strings.NewReader(`{"Payload":"abcd"}`))
+
+	parsed, err := ParsedRequestOf(r)
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"Payload":"abcd"}`), parsed.Body)
+	require.NoError(t, parsed.SignedErr)
+	require.Equal(t, "abcd", parsed.Signed.Payload)
+
+	// the parsed request is kept in the context instead of the body
+	r = WithParsedRequest(r, parsed)
+
+	kept, err := ParsedRequestOf(r)
+	require.NoError(t, err)
+	require.Same(t, parsed, kept)
+
+	signed, err := SignedRequestOf(r)
+	require.NoError(t, err)
+	require.Equal(t, "abcd", signed.Payload)
+
+	r = httptest.NewRequest(http.MethodPost, "/evoting/forms", strings.NewReader("{"))
+
+	_, err = SignedRequestOf(r)
+	require.Error(t, err)
+}
+
+func TestParsedRequestOf_Header(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/evoting/forms/abcd/status", nil)
+	r.Header.Set(SignedRequestHeader, `{"Payload":"abcd"}`)
+
+	signed, err := SignedRequestOf(r)
+	require.NoError(t, err)
+	require.Equal(t, "abcd", signed.Payload)
+
+	r = httptest.NewRequest(http.MethodGet, "/evoting/forms/abcd/status", nil)
+
+	_, err = SignedRequestOf(r)
+	require.Error(t, err)
+}
@@ -1,11 +1,13 @@
 package types
 
 import (
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"io"
+	"time"
 
 	"go.dedis.ch/kyber/v3"
 	"go.dedis.ch/kyber/v3/sign/schnorr"
@@ -32,6 +34,8 @@ func NewSignedRequest(r io.Reader) (SignedRequest, error) {
 type SignedRequest struct {
 	Payload   string // url base64 encoded json message
 	Signature string // hex encoded signature on sha256(Payload)
+	// KeyID is the ID of the key that signed, or empty for the default key
+	KeyID string `json:",omitempty"`
 }
 
 // GetMessage JSON unmarshals the payload to the given element. The given
@@ -90,3 +94,60 @@ func (s SignedRequest) GetAndVerify(pk kyber.Point, el interface{}) error {
 
 	return nil
 }
+
+// Freshness is carried by the payload of a signed request, next to the
+// fields of the request, to prevent its replay. Timestamp is in unix seconds
+// and Nonce must not be reused. Method and Path are those of the request, so
+// that it can't be sent to another route or form.
+type Freshness struct {
+	Timestamp int64
+	Nonce     string
+	Method    string
+	Path      string
+}
+
+// NewFreshness returns a freshness for a request to the method and path, with
+// the current time and a random nonce.
+func NewFreshness(method, path string) (Freshness, error) {
+	nonce := make([]byte, 16)
+
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return Freshness{}, xerrors.Errorf("failed to generate nonce: %v", err)
+	}
+
+	return Freshness{
+		Timestamp: time.Now().Unix(),
+		Nonce:     hex.EncodeToString(nonce),
+		Method:    method,
+		Path:      path,
+	}, nil
+}
+
+// NewPayload returns the JSON payload of msg with a new freshness for a request
+// to the method and path. msg must be encoded as a JSON object.
+func NewPayload(msg interface{}, method, path string) ([]byte, error) {
+	jsonMsg, err := json.Marshal(msg)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to marshal json: %v", err)
+	}
+
+	var fields map[string]json.RawMessage
+
+	err = json.Unmarshal(jsonMsg, &fields)
+	if err != nil || fields == nil {
+		return nil, xerrors.Errorf("message is not a json object: %s", jsonMsg)
+	}
+
+	freshness, err := NewFreshness(method, path)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get freshness: %v", err)
+	}
+
+	fields["Timestamp"], _ = json.Marshal(freshness.Timestamp)
+	fields["Nonce"], _ = json.Marshal(freshness.Nonce)
+	fields["Method"], _ = json.Marshal(freshness.Method)
+	fields["Path"], _ = json.Marshal(freshness.Path)
+
+	return json.Marshal(fields)
+}
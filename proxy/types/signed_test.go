@@ -5,7 +5,9 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.dedis.ch/kyber/v3/sign/schnorr"
@@ -217,3 +219,24 @@ func TestGetAndVerify_ok(t *testing.T) {
 
 	require.Equal(t, expected, req)
 }
+
+func TestNewPayload(t *testing.T) {
+	payload, err := NewPayload(struct{ UserID string }{UserID: "123456"}, "POST", "/evoting/forms/abcd/verify")
+	require.NoError(t, err)
+
+	var msg struct {
+		UserID string
+		Freshness
+	}
+
+	err = json.Unmarshal(payload, &msg)
+	require.NoError(t, err)
+	require.Equal(t, "123456", msg.UserID)
+	require.Len(t, msg.Nonce, 32)
+	require.InDelta(t, time.Now().Unix(), msg.Timestamp, 5)
+	require.Equal(t, "POST", msg.Method)
+	require.Equal(t, "/evoting/forms/abcd/verify", msg.Path)
+
+	_, err = NewPayload([]string{}, "POST", "/evoting/forms")
+	require.EqualError(t, err, "message is not a json object: []")
+}
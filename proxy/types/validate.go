@@ -0,0 +1,305 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	etypes "github.com/c4dt/d-voting/contracts/evoting/types"
+)
+
+// Validator is implemented by the requests that can be checked before a
+// transaction is built from them.
+type Validator interface {
+	Validate() error
+}
+
+// ValidationError is returned when a field of a request is invalid.
+type ValidationError struct {
+	Field  string
+	Reason string
+}
+
+// Error implements error.
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Reason)
+}
+
+// Validate implements Validator. The user must be a SCIPER and the
+// configuration must be coherent.
+func (req CreateFormRequest) Validate() error {
+	err := validateSciper("UserID", req.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !req.Configuration.IsValid() {
+		return ValidationError{Field: "Configuration", Reason: "the configuration is not valid"}
+	}
+
+	return nil
+}
+
+// Validate implements Validator. The voters must be SCIPERs and every ElGamal
+// pair of the ballot must be set.
+func (req CastVoteRequest) Validate() error {
+	err := validateSciper("VoterID", req.VoterID)
+	if err != nil {
+		return err
+	}
+
+	if req.DelegatorID != "" {
+		err = validateSciper("DelegatorID", req.DelegatorID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(req.Ballot) == 0 {
+		return ValidationError{Field: "Ballot", Reason: "the ballot is empty"}
+	}
+
+	for i, pair := range req.Ballot {
+		if len(pair.K) == 0 {
+			return ValidationError{Field: fmt.Sprintf("Ballot[%d].K", i), Reason: "missing value"}
+		}
+
+		if len(pair.C) == 0 {
+			return ValidationError{Field: fmt.Sprintf("Ballot[%d].C", i), Reason: "missing value"}
+		}
+	}
+
+	return nil
+}
+
+// Validate implements Validator. Both users must be SCIPERs.
+func (req PermissionOperationRequest) Validate() error {
+	err := validateSciper("TargetUserID", req.TargetUserID)
+	if err != nil {
+		return err
+	}
+
+	return validateSciper("PerformingUserID", req.PerformingUserID)
+}
+
+// Validate implements Validator. The limits that are set must be within the
+// hard limits, the others keep their current value.
+func (req SetLimitsRequest) Validate() error {
+	err := validateSciper("PerformingUserID", req.PerformingUserID)
+	if err != nil {
+		return err
+	}
+
+	err = etypes.DefaultLimits.Merge(req.Limits).Validate()
+	if err != nil {
+		return ValidationError{Field: "Limits", Reason: err.Error()}
+	}
+
+	return nil
+}
+
+// Validate implements Validator. The target user is needed to add or remove
+// an admin and the threshold to change it.
+func (req ProposeAdminChangeRequest) Validate() error {
+	err := validateSciper("PerformingUserID", req.PerformingUserID)
+	if err != nil {
+		return err
+	}
+
+	switch etypes.ProposalAction(req.Action) {
+	case etypes.ProposalAddAdmin, etypes.ProposalRemoveAdmin:
+		err = validateSciper("TargetUserID", req.TargetUserID)
+		if err != nil {
+			return err
+		}
+	case etypes.ProposalThreshold:
+		if req.Threshold < 1 {
+			return ValidationError{Field: "Threshold", Reason: "must be at least 1"}
+		}
+	default:
+		return ValidationError{Field: "Action", Reason: fmt.Sprintf("unknown action: %q", req.Action)}
+	}
+
+	if req.Lifetime > etypes.MaxProposalLifetime {
+		return ValidationError{
+			Field:  "Lifetime",
+			Reason: fmt.Sprintf("must be at most %d blocks", etypes.MaxProposalLifetime),
+		}
+	}
+
+	return nil
+}
+
+// Validate implements Validator. The user must be a SCIPER.
+func (req AdminProposalVoteRequest) Validate() error {
+	return validateSciper("PerformingUserID", req.PerformingUserID)
+}
+
+// Validate implements Validator. The tenant must have an ID and a name, and
+// its first admin must be a SCIPER.
+func (req CreateTenantRequest) Validate() error {
+	if req.TenantID == "" {
+		return ValidationError{Field: "TenantID", Reason: "missing value"}
+	}
+
+	if req.Name == "" {
+		return ValidationError{Field: "Name", Reason: "missing value"}
+	}
+
+	err := validateSciper("AdminID", req.AdminID)
+	if err != nil {
+		return err
+	}
+
+	return validateSciper("PerformingUserID", req.PerformingUserID)
+}
+
+// Validate implements Validator. The role must be known and both users must
+// be SCIPERs.
+func (req RoleOperationRequest) Validate() error {
+	switch etypes.FormRole(req.Role) {
+	case etypes.RoleAuditor, etypes.RoleRegistrar, etypes.RoleObserver:
+	default:
+		return ValidationError{Field: "Role", Reason: fmt.Sprintf("unknown role: %q", req.Role)}
+	}
+
+	err := validateSciper("TargetUserID", req.TargetUserID)
+	if err != nil {
+		return err
+	}
+
+	return validateSciper("PerformingUserID", req.PerformingUserID)
+}
+
+// Validate implements Validator. The user must be a SCIPER.
+func (req VerifyFormRequest) Validate() error {
+	return validateSciper("UserID", req.UserID)
+}
+
+// Validate implements Validator. The user must be a SCIPER.
+func (req ReadFormRequest) Validate() error {
+	return validateSciper("UserID", req.UserID)
+}
+
+// formActions are the actions that update the status of a form
+var formActions = []string{"open", "close", "reopen", "suspend", "resume", "combineShares", "cancel"}
+
+// Validate implements Validator. The action must be known and the user must
+// be a SCIPER.
+func (req UpdateFormRequest) Validate() error {
+	err := validateAction(req.Action, formActions)
+	if err != nil {
+		return err
+	}
+
+	return validateSciper("UserID", req.UserID)
+}
+
+// Validate implements Validator. The user must be a SCIPER.
+func (req DeleteFormRequest) Validate() error {
+	return validateSciper("UserID", req.UserID)
+}
+
+// Validate implements Validator. The question must be set and exactly one
+// rule must be given.
+func (req CreateRunoffRequest) Validate() error {
+	err := validateSciper("UserID", req.UserID)
+	if err != nil {
+		return err
+	}
+
+	if req.QuestionID == "" {
+		return ValidationError{Field: "QuestionID", Reason: "missing value"}
+	}
+
+	if !req.Rule.IsValid() {
+		return ValidationError{Field: "Rule", Reason: "exactly one of TopN and MinPercent must be set"}
+	}
+
+	return nil
+}
+
+// Validate implements Validator. The voters must be SCIPERs.
+func (req WithdrawVoteRequest) Validate() error {
+	err := validateSciper("VoterID", req.VoterID)
+	if err != nil {
+		return err
+	}
+
+	if req.DelegatorID != "" {
+		return validateSciper("DelegatorID", req.DelegatorID)
+	}
+
+	return nil
+}
+
+// Validate implements Validator. Both voters must be SCIPERs and a voter
+// can't delegate to themselves.
+func (req DelegateVoteRequest) Validate() error {
+	err := validateSciper("VoterID", req.VoterID)
+	if err != nil {
+		return err
+	}
+
+	err = validateSciper("DelegateID", req.DelegateID)
+	if err != nil {
+		return err
+	}
+
+	if req.VoterID == req.DelegateID {
+		return ValidationError{Field: "DelegateID", Reason: "a voter can't delegate to themselves"}
+	}
+
+	return nil
+}
+
+// Validate implements Validator. The voter must be a SCIPER.
+func (req RevokeDelegationRequest) Validate() error {
+	return validateSciper("VoterID", req.VoterID)
+}
+
+// Validate implements Validator. The form ID must be hex-encoded.
+func (req NewDKGRequest) Validate() error {
+	if req.FormID == "" {
+		return ValidationError{Field: "FormID", Reason: "missing value"}
+	}
+
+	_, err := hex.DecodeString(req.FormID)
+	if err != nil {
+		return ValidationError{Field: "FormID", Reason: fmt.Sprintf("not hex-encoded: %q", req.FormID)}
+	}
+
+	return nil
+}
+
+// Validate implements Validator. The action must be known.
+func (req UpdateDKG) Validate() error {
+	return validateAction(req.Action, []string{"setup", "computePubshares"})
+}
+
+// Validate implements Validator. The action must be known.
+func (req UpdateShuffle) Validate() error {
+	return validateAction(req.Action, []string{"shuffle"})
+}
+
+func validateAction(action string, actions []string) error {
+	for _, known := range actions {
+		if action == known {
+			return nil
+		}
+	}
+
+	return ValidationError{Field: "Action", Reason: fmt.Sprintf("unknown action: %q", action)}
+}
+
+func validateSciper(field, userID string) error {
+	if userID == "" {
+		return ValidationError{Field: field, Reason: "missing value"}
+	}
+
+	_, err := etypes.SciperToInt(userID)
+	if err != nil {
+		return ValidationError{Field: field, Reason: fmt.Sprintf("not a SCIPER: %q", userID)}
+	}
+
+	return nil
+}
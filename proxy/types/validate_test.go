@@ -0,0 +1,142 @@
+package types
+
+import (
+	"testing"
+
+	etypes "github.com/c4dt/d-voting/contracts/evoting/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateFormRequest_Validate(t *testing.T) {
+	req := CreateFormRequest{UserID: "123456"}
+	require.NoError(t, req.Validate())
+
+	req.UserID = ""
+	require.EqualError(t, req.Validate(), "invalid UserID: missing value")
+
+	req.UserID = "admin"
+	require.EqualError(t, req.Validate(), `invalid UserID: not a SCIPER: "admin"`)
+
+	req.UserID = "123456"
+	req.Configuration.Quorum = etypes.Quorum{MinTurnoutPercent: 101}
+	require.Equal(t, ValidationError{Field: "Configuration", Reason: "the configuration is not valid"},
+		req.Validate())
+}
+
+func TestCastVoteRequest_Validate(t *testing.T) {
+	req := CastVoteRequest{
+		VoterID: "123456",
+		Ballot:  CiphervoteJSON{{K: []byte{1}, C: []byte{2}}},
+	}
+	require.NoError(t, req.Validate())
+
+	req.DelegatorID = "12"
+	require.EqualError(t, req.Validate(), `invalid DelegatorID: not a SCIPER: "12"`)
+
+	req.DelegatorID = "234567"
+	require.NoError(t, req.Validate())
+
+	req.Ballot = append(req.Ballot, EGPairJSON{K: []byte{1}})
+	require.EqualError(t, req.Validate(), "invalid Ballot[1].C: missing value")
+
+	req.Ballot = nil
+	require.EqualError(t, req.Validate(), "invalid Ballot: the ballot is empty")
+
+	req.VoterID = ""
+	require.EqualError(t, req.Validate(), "invalid VoterID: missing value")
+}
+
+func TestPermissionOperationRequest_Validate(t *testing.T) {
+	req := PermissionOperationRequest{TargetUserID: "123456", PerformingUserID: "234567"}
+	require.NoError(t, req.Validate())
+
+	req.PerformingUserID = ""
+	require.EqualError(t, req.Validate(), "invalid PerformingUserID: missing value")
+
+	req.TargetUserID = "1234567"
+	require.EqualError(t, req.Validate(), `invalid TargetUserID: not a SCIPER: "1234567"`)
+}
+
+func TestSetLimitsRequest_Validate(t *testing.T) {
+	req := SetLimitsRequest{PerformingUserID: "123456"}
+	require.NoError(t, req.Validate())
+
+	req.Limits.MaxVoters = etypes.HardLimits.MaxVoters + 1
+	err := req.Validate()
+	require.Error(t, err)
+	require.Equal(t, "Limits", err.(ValidationError).Field)
+}
+
+func TestProposeAdminChangeRequest_Validate(t *testing.T) {
+	req := ProposeAdminChangeRequest{Action: "add", TargetUserID: "123456", PerformingUserID: "234567"}
+	require.NoError(t, req.Validate())
+
+	req.TargetUserID = ""
+	require.EqualError(t, req.Validate(), "invalid TargetUserID: missing value")
+
+	req.Action = "threshold"
+	require.EqualError(t, req.Validate(), "invalid Threshold: must be at least 1")
+
+	req.Threshold = 2
+	require.NoError(t, req.Validate())
+
+	req.Lifetime = etypes.MaxProposalLifetime + 1
+	require.Equal(t, "Lifetime", req.Validate().(ValidationError).Field)
+
+	req.Action = "promote"
+	require.EqualError(t, req.Validate(), `invalid Action: unknown action: "promote"`)
+}
+
+func TestRoleOperationRequest_Validate(t *testing.T) {
+	req := RoleOperationRequest{Role: "auditor", TargetUserID: "123456", PerformingUserID: "234567"}
+	require.NoError(t, req.Validate())
+
+	req.Role = "owner"
+	require.EqualError(t, req.Validate(), `invalid Role: unknown role: "owner"`)
+}
+
+func TestUpdateFormRequest_Validate(t *testing.T) {
+	req := UpdateFormRequest{Action: "open", UserID: "123456"}
+	require.NoError(t, req.Validate())
+
+	req.Action = "archive"
+	require.EqualError(t, req.Validate(), `invalid Action: unknown action: "archive"`)
+}
+
+func TestCreateRunoffRequest_Validate(t *testing.T) {
+	req := CreateRunoffRequest{UserID: "123456", QuestionID: "q1", Rule: etypes.RunoffRule{TopN: 2}}
+	require.NoError(t, req.Validate())
+
+	req.Rule.MinPercent = 10
+	require.EqualError(t, req.Validate(),
+		"invalid Rule: exactly one of TopN and MinPercent must be set")
+
+	req.QuestionID = ""
+	require.EqualError(t, req.Validate(), "invalid QuestionID: missing value")
+}
+
+func TestWithdrawVoteRequest_Validate(t *testing.T) {
+	req := WithdrawVoteRequest{VoterID: "123456"}
+	require.NoError(t, req.Validate())
+
+	req.DelegatorID = "12"
+	require.EqualError(t, req.Validate(), `invalid DelegatorID: not a SCIPER: "12"`)
+
+	req.DelegatorID = "234567"
+	require.NoError(t, req.Validate())
+}
+
+func TestDelegateVoteRequest_Validate(t *testing.T) {
+	req := DelegateVoteRequest{VoterID: "123456", DelegateID: "234567"}
+	require.NoError(t, req.Validate())
+
+	req.DelegateID = req.VoterID
+	require.EqualError(t, req.Validate(), "invalid DelegateID: a voter can't delegate to themselves")
+}
+
+func TestNewDKGRequest_Validate(t *testing.T) {
+	require.NoError(t, NewDKGRequest{FormID: "abcd"}.Validate())
+	require.EqualError(t, NewDKGRequest{FormID: "xyz"}.Validate(), `invalid FormID: not hex-encoded: "xyz"`)
+	require.EqualError(t, UpdateDKG{Action: "shuffle"}.Validate(), `invalid Action: unknown action: "shuffle"`)
+	require.NoError(t, UpdateShuffle{Action: "shuffle"}.Validate())
+}
@@ -0,0 +1,118 @@
+package proxy
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+
+	ptypes "github.com/c4dt/d-voting/proxy/types"
+	"github.com/gorilla/mux"
+	"golang.org/x/xerrors"
+)
+
+// ParseRequests returns a middleware that reads and parses the body of the
+// requests once, or the Signed-Request header of the requests without a body,
+// and verifies their signature with the keyring. The next
+// middlewares and the handlers get the parsed request from the context of the
+// request instead of reading the body again.
+func ParseRequests(keys *Keyring) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !hasSignedRequest(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			parsed, err := ptypes.ParsedRequestOf(r)
+			if err != nil {
+				BadRequestError(w, r, xerrors.Errorf("failed to parse request: %v", err), nil)
+				return
+			}
+
+			verifyRequest(parsed, keys)
+
+			next.ServeHTTP(w, ptypes.WithParsedRequest(r, parsed))
+		})
+	}
+}
+
+// hasSignedRequest tells if the request may carry a signed request. The
+// requests without a body only do in their Signed-Request header.
+func hasSignedRequest(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return r.Header.Get(ptypes.SignedRequestHeader) != ""
+	}
+
+	return true
+}
+
+// verifyRequest verifies the signature of the parsed request with the
+// keyring and sets its signer, unless the signature was already checked.
+func verifyRequest(parsed *ptypes.ParsedRequest, keys *Keyring) {
+	if parsed.Checked {
+		return
+	}
+
+	parsed.Checked = true
+
+	if parsed.SignedErr != nil || keys.Verify(parsed.Signed) != nil {
+		return
+	}
+
+	parsed.Signer = parsed.Signed.KeyID
+	if parsed.Signer == "" {
+		parsed.Signer = DefaultKeyID
+	}
+}
+
+// ValidatePayload returns a middleware that decodes the payload of the signed
+// request in a new value of the type of req and validates it. An invalid
+// payload is answered with a bad request error, before next builds any
+// transaction. The signature is still verified by next, which gets the
+// parsed request.
+func ValidatePayload(req ptypes.Validator, next http.HandlerFunc) http.HandlerFunc {
+	reqType := reflect.TypeOf(req)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		parsed, err := ptypes.ParsedRequestOf(r)
+		if err != nil {
+			BadRequestError(w, r, xerrors.Errorf("failed to parse request: %v", err), nil)
+			return
+		}
+
+		r = ptypes.WithParsedRequest(r, parsed)
+
+		signed := parsed.Signed
+		if parsed.SignedErr != nil {
+			BadRequestError(w, r, newSignedErr(parsed.SignedErr), nil)
+			return
+		}
+
+		payload := reflect.New(reqType).Interface().(ptypes.Validator)
+
+		err = signed.GetMessage(payload)
+		if err != nil {
+			BadRequestError(w, r, xerrors.Errorf("failed to get payload: %v", err), nil)
+			return
+		}
+
+		err = payload.Validate()
+		if err != nil {
+			var args map[string]interface{}
+
+			var validationErr ptypes.ValidationError
+			if errors.As(err, &validationErr) {
+				args = map[string]interface{}{
+					"field":  validationErr.Field,
+					"reason": validationErr.Reason,
+				}
+			}
+
+			ErrorResponse(w, r, ptypes.ErrInvalidRequest, xerrors.Errorf("invalid request: %v", err), args)
+			return
+		}
+
+		next(w, r)
+	}
+}
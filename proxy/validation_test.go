@@ -0,0 +1,134 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ptypes "github.com/c4dt/d-voting/proxy/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePayload(t *testing.T) {
+	var body []byte
+
+	handler := ValidatePayload(ptypes.PermissionOperationRequest{},
+		func(w http.ResponseWriter, r *http.Request) {
+			parsed, err := ptypes.ParsedRequestOf(r)
+			require.NoError(t, err)
+
+			body = parsed.Body
+		})
+
+	// the handler gets the parsed body of a valid request
+	req := signedBody(t, ptypes.PermissionOperationRequest{
+		TargetUserID:     "123456",
+		PerformingUserID: "234567",
+	})
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, "/evoting/addadmin", bytes.NewReader(req)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, req, body)
+
+	// an invalid request doesn't reach the handler
+	body = nil
+	req = signedBody(t, ptypes.PermissionOperationRequest{TargetUserID: "123456"})
+
+	rec = httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, "/evoting/addadmin", bytes.NewReader(req)))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Nil(t, body)
+
+	var httpErr ptypes.HTTPError
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &httpErr))
+	require.Equal(t, uint(http.StatusBadRequest), httpErr.Code)
+	require.Equal(t, "PerformingUserID", httpErr.Args["field"])
+	require.Equal(t, "missing value", httpErr.Args["reason"])
+
+	// so does a malformed request
+	rec = httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, "/evoting/addadmin", bytes.NewBufferString("{")))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Nil(t, body)
+}
+
+func TestParseRequests(t *testing.T) {
+	secret := suite.Scalar().Pick(suite.RandomStream())
+	pk := suite.Point().Mul(secret, nil)
+
+	var parsed *ptypes.ParsedRequest
+
+	handler := ParseRequests(newTestKeyring(t, pk))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var err error
+			parsed, err = ptypes.ParsedRequestOf(r)
+			require.NoError(t, err)
+
+			// the body is not read again
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.Empty(t, body)
+		}))
+
+	serve := func(body []byte) {
+		parsed = nil
+		handler.ServeHTTP(httptest.NewRecorder(),
+			httptest.NewRequest(http.MethodPost, "/evoting/forms", bytes.NewReader(body)))
+	}
+
+	payload, err := ptypes.NewPayload(ptypes.VerifyFormRequest{UserID: "123456"}, http.MethodPost, "/evoting/forms")
+	require.NoError(t, err)
+
+	signed := signPayload(t, secret, payload)
+
+	serve(signed)
+	require.Equal(t, signed, parsed.Body)
+	require.NoError(t, parsed.SignedErr)
+	require.Equal(t, DefaultKeyID, parsed.Signer)
+
+	// a request signed by an unknown key is parsed but not verified
+	serve(signedBody(t, ptypes.VerifyFormRequest{UserID: "123456"}))
+	require.NoError(t, parsed.SignedErr)
+	require.Empty(t, parsed.Signer)
+
+	serve([]byte("{"))
+	require.Error(t, parsed.SignedErr)
+	require.Empty(t, parsed.Signer)
+
+	// a GET request is signed in its header, if it is signed at all
+	payload, err = ptypes.NewPayload(ptypes.ReadFormRequest{UserID: "123456"}, http.MethodGet, "/evoting/forms/abcd/status")
+	require.NoError(t, err)
+
+	signed = signPayload(t, secret, payload)
+
+	r := httptest.NewRequest(http.MethodGet, "/evoting/forms/abcd/status", nil)
+	r.Header.Set(ptypes.SignedRequestHeader, string(signed))
+
+	parsed = nil
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	require.Equal(t, signed, parsed.Body)
+	require.Equal(t, DefaultKeyID, parsed.Signer)
+
+	parsed = nil
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/evoting/forms/abcd/status", nil))
+	require.Empty(t, parsed.Body)
+	require.Empty(t, parsed.Signer)
+}
+
+func signedBody(t *testing.T, payload interface{}) []byte {
+	buf, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	signed, err := json.Marshal(ptypes.SignedRequest{
+		Payload:   base64.URLEncoding.EncodeToString(buf),
+		Signature: "aef123",
+	})
+	require.NoError(t, err)
+
+	return signed
+}
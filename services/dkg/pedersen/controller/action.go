@@ -24,6 +24,7 @@ import (
 	"golang.org/x/xerrors"
 
 	eproxy "github.com/c4dt/d-voting/proxy"
+	ptypes "github.com/c4dt/d-voting/proxy/types"
 )
 
 var suite = suites.MustFind("Ed25519")
@@ -276,16 +277,19 @@ func (a *RegisterHandlersAction) Execute(ctx node.Context) error {
 		return xerrors.Errorf("failed to unmarshal proxy key: %v", err)
 	}
 
+	keys, err := eproxy.ResolveKeyring(ctx.Injector, proxykey)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve keyring: %v", err)
+	}
+
 	router := mux.NewRouter()
+	router.Use(eproxy.ParseRequests(keys))
+	router.Use(eproxy.RejectReplays(keys, eproxy.ResolveReplayGuard(ctx.Injector)))
 
-	ep := eproxy.NewDKG(mngr, dkg, proxykey)
+	ep := eproxy.NewDKG(mngr, dkg, keys)
 
 	// Link the request to the proxy
-	router.HandleFunc("/evoting/services/dkg/actors", ep.NewDKGActor).Methods("POST")
-	router.HandleFunc("/evoting/services/dkg/actors", eproxy.AllowCORS).Methods("OPTIONS")
-	router.HandleFunc("/evoting/services/dkg/actors/{formID}", ep.Actor).Methods("GET")
-	router.HandleFunc("/evoting/services/dkg/actors/{formID}", ep.EditDKGActor).Methods("PUT")
-	router.HandleFunc("/evoting/services/dkg/actors/{formID}", eproxy.AllowCORS).Methods("OPTIONS")
+	registerRoutes(router, ep)
 
 	router.NotFoundHandler = http.HandlerFunc(eproxy.NotFoundHandler)
 	router.MethodNotAllowedHandler = http.HandlerFunc(eproxy.NotAllowedHandler)
@@ -297,6 +301,15 @@ func (a *RegisterHandlersAction) Execute(ctx node.Context) error {
 	return nil
 }
 
+// registerRoutes registers the handlers of the DKG proxy on the router
+func registerRoutes(router *mux.Router, ep eproxy.DKG) {
+	router.HandleFunc("/evoting/services/dkg/actors", eproxy.ValidatePayload(ptypes.NewDKGRequest{}, ep.NewDKGActor)).Methods("POST")
+	router.HandleFunc("/evoting/services/dkg/actors", eproxy.AllowCORS).Methods("OPTIONS")
+	router.HandleFunc("/evoting/services/dkg/actors/{formID}", ep.Actor).Methods("GET")
+	router.HandleFunc("/evoting/services/dkg/actors/{formID}", eproxy.ValidatePayload(ptypes.UpdateDKG{}, ep.EditDKGActor)).Methods("PUT")
+	router.HandleFunc("/evoting/services/dkg/actors/{formID}", eproxy.AllowCORS).Methods("OPTIONS")
+}
+
 func makeClient(inj node.Injector) (client, error) {
 	var service ordering.Service
 	err := inj.Resolve(&service)
@@ -3,12 +3,15 @@ package controller
 import (
 	"encoding/hex"
 	"io"
+	"strings"
 	"testing"
 
 	"golang.org/x/xerrors"
 
 	"github.com/c4dt/d-voting/internal/testing/fake"
+	eproxy "github.com/c4dt/d-voting/proxy"
 	"github.com/c4dt/d-voting/services/dkg"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/require"
 	"go.dedis.ch/dela/cli"
 	"go.dedis.ch/dela/cli/node"
@@ -139,3 +142,37 @@ func (f fakeFlags) String(name string) string {
 func (f fakeFlags) Path(name string) string {
 	return f.String(name)
 }
+
+func TestRegisterRoutes_Documented(t *testing.T) {
+	router := mux.NewRouter()
+	registerRoutes(router, eproxy.NewDKG(nil, nil, nil))
+
+	var routes []string
+
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		require.NoError(t, err)
+
+		methods, err := route.GetMethods()
+		require.NoError(t, err)
+
+		for _, method := range methods {
+			if method != "OPTIONS" {
+				routes = append(routes, method+" "+path)
+			}
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	var documented []string
+
+	for _, op := range eproxy.Operations() {
+		if strings.Contains(op, " /evoting/services/dkg/") {
+			documented = append(documented, op)
+		}
+	}
+
+	require.ElementsMatch(t, documented, routes)
+}
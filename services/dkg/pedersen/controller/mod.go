@@ -18,9 +18,11 @@ import (
 	"go.dedis.ch/dela/core/execution/native"
 	"go.dedis.ch/dela/core/ordering/cosipbft"
 	"go.dedis.ch/dela/core/ordering/cosipbft/authority"
+	"go.dedis.ch/dela/core/ordering/cosipbft/blockstore"
 	"go.dedis.ch/dela/core/store/kv"
 	"go.dedis.ch/dela/cosi/threshold"
 	"go.dedis.ch/dela/mino"
+	"go.dedis.ch/dela/serde/json"
 	"golang.org/x/xerrors"
 
 	etypes "github.com/c4dt/d-voting/contracts/evoting/types"
@@ -127,6 +129,12 @@ func (m controller) OnStart(ctx cli.Flags, inj node.Injector) error {
 		return xerrors.Errorf("no config path")
 	}
 
+	var blocks *blockstore.InDisk
+	err = inj.Resolve(&blocks)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve blockstore.InDisk: %v", err)
+	}
+
 	var db kv.DB
 
 	err = inj.Resolve(&db)
@@ -157,7 +165,7 @@ func (m controller) OnStart(ctx cli.Flags, inj node.Injector) error {
 
 	inj.Inject(dkg)
 
-	c := evoting.NewContract(access, dkg, rosterFac)
+	c := evoting.NewContract(evoting.NewAccessService(access, json.NewContext()), dkg, rosterFac, blocks)
 	evoting.RegisterContract(exec, c)
 
 	return nil
@@ -16,6 +16,7 @@ import (
 	"go.dedis.ch/dela/core/access/darc"
 	"go.dedis.ch/dela/core/execution/native"
 	"go.dedis.ch/dela/core/ordering/cosipbft"
+	"go.dedis.ch/dela/core/ordering/cosipbft/blockstore"
 	"go.dedis.ch/dela/cosi/threshold"
 )
 
@@ -77,6 +78,8 @@ func TestMinimal_OnStart(t *testing.T) {
 
 	ctx.Injector.Inject(&cosipbft.Service{})
 
+	ctx.Injector.Inject(&blockstore.InDisk{})
+
 	ctx.Injector.Inject(&fake.InMemoryDB{})
 
 	// Should miss flags
@@ -17,6 +17,7 @@ import (
 	"golang.org/x/xerrors"
 
 	eproxy "github.com/c4dt/d-voting/proxy"
+	ptypes "github.com/c4dt/d-voting/proxy/types"
 )
 
 var suite = suites.MustFind("ed25519")
@@ -96,11 +97,18 @@ func (a *RegisterHandlersAction) Execute(ctx node.Context) error {
 		return xerrors.Errorf("failed to unmarshal proxy key: %v", err)
 	}
 
+	keys, err := eproxy.ResolveKeyring(ctx.Injector, proxykey)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve keyring: %v", err)
+	}
+
 	router := mux.NewRouter()
+	router.Use(eproxy.ParseRequests(keys))
+	router.Use(eproxy.RejectReplays(keys, eproxy.ResolveReplayGuard(ctx.Injector)))
 
-	ep := eproxy.NewShuffle(actor, proxykey)
+	ep := eproxy.NewShuffle(actor, keys)
 
-	router.HandleFunc("/evoting/services/shuffle/{formID}", ep.EditShuffle).Methods("PUT")
+	registerRoutes(router, ep)
 
 	router.NotFoundHandler = http.HandlerFunc(eproxy.NotFoundHandler)
 	router.MethodNotAllowedHandler = http.HandlerFunc(eproxy.NotAllowedHandler)
@@ -112,6 +120,11 @@ func (a *RegisterHandlersAction) Execute(ctx node.Context) error {
 	return nil
 }
 
+// registerRoutes registers the handlers of the shuffle proxy on the router
+func registerRoutes(router *mux.Router, ep eproxy.Shuffle) {
+	router.HandleFunc("/evoting/services/shuffle/{formID}", eproxy.ValidatePayload(ptypes.UpdateShuffle{}, ep.EditShuffle)).Methods("PUT")
+}
+
 func makeClient(ctx node.Context) (client, error) {
 	var service ordering.Service
 	err := ctx.Injector.Resolve(&service)
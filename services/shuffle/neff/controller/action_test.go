@@ -2,8 +2,11 @@ package controller
 
 import (
 	"io"
+	"strings"
 	"testing"
 
+	eproxy "github.com/c4dt/d-voting/proxy"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/require"
 	"go.dedis.ch/dela/cli/node"
 )
@@ -21,3 +24,35 @@ func TestInitAction_Execute(t *testing.T) {
 	require.EqualError(t, err, "failed to resolve shuffle: couldn't find "+
 		"dependency for 'shuffle.Shuffle'")
 }
+
+func TestRegisterRoutes_Documented(t *testing.T) {
+	router := mux.NewRouter()
+	registerRoutes(router, eproxy.NewShuffle(nil, nil))
+
+	var routes []string
+
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		require.NoError(t, err)
+
+		methods, err := route.GetMethods()
+		require.NoError(t, err)
+
+		for _, method := range methods {
+			routes = append(routes, method+" "+path)
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	var documented []string
+
+	for _, op := range eproxy.Operations() {
+		if strings.Contains(op, " /evoting/services/shuffle/") {
+			documented = append(documented, op)
+		}
+	}
+
+	require.ElementsMatch(t, documented, routes)
+}
@@ -15,9 +15,19 @@ export const delaRouter = express.Router();
 
 initEnforcer().catch((e) => console.error(`Couldn't initialize enforcerer: ${e}`));
 
-// get payload creates a payload with a signature on it
-function getPayload(dataStr: string) {
-  let dataStrB64 = Buffer.from(dataStr).toString('base64url');
+// get payload creates a payload with a signature on it. The payload carries a
+// timestamp, a nonce and the method and path of the request, without which the
+// proxy rejects the request.
+function getPayload(dataStr: string, method: string, path: string) {
+  const data = {
+    ...JSON.parse(dataStr),
+    Timestamp: Math.floor(Date.now() / 1000),
+    Nonce: crypto.randomBytes(16).toString('hex'),
+    Method: method,
+    Path: path,
+  };
+
+  let dataStrB64 = Buffer.from(JSON.stringify(data)).toString('base64url');
   while (dataStrB64.length % 4 !== 0) {
     dataStrB64 += '=';
   }
@@ -40,16 +50,21 @@ function getPayload(dataStr: string) {
   return {
     Payload: dataStrB64,
     Signature: sign.toString('hex'),
+    // the ID of the key in the keyring of the nodes, if it isn't the default
+    // one given with --proxykey
+    KeyID: process.env.KEY_ID || undefined,
   };
 }
 
 // sendToDela signs the message and sends it to the dela proxy. It makes no
 // authentication check.
 function sendToDela(dataStr: string, req: express.Request, res: express.Response) {
-  let payload = getPayload(dataStr);
-
   // we strip the `/api` part: /api/form/xxx => /form/xxx
-  let uri = process.env.DELA_PROXY_URL + req.baseUrl.slice(4);
+  const path = req.baseUrl.slice(4);
+
+  let payload = getPayload(dataStr, req.method, path);
+
+  let uri = process.env.DELA_PROXY_URL + path;
   // boolean to check
   let redirectToDefaultProxy = true;
 
@@ -57,7 +72,7 @@ function sendToDela(dataStr: string, req: express.Request, res: express.Response
   const dkgInitRegex = /\/evoting\/services\/dkg\/actors$/;
   if (uri.match(dkgInitRegex)) {
     const dataStr2 = JSON.stringify({ FormID: req.body.FormID });
-    payload = getPayload(dataStr2);
+    payload = getPayload(dataStr2, req.method, path);
     redirectToDefaultProxy = false;
   }
 
@@ -65,7 +80,7 @@ function sendToDela(dataStr: string, req: express.Request, res: express.Response
   const dkgSetupRegex = /\/evoting\/services\/dkg\/actors\/.*$/;
   if (uri.match(dkgSetupRegex)) {
     const dataStr2 = JSON.stringify({ Action: req.body.Action });
-    payload = getPayload(dataStr2);
+    payload = getPayload(dataStr2, req.method, path);
 
     // If setup don't redirect to default proxy, if 'computePubshares' then keep
     // default proxy
@@ -82,18 +97,24 @@ function sendToDela(dataStr: string, req: express.Request, res: express.Response
       res.status(400).send('proxy undefined in body');
       return;
     }
-    uri = proxy + req.baseUrl.slice(4);
+    uri = proxy + path;
   }
 
   console.log('sending payload:', JSON.stringify(payload), 'to', uri);
 
+  // the retries of the browser carry the same key, so that the proxy doesn't
+  // submit the transaction again
+  const headers: Record<string, string> = { 'Content-Type': 'application/json' };
+  const idempotencyKey = req.get('Idempotency-Key');
+  if (idempotencyKey) {
+    headers['Idempotency-Key'] = idempotencyKey;
+  }
+
   axios({
     method: req.method as Method,
     url: uri,
     data: payload,
-    headers: {
-      'Content-Type': 'application/json',
-    },
+    headers,
   })
     .then((resp) => {
       res.status(200).send(resp.data);